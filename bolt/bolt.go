@@ -3,12 +3,14 @@ package bdb
 
 import (
 	"encoding/binary"
+	"sync"
 	"time"
 
 	"github.com/boltdb/bolt"
 	pb "github.com/gogo/protobuf/proto"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"golang.org/x/net/context"
 
 	"github.com/negz/q"
 	"github.com/negz/q/e"
@@ -18,13 +20,33 @@ import (
 var (
 	keyMetadata = []byte("meta")
 	keyLimit    = []byte("limit")
+	keyMaxBytes = []byte("maxbytes")
+	keyMaxMsg   = []byte("maxmessagebytes")
+	keyOverflow = []byte("overflow")
+	keyRetain   = []byte("retention")
+	keyIdle     = []byte("expireafteridle")
 	keyMessages = []byte("messages")
+	keyLength   = []byte("length")
+	keyBytes    = []byte("bytes")
+	keyIndex    = []byte("index")
+	keySequence = []byte("sequence")
+	keyConsumed = []byte("consumed")
 )
 
 type bdb struct {
-	meta  *q.Metadata
-	limit int
-	db    *bolt.DB
+	meta   *q.Metadata
+	config q.Config
+	db     *bolt.DB
+	m      *sync.RWMutex // Guards config, freed, added, and evict.
+
+	// freed is closed when space is freed in the queue, waking any producers
+	// blocked waiting for room. It is nil when no producers are waiting.
+	freed chan struct{}
+
+	// added is closed when a message is added to the queue, waking any
+	// consumers waiting for messages. It is nil when no consumers are waiting.
+	added chan struct{}
+	evict []func(*q.Message)
 }
 
 // An Option represents an optional argument to a new BoltDB queue.
@@ -34,7 +56,42 @@ type Option func(*bdb)
 // Unbounded queues will accept messages until they exhaust available resources.
 func Limit(l int) Option {
 	return func(b *bdb) {
-		b.limit = l
+		b.config.Limit = l
+	}
+}
+
+// MaxBytes sets the MaxBytes field of a new queue's q.Config.
+func MaxBytes(n int) Option {
+	return func(b *bdb) {
+		b.config.MaxBytes = n
+	}
+}
+
+// MaxMessageBytes sets the MaxMessageBytes field of a new queue's q.Config.
+func MaxMessageBytes(n int) Option {
+	return func(b *bdb) {
+		b.config.MaxMessageBytes = n
+	}
+}
+
+// Overflow sets the Overflow field of a new queue's q.Config.
+func Overflow(o q.Overflow) Option {
+	return func(b *bdb) {
+		b.config.Overflow = o
+	}
+}
+
+// Retention sets the Retention field of a new queue's q.Config.
+func Retention(d time.Duration) Option {
+	return func(b *bdb) {
+		b.config.Retention = d
+	}
+}
+
+// ExpireAfterIdle sets the ExpireAfterIdle field of a new queue's q.Config.
+func ExpireAfterIdle(d time.Duration) Option {
+	return func(b *bdb) {
+		b.config.ExpireAfterIdle = d
 	}
 }
 
@@ -51,7 +108,7 @@ func Tagged(t ...q.Tag) Option {
 func New(db *bolt.DB, o ...Option) (q.Queue, error) {
 	id := uuid.New()
 	meta := &q.Metadata{ID: id, Created: time.Now(), Tags: &q.Tags{}}
-	queue := &bdb{meta: meta, limit: q.Unbounded, db: db}
+	queue := &bdb{meta: meta, config: unbounded(), db: db, m: &sync.RWMutex{}}
 	for _, opt := range o {
 		opt(queue)
 	}
@@ -75,10 +132,13 @@ func New(db *bolt.DB, o ...Option) (q.Queue, error) {
 		if err := bucket.Put(keyMetadata, bmeta); err != nil {
 			return errors.Wrap(err, "cannot store metadata")
 		}
-		if err := bucket.Put(keyLimit, itob(queue.limit)); err != nil {
-			return errors.Wrap(err, "cannot store limit")
+		if err := putConfig(bucket, queue.config); err != nil {
+			return err
 		}
-		return nil
+		if err := setBytes(bucket, 0); err != nil {
+			return err
+		}
+		return setLength(bucket, 0)
 	}); err != nil {
 		return nil, errors.Wrap(err, "cannot store queue in BoltDB")
 	}
@@ -87,7 +147,8 @@ func New(db *bolt.DB, o ...Option) (q.Queue, error) {
 
 // Open an existing BoltDB backed FIFO queue.
 func Open(db *bolt.DB, id uuid.UUID) (q.Queue, error) {
-	queue := &bdb{meta: &q.Metadata{}, limit: q.Unbounded, db: db}
+	queue := &bdb{meta: &q.Metadata{}, config: unbounded(), db: db, m: &sync.RWMutex{}}
+	unindexed := false
 	if err := db.View(func(tx *bolt.Tx) error {
 		// uuid.UUID is a 16 byte array. id[:] converts it to a byte slice.
 		bucket := tx.Bucket(id[:])
@@ -109,19 +170,98 @@ func Open(db *bolt.DB, id uuid.UUID) (q.Queue, error) {
 		}
 		queue.meta = meta
 
-		blimit := bucket.Get(keyLimit)
-		if blimit == nil {
-			return errors.New("cannot read queue limit")
+		c, err := getConfig(bucket)
+		if err != nil {
+			return err
 		}
-		queue.limit = btoi(blimit)
+		queue.config = c
 
+		unindexed = bucket.Bucket(keyMessages) != nil && bucket.Bucket(keyIndex) == nil
 		return nil
 	}); err != nil {
 		return nil, errors.Wrapf(err, "cannot read queue %s from BoltDB", id)
 	}
+	if unindexed {
+		if err := db.Update(func(tx *bolt.Tx) error { return index(tx.Bucket(id[:])) }); err != nil {
+			return nil, errors.Wrapf(err, "cannot index queue %s", id)
+		}
+	}
 	return queue, nil
 }
 
+// index builds the index of message IDs to keys for a queue created before
+// messages were indexed, so that they may be got and deleted by ID.
+func index(bucket *bolt.Bucket) error {
+	msgs := bucket.Bucket(keyMessages)
+	if msgs == nil || bucket.Bucket(keyIndex) != nil {
+		return nil
+	}
+	idx, err := bucket.CreateBucket(keyIndex)
+	if err != nil {
+		return errors.Wrap(err, "cannot create index bucket")
+	}
+	return msgs.ForEach(func(k, bmsg []byte) error {
+		m, err := decode(bmsg)
+		if err != nil {
+			return err
+		}
+		return errors.Wrap(idx.Put(m.ID[:], clone(k)), "cannot index message")
+	})
+}
+
+func unbounded() q.Config {
+	return q.Config{Limit: q.Unbounded, MaxBytes: q.Unbounded, MaxMessageBytes: q.Unbounded}
+}
+
+func putConfig(b *bolt.Bucket, c q.Config) error {
+	if err := b.Put(keyLimit, itob(c.Limit)); err != nil {
+		return errors.Wrap(err, "cannot store limit")
+	}
+	if err := b.Put(keyMaxBytes, itob(c.MaxBytes)); err != nil {
+		return errors.Wrap(err, "cannot store maximum bytes")
+	}
+	if err := b.Put(keyMaxMsg, itob(c.MaxMessageBytes)); err != nil {
+		return errors.Wrap(err, "cannot store maximum message bytes")
+	}
+	if err := b.Put(keyOverflow, itob(int(c.Overflow))); err != nil {
+		return errors.Wrap(err, "cannot store overflow policy")
+	}
+	if err := b.Put(keyRetain, itob(int(c.Retention))); err != nil {
+		return errors.Wrap(err, "cannot store retention")
+	}
+	return errors.Wrap(b.Put(keyIdle, itob(int(c.ExpireAfterIdle))), "cannot store idle expiry")
+}
+
+// getConfig reads a queue's config. Queues created before byte limits existed
+// are not limited by bytes, queues created before overflow policies existed
+// reject messages when full, queues created before retention existed do not
+// retain consumed messages, and queues created before idle expiry existed never
+// expire.
+func getConfig(b *bolt.Bucket) (q.Config, error) {
+	c := unbounded()
+	blimit := b.Get(keyLimit)
+	if blimit == nil {
+		return c, errors.New("cannot read queue limit")
+	}
+	c.Limit = btoi(blimit)
+	if v := b.Get(keyMaxBytes); v != nil {
+		c.MaxBytes = btoi(v)
+	}
+	if v := b.Get(keyMaxMsg); v != nil {
+		c.MaxMessageBytes = btoi(v)
+	}
+	if v := b.Get(keyOverflow); v != nil {
+		c.Overflow = q.Overflow(btoi(v))
+	}
+	if v := b.Get(keyRetain); v != nil {
+		c.Retention = time.Duration(btoi(v))
+	}
+	if v := b.Get(keyIdle); v != nil {
+		c.ExpireAfterIdle = time.Duration(btoi(v))
+	}
+	return c, nil
+}
+
 func itob(i int) []byte {
 	b := make([]byte, 8)
 	binary.BigEndian.PutUint64(b, uint64(i))
@@ -149,10 +289,15 @@ func (b *bdb) Tags() *q.Tags {
 	return b.meta.Tags
 }
 
-// We key messages in the messages bucket using the bucket's monotonically
-// increasing NextSequence method. Thus the length of the queue should be the
-// key of the last message minus the key of the first message.
+// getLength returns the number of messages in a queue. Queues created before
+// messages could be removed from the middle of a queue do not store their
+// length. We key messages in the messages bucket using the bucket's
+// monotonically increasing NextSequence method, so the length of such queues is
+// the key of the last message minus the key of the first message.
 func getLength(b *bolt.Bucket) int {
+	if l := b.Get(keyLength); l != nil {
+		return btoi(l)
+	}
 	msgs := b.Bucket(keyMessages)
 	if msgs == nil {
 		return 0
@@ -166,7 +311,140 @@ func getLength(b *bolt.Bucket) int {
 	return (btoi(l) - btoi(f)) + 1
 }
 
+func setLength(b *bolt.Bucket, l int) error {
+	return errors.Wrap(b.Put(keyLength, itob(l)), "cannot store length")
+}
+
+func (b *bdb) Config() q.Config {
+	b.m.RLock()
+	defer b.m.RUnlock()
+	return b.config
+}
+
+// Configure persists the supplied settings before applying them.
+func (b *bdb) Configure(c q.Config) error {
+	if err := c.Validate(); err != nil {
+		return e.ErrInvalid(err)
+	}
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		id := b.ID()
+		bucket := tx.Bucket(id[:])
+		if bucket == nil {
+			return e.ErrNotFound(errors.Errorf("cannot open BoltDB bucket %s", b.ID()))
+		}
+		return putConfig(bucket, c)
+	})
+	if err != nil {
+		return errors.Wrap(err, "cannot configure queue")
+	}
+	b.m.Lock()
+	defer b.m.Unlock()
+	b.config = c
+	b.wake()
+	return nil
+}
+
+func (b *bdb) OnEvict(fn func(*q.Message)) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	b.evict = append(b.evict, fn)
+}
+
+// waiter returns a channel that is closed when space is next freed in the
+// queue.
+func (b *bdb) waiter() chan struct{} {
+	b.m.Lock()
+	defer b.m.Unlock()
+	if b.freed == nil {
+		b.freed = make(chan struct{})
+	}
+	return b.freed
+}
+
+// free wakes any producers waiting for room in the queue.
+func (b *bdb) free() {
+	b.m.Lock()
+	defer b.m.Unlock()
+	b.wake()
+}
+
+// wake must be called with the write lock held.
+func (b *bdb) wake() {
+	if b.freed == nil {
+		return
+	}
+	close(b.freed)
+	b.freed = nil
+}
+
+// Added returns a channel that is closed when a message is next added to the
+// queue.
+func (b *bdb) Added() <-chan struct{} {
+	b.m.Lock()
+	defer b.m.Unlock()
+	if b.added == nil {
+		b.added = make(chan struct{})
+	}
+	return b.added
+}
+
+// notify wakes any consumers waiting for a message to be added to the queue. It
+// is called when a transaction that added messages commits.
+func (b *bdb) notify() {
+	b.m.Lock()
+	defer b.m.Unlock()
+	if b.added == nil {
+		return
+	}
+	close(b.added)
+	b.added = nil
+}
+
+func (b *bdb) evicted(msgs []*q.Message) {
+	b.m.RLock()
+	fns := b.evict
+	b.m.RUnlock()
+	for _, m := range msgs {
+		for _, fn := range fns {
+			fn(m)
+		}
+	}
+}
+
+// getBytes returns the total size of the payloads of the messages in a queue.
+// Queues created before stored sizes existed derive it from their messages.
+func getBytes(b *bolt.Bucket) (int, error) {
+	if n := b.Get(keyBytes); n != nil {
+		return btoi(n), nil
+	}
+	msgs := b.Bucket(keyMessages)
+	if msgs == nil {
+		return 0, nil
+	}
+	n := 0
+	err := msgs.ForEach(func(_, bmsg []byte) error {
+		m, err := decode(bmsg)
+		if err != nil {
+			return err
+		}
+		n += len(m.Payload)
+		return nil
+	})
+	return n, err
+}
+
+func setBytes(b *bolt.Bucket, n int) error {
+	return errors.Wrap(b.Put(keyBytes, itob(n)), "cannot store size")
+}
+
 func (b *bdb) Add(m *q.Message) error {
+	return b.AddContext(context.Background(), m)
+}
+
+// AddContext stores the supplied message. Messages evicted under the
+// DropOldest overflow policy are removed in the same transaction that adds the
+// new message.
+func (b *bdb) AddContext(ctx context.Context, m *q.Message) error {
 	pmsg, err := proto.FromMessage(m)
 	if err != nil {
 		return errors.Wrap(err, "cannot marshal message to protobuf")
@@ -175,37 +453,282 @@ func (b *bdb) Add(m *q.Message) error {
 	if err != nil {
 		return errors.Wrap(err, "cannot marshal message to bytes")
 	}
-	err = b.db.Update(func(tx *bolt.Tx) error {
+	for {
+		evicted, freed, err := b.add(ctx, m, bmsg)
+		if err != nil {
+			return errors.Wrap(err, "cannot store message in queue")
+		}
+		if freed == nil {
+			b.evicted(evicted)
+			return nil
+		}
+		select {
+		case <-freed:
+		case <-ctx.Done():
+			err := e.WithReason(e.ErrFull(errors.Wrapf(ctx.Err(), "queue %s remained full", b.ID())), e.QUEUE_FULL)
+			return errors.Wrap(err, "cannot store message in queue")
+		}
+	}
+}
+
+// add attempts to store the supplied message, returning any messages that were
+// evicted to make room for it. If the queue is full and producers should block
+// add stores nothing and returns a channel that will be closed when room may
+// have been freed. Producers never block when adding a message as part of a
+// transfer, because no room can be freed until the transfer's transaction is
+// committed.
+func (b *bdb) add(ctx context.Context, m *q.Message, bmsg []byte) ([]*q.Message, chan struct{}, error) {
+	var evicted []*q.Message
+	var freed chan struct{}
+	joined := b.tx(ctx) != nil
+	err := b.update(ctx, func(tx *bolt.Tx) error {
 		id := b.ID()
 		bucket := tx.Bucket(id[:])
 		if bucket == nil {
 			return e.ErrNotFound(errors.Errorf("cannot open BoltDB bucket %s", b.ID()))
 		}
 
-		length := getLength(bucket)
-		if (b.limit != q.Unbounded) && (length >= b.limit) {
-			return e.ErrFull(errors.Errorf("queue %s has reached limit of %d messages", b.ID(), b.limit))
-		}
-
 		msgs, berr := bucket.CreateBucketIfNotExists(keyMessages)
 		if berr != nil {
 			return errors.Wrap(berr, "cannot create messages bucket")
 		}
 
-		// This returns an error only if the Tx is closed or not writeable,
-		// which can't happen inside an update.
-		i, _ := msgs.NextSequence()
-		if perr := msgs.Put(itob(int(i)), bmsg); perr != nil {
+		idx, berr := bucket.CreateBucketIfNotExists(keyIndex)
+		if berr != nil {
+			return errors.Wrap(berr, "cannot create index bucket")
+		}
+
+		c := b.Config()
+		length := getLength(bucket)
+		bytes, err := getBytes(bucket)
+		if err != nil {
+			return err
+		}
+		err = c.Admit(b.ID(), length, bytes, m)
+
+		// Neither evicting nor waiting will help if the message would not fit
+		// in an empty queue.
+		if e.IsFull(err) && c.Admit(b.ID(), 0, 0, m) == nil {
+			switch c.Overflow {
+			case q.DropOldest:
+				for e.IsFull(err) {
+					k, old, ferr := first(msgs, nil)
+					if ferr != nil {
+						return ferr
+					}
+					if rerr := remove(bucket, msgs, k, old); rerr != nil {
+						return rerr
+					}
+					evicted = append(evicted, old)
+					length--
+					bytes -= len(old.Payload)
+					err = c.Admit(b.ID(), length, bytes, m)
+				}
+			case q.Block:
+				if joined {
+					break
+				}
+				// We're inside an update, so no other transaction can free
+				// space before we're waiting for it.
+				freed = b.waiter()
+				return nil
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		i, serr := nextSequence(bucket, msgs)
+		if serr != nil {
+			return serr
+		}
+		k := itob(int(i))
+		if perr := msgs.Put(k, bmsg); perr != nil {
 			return errors.Wrap(perr, "cannot store message")
 		}
-		return nil
+		if perr := idx.Put(m.ID[:], k); perr != nil {
+			return errors.Wrap(perr, "cannot index message")
+		}
+		if serr := setBytes(bucket, bytes+len(m.Payload)); serr != nil {
+			return serr
+		}
+		tx.OnCommit(b.notify)
+		return setLength(bucket, length+1)
 	})
-	return errors.Wrap(err, "cannot store message in queue")
+	if err != nil {
+		return nil, nil, err
+	}
+	return evicted, freed, nil
+}
+
+// txKey is the context key under which a transfer's transaction is stored.
+type txKey struct{}
+
+// tx returns the read-write transaction carried by the supplied context, if it
+// belongs to this queue's database.
+func (b *bdb) tx(ctx context.Context) *bolt.Tx {
+	tx, ok := ctx.Value(txKey{}).(*bolt.Tx)
+	if !ok || tx.DB() != b.db || !tx.Writable() {
+		return nil
+	}
+	return tx
+}
+
+// update calls fn in the transaction carried by the supplied context, or in a
+// new read-write transaction if the context carries no transaction for this
+// queue's database.
+func (b *bdb) update(ctx context.Context, fn func(*bolt.Tx) error) error {
+	if tx := b.tx(ctx); tx != nil {
+		return fn(tx)
+	}
+	return b.db.Update(fn)
+}
+
+// CanTransfer returns true if the supplied queue is another queue stored in the
+// same BoltDB database.
+func (b *bdb) CanTransfer(dst q.Queue) bool {
+	d, ok := q.Unwrap(dst).(*bdb)
+	return ok && d.db == b.db && d.ID() != b.ID()
+}
+
+// Transfer transfers each message in its own transaction. Messages are added to
+// the supplied queue, rather than the unwrapped queue, so that any wrappers may
+// account for them. The supplied queue joins the transaction via its context.
+func (b *bdb) Transfer(ctx context.Context, dst q.Queue, max int, move bool, t ...q.Tag) ([]*q.Message, error) {
+	transferred := []*q.Message{}
+	next := 0
+	for max == q.Unbounded || len(transferred) < max {
+		var msg *q.Message
+		err := b.db.Update(func(tx *bolt.Tx) error {
+			id := b.ID()
+			bucket := tx.Bucket(id[:])
+			if bucket == nil {
+				return e.ErrNotFound(errors.Errorf("cannot open BoltDB bucket %s", b.ID()))
+			}
+			msgs := bucket.Bucket(keyMessages)
+			if msgs == nil {
+				return nil
+			}
+			c := msgs.Cursor()
+			for k, bmsg := c.Seek(itob(next)); k != nil; k, bmsg = c.Next() {
+				m, err := decode(bmsg)
+				if err != nil {
+					return err
+				}
+				if !m.Tags.ContainsAll(t...) {
+					continue
+				}
+				next = btoi(k) + 1
+				if move {
+					if err := remove(bucket, msgs, k, m); err != nil {
+						return err
+					}
+				} else {
+					m = m.Copy()
+				}
+				if err := dst.AddContext(context.WithValue(ctx, txKey{}, tx), m); err != nil {
+					return err
+				}
+				msg = m
+				return nil
+			}
+			return nil
+		})
+		if err != nil {
+			return transferred, errors.Wrap(err, "cannot transfer message")
+		}
+		if msg == nil {
+			break
+		}
+		transferred = append(transferred, msg)
+	}
+	if move && len(transferred) > 0 {
+		b.free()
+	}
+	return transferred, nil
+}
+
+// getSequence returns the sequence of the most recently added message. The
+// sequence is stored in the queue bucket rather than the messages bucket so that
+// it survives purges and message keys are never reused. Queues that predate
+// stored sequences fall back to the messages bucket's sequence, which can only
+// be read by incrementing it. getSequence must therefore be called inside an
+// update.
+func getSequence(bucket, msgs *bolt.Bucket) uint64 {
+	if s := bucket.Get(keySequence); s != nil {
+		return uint64(btoi(s))
+	}
+	if msgs == nil {
+		return 0
+	}
+	// This returns an error only if the Tx is closed or not writeable, which
+	// can't happen inside an update.
+	i, _ := msgs.NextSequence()
+	return i - 1
+}
+
+func nextSequence(bucket, msgs *bolt.Bucket) (uint64, error) {
+	i := getSequence(bucket, msgs) + 1
+	return i, errors.Wrap(bucket.Put(keySequence, itob(int(i))), "cannot store sequence")
+}
+
+// first returns the key and content of the first message in the supplied
+// messages bucket that is tagged with all of the supplied tags. It returns a nil
+// key if no message matches.
+func first(msgs *bolt.Bucket, t []q.Tag) ([]byte, *q.Message, error) {
+	c := msgs.Cursor()
+	for k, bmsg := c.First(); k != nil; k, bmsg = c.Next() {
+		msg, err := decode(bmsg)
+		if err != nil {
+			return nil, nil, err
+		}
+		if msg.Tags.ContainsAll(t...) {
+			return k, msg, nil
+		}
+	}
+	return nil, nil, nil
+}
+
+// clone returns a copy of the supplied bytes.
+func clone(b []byte) []byte {
+	return append([]byte(nil), b...)
+}
+
+func decode(bmsg []byte) (*q.Message, error) {
+	pmsg := &proto.Message{}
+	if err := pb.Unmarshal(bmsg, pmsg); err != nil {
+		return nil, errors.Wrap(err, "cannot unmarshal message from bytes to protobuf")
+	}
+	msg, err := proto.ToMessage(pmsg)
+	return msg, errors.Wrap(err, "cannot convert message from protobuf")
+}
+
+func (b *bdb) notFound(t []q.Tag) error {
+	if len(t) == 0 {
+		return e.WithReason(e.ErrNotFound(errors.Errorf("queue %s is empty", b.ID())), e.QUEUE_EMPTY)
+	}
+	return e.WithReason(e.ErrNotFound(errors.Errorf("queue %s has no messages tagged %v", b.ID(), t)), e.QUEUE_EMPTY)
 }
 
 func (b *bdb) Pop() (*q.Message, error) {
+	m, err := b.pop()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot pop from queue")
+	}
+	return m, nil
+}
+
+func (b *bdb) PopMatching(t ...q.Tag) (*q.Message, error) {
+	m, err := b.pop(t...)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot pop matching message from queue")
+	}
+	return m, nil
+}
+
+func (b *bdb) pop(t ...q.Tag) (*q.Message, error) {
 	var msg *q.Message
-	if err := b.db.Update(func(tx *bolt.Tx) error {
+	err := b.db.Update(func(tx *bolt.Tx) error {
 		id := b.ID()
 		bucket := tx.Bucket(id[:])
 		if bucket == nil {
@@ -214,34 +737,279 @@ func (b *bdb) Pop() (*q.Message, error) {
 
 		msgs := bucket.Bucket(keyMessages)
 		if msgs == nil {
-			return e.ErrNotFound(errors.Errorf("queue %s is empty", b.ID()))
+			return b.notFound(t)
+		}
+		k, m, err := first(msgs, t)
+		if err != nil {
+			return err
 		}
-		k, bmsg := msgs.Cursor().First()
 		if k == nil {
-			return e.ErrNotFound(errors.Errorf("queue %s is empty", b.ID()))
+			return b.notFound(t)
 		}
-		if err := msgs.Delete(k); err != nil {
-			return errors.Wrap(err, "cannot delete message")
+		msg = m
+		// Bolt keys and values are only valid until the transaction that
+		// read them modifies the bucket.
+		k, bmsg := clone(k), clone(msgs.Get(k))
+		if err := remove(bucket, msgs, k, m); err != nil {
+			return err
 		}
-		pmsg := &proto.Message{}
-		if err := pb.Unmarshal(bmsg, pmsg); err != nil {
-			return errors.Wrap(err, "cannot unmarshal message from bytes to protobuf")
+		return b.retain(bucket, k, bmsg)
+	})
+	if err != nil {
+		return nil, err
+	}
+	b.free()
+	return msg, nil
+}
+
+// retain stores the supplied consumed message under its original key if the
+// queue has a retention, and discards any retained messages that have outlived
+// it.
+func (b *bdb) retain(bucket *bolt.Bucket, k, bmsg []byte) error {
+	r := b.Config().Retention
+	if r == 0 && bucket.Bucket(keyConsumed) == nil {
+		return nil
+	}
+	consumed, err := bucket.CreateBucketIfNotExists(keyConsumed)
+	if err != nil {
+		return errors.Wrap(err, "cannot create consumed messages bucket")
+	}
+	if r > 0 {
+		if err := consumed.Put(k, bmsg); err != nil {
+			return errors.Wrap(err, "cannot retain consumed message")
 		}
-		var err error
-		msg, err = proto.ToMessage(pmsg)
+	}
+	return expire(consumed, r, time.Now())
+}
+
+// expire discards retained consumed messages created longer than the supplied
+// retention before now. Messages are expired in key order, stopping at the
+// first that has not expired.
+func expire(consumed *bolt.Bucket, r time.Duration, now time.Time) error {
+	c := consumed.Cursor()
+	for k, bmsg := c.First(); k != nil; k, bmsg = c.First() {
+		m, err := decode(bmsg)
 		if err != nil {
-			return errors.Wrap(err, "cannot convert message from protobuf")
+			return err
+		}
+		if now.Sub(m.Created) <= r {
+			return nil
 		}
+		if err := c.Delete(); err != nil {
+			return errors.Wrap(err, "cannot expire consumed message")
+		}
+	}
+	return nil
+}
+
+// Seek ignores limits; replaying messages may leave the queue fuller than its
+// limits allow, in which case it rejects new messages until enough have been
+// consumed.
+func (b *bdb) Seek(offset uint64) ([]*q.Message, error) {
+	replayed, err := b.seek(func(k uint64, _ *q.Message) bool { return k >= offset })
+	return replayed, errors.Wrapf(err, "cannot seek to offset %d", offset)
+}
+
+func (b *bdb) SeekTime(t time.Time) ([]*q.Message, error) {
+	replayed, err := b.seek(func(_ uint64, m *q.Message) bool { return !m.Created.Before(t) })
+	return replayed, errors.Wrapf(err, "cannot seek to %s", t)
+}
+
+// seek replays retained consumed messages, starting with the first for which
+// from returns true.
+func (b *bdb) seek(from func(offset uint64, m *q.Message) bool) ([]*q.Message, error) {
+	var replayed []*q.Message
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		id := b.ID()
+		bucket := tx.Bucket(id[:])
+		if bucket == nil {
+			return e.ErrNotFound(errors.Errorf("cannot open BoltDB bucket %s", b.ID()))
+		}
+		consumed := bucket.Bucket(keyConsumed)
+		if consumed == nil {
+			return nil
+		}
+		if err := expire(consumed, b.Config().Retention, time.Now()); err != nil {
+			return err
+		}
+		msgs, err := bucket.CreateBucketIfNotExists(keyMessages)
+		if err != nil {
+			return errors.Wrap(err, "cannot create messages bucket")
+		}
+		idx, err := bucket.CreateBucketIfNotExists(keyIndex)
+		if err != nil {
+			return errors.Wrap(err, "cannot create index bucket")
+		}
+		length := getLength(bucket)
+		bytes, err := getBytes(bucket)
+		if err != nil {
+			return err
+		}
+
+		// Bolt cursors may skip keys when the key under them is deleted, so we
+		// collect the keys to replay before we replay them.
+		replay := [][]byte{}
+		found := false
+		c := consumed.Cursor()
+		for k, bmsg := c.First(); k != nil; k, bmsg = c.Next() {
+			if !found {
+				m, err := decode(bmsg)
+				if err != nil {
+					return err
+				}
+				found = from(uint64(btoi(k)), m)
+			}
+			if found {
+				replay = append(replay, clone(k))
+			}
+		}
+		for _, k := range replay {
+			bmsg := clone(consumed.Get(k))
+			m, err := decode(bmsg)
+			if err != nil {
+				return err
+			}
+			if err := msgs.Put(k, bmsg); err != nil {
+				return errors.Wrap(err, "cannot replay message")
+			}
+			if err := idx.Put(m.ID[:], k); err != nil {
+				return errors.Wrap(err, "cannot index message")
+			}
+			if err := consumed.Delete(k); err != nil {
+				return errors.Wrap(err, "cannot delete replayed message")
+			}
+			length++
+			bytes += len(m.Payload)
+			replayed = append(replayed, m)
+		}
+		if len(replayed) > 0 {
+			tx.OnCommit(b.notify)
+		}
+		if err := setBytes(bucket, bytes); err != nil {
+			return err
+		}
+		return setLength(bucket, length)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return replayed, nil
+}
+
+// remove deletes the supplied message, stored under the supplied key, from the
+// supplied queue and messages buckets.
+func remove(bucket, msgs *bolt.Bucket, k []byte, m *q.Message) error {
+	// Read the length and size before deleting the message, in case this queue
+	// predates stored lengths and sizes and we need to derive them from its
+	// messages.
+	length := getLength(bucket)
+	bytes, err := getBytes(bucket)
+	if err != nil {
+		return err
+	}
+	if err := msgs.Delete(k); err != nil {
+		return errors.Wrap(err, "cannot delete message")
+	}
+	if idx := bucket.Bucket(keyIndex); idx != nil {
+		if err := idx.Delete(m.ID[:]); err != nil {
+			return errors.Wrap(err, "cannot delete message from index")
+		}
+	}
+	if err := setBytes(bucket, bytes-len(m.Payload)); err != nil {
+		return err
+	}
+	return setLength(bucket, length-1)
+}
+
+// lookup returns the key and content of the message with the supplied ID in the
+// supplied queue bucket. It returns a nil key if no such message exists.
+func lookup(bucket *bolt.Bucket, id uuid.UUID) ([]byte, *q.Message, error) {
+	idx := bucket.Bucket(keyIndex)
+	msgs := bucket.Bucket(keyMessages)
+	if idx == nil || msgs == nil {
+		return nil, nil, nil
+	}
+	k := idx.Get(id[:])
+	if k == nil {
+		return nil, nil, nil
+	}
+	bmsg := msgs.Get(k)
+	if bmsg == nil {
+		return nil, nil, nil
+	}
+	m, err := decode(bmsg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return k, m, nil
+}
+
+func (b *bdb) Get(id uuid.UUID) (*q.Message, error) {
+	var msg *q.Message
+	err := b.db.View(func(tx *bolt.Tx) error {
+		qid := b.ID()
+		bucket := tx.Bucket(qid[:])
+		if bucket == nil {
+			return e.ErrNotFound(errors.Errorf("cannot open BoltDB bucket %s", b.ID()))
+		}
+		k, m, err := lookup(bucket, id)
+		if err != nil {
+			return err
+		}
+		if k == nil {
+			return e.WithReason(e.ErrNotFound(errors.Errorf("queue %s has no message %s", b.ID(), id)), e.MESSAGE_NOT_FOUND)
+		}
+		msg = m
 		return nil
-	}); err != nil {
-		return nil, errors.Wrap(err, "cannot pop from queue")
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get message from queue")
 	}
 	return msg, nil
 }
 
+func (b *bdb) Delete(id uuid.UUID) error {
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		qid := b.ID()
+		bucket := tx.Bucket(qid[:])
+		if bucket == nil {
+			return e.ErrNotFound(errors.Errorf("cannot open BoltDB bucket %s", b.ID()))
+		}
+		k, m, err := lookup(bucket, id)
+		if err != nil {
+			return err
+		}
+		if k == nil {
+			return e.WithReason(e.ErrNotFound(errors.Errorf("queue %s has no message %s", b.ID(), id)), e.MESSAGE_NOT_FOUND)
+		}
+		return remove(bucket, bucket.Bucket(keyMessages), k, m)
+	})
+	if err != nil {
+		return errors.Wrap(err, "cannot delete message from queue")
+	}
+	b.free()
+	return nil
+}
+
 func (b *bdb) Peek() (*q.Message, error) {
+	m, err := b.peek()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot peek into queue")
+	}
+	return m, nil
+}
+
+func (b *bdb) PeekMatching(t ...q.Tag) (*q.Message, error) {
+	m, err := b.peek(t...)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot peek at matching message in queue")
+	}
+	return m, nil
+}
+
+func (b *bdb) peek(t ...q.Tag) (*q.Message, error) {
 	var msg *q.Message
-	if err := b.db.View(func(tx *bolt.Tx) error {
+	err := b.db.View(func(tx *bolt.Tx) error {
 		id := b.ID()
 		bucket := tx.Bucket(id[:])
 		if bucket == nil {
@@ -249,24 +1017,108 @@ func (b *bdb) Peek() (*q.Message, error) {
 		}
 		msgs := bucket.Bucket(keyMessages)
 		if msgs == nil {
-			return e.ErrNotFound(errors.Errorf("queue %s is empty", b.ID()))
+			return b.notFound(t)
+		}
+		k, m, err := first(msgs, t)
+		if err != nil {
+			return err
 		}
-		k, bmsg := msgs.Cursor().First()
 		if k == nil {
-			return e.ErrNotFound(errors.Errorf("queue %s is empty", b.ID()))
+			return b.notFound(t)
+		}
+		msg = m
+		return nil
+	})
+	return msg, err
+}
+
+// Purge drops and recreates the messages bucket. The message sequence is
+// preserved so that offsets are never reused.
+func (b *bdb) Purge() (int, error) {
+	var n int
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		id := b.ID()
+		bucket := tx.Bucket(id[:])
+		if bucket == nil {
+			return e.ErrNotFound(errors.Errorf("cannot open BoltDB bucket %s", b.ID()))
 		}
-		pmsg := &proto.Message{}
-		if err := pb.Unmarshal(bmsg, pmsg); err != nil {
-			return errors.Wrap(err, "cannot unmarshal message from bytes to protobuf")
+		n = getLength(bucket)
+
+		msgs := bucket.Bucket(keyMessages)
+		if err := bucket.Put(keySequence, itob(int(getSequence(bucket, msgs)))); err != nil {
+			return errors.Wrap(err, "cannot store sequence")
 		}
-		var err error
-		msg, err = proto.ToMessage(pmsg)
-		if err != nil {
-			return errors.Wrap(err, "cannot convert message from protobuf")
+		if msgs != nil {
+			if err := bucket.DeleteBucket(keyMessages); err != nil {
+				return errors.Wrap(err, "cannot delete messages bucket")
+			}
 		}
-		return nil
-	}); err != nil {
-		return nil, errors.Wrap(err, "cannot peek into queue")
+		if bucket.Bucket(keyIndex) != nil {
+			if err := bucket.DeleteBucket(keyIndex); err != nil {
+				return errors.Wrap(err, "cannot delete index bucket")
+			}
+		}
+		if _, err := bucket.CreateBucket(keyMessages); err != nil {
+			return errors.Wrap(err, "cannot create messages bucket")
+		}
+		if err := setBytes(bucket, 0); err != nil {
+			return err
+		}
+		return setLength(bucket, 0)
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "cannot purge queue")
 	}
-	return msg, nil
+	b.free()
+	return n, nil
+}
+
+// Destroy deletes the queue's bucket, along with its metadata and any pending or
+// retained messages. The queue may not be used once destroyed; producers waiting
+// for room are woken and fail to add their messages.
+func (b *bdb) Destroy(force bool) error {
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		id := b.ID()
+		bucket := tx.Bucket(id[:])
+		if bucket == nil {
+			return e.ErrNotFound(errors.Errorf("cannot open BoltDB bucket %s", b.ID()))
+		}
+		if !force && getLength(bucket) > 0 {
+			return q.NotEmpty(b.ID())
+		}
+		return errors.Wrap(tx.DeleteBucket(id[:]), "cannot delete queue bucket")
+	})
+	if err != nil {
+		return errors.Wrap(err, "cannot destroy queue")
+	}
+	b.free()
+	return nil
+}
+
+// Walk visits messages in key order. Message keys are sequence numbers assigned
+// when they were added, so they double as offsets.
+func (b *bdb) Walk(offset uint64, fn q.WalkFunc) error {
+	err := b.db.View(func(tx *bolt.Tx) error {
+		id := b.ID()
+		bucket := tx.Bucket(id[:])
+		if bucket == nil {
+			return e.ErrNotFound(errors.Errorf("cannot open BoltDB bucket %s", b.ID()))
+		}
+		msgs := bucket.Bucket(keyMessages)
+		if msgs == nil {
+			return nil
+		}
+		c := msgs.Cursor()
+		for k, bmsg := c.Seek(itob(int(offset))); k != nil; k, bmsg = c.Next() {
+			msg, err := decode(bmsg)
+			if err != nil {
+				return err
+			}
+			if !fn(uint64(btoi(k)), msg) {
+				return nil
+			}
+		}
+		return nil
+	})
+	return errors.Wrap(err, "cannot walk queue")
 }
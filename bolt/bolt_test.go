@@ -1,6 +1,7 @@
 package bdb
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -9,105 +10,780 @@ import (
 	"time"
 
 	"github.com/boltdb/bolt"
+	"github.com/google/uuid"
+	"golang.org/x/net/context"
+
 	"github.com/negz/q"
 	"github.com/negz/q/e"
+	"github.com/negz/q/memory"
+	"github.com/negz/q/metrics"
+	"github.com/negz/q/queuetest"
 )
 
-var boltTests = []struct {
-	messages []*q.Message
-	limit    int
-}{
-	{
-		messages: []*q.Message{
-			q.NewMessage([]byte("salyut"), q.Tagged(q.Tag{"country", "USSR"})),
-			q.NewMessage([]byte("DOS")),
-			q.NewMessage([]byte("kosmos")),
-			q.NewMessage([]byte("skylab")),
-			q.NewMessage([]byte("mir")),
-			q.NewMessage([]byte("iss")),
-			q.NewMessage([]byte("tiangong")),
-		},
-		limit: q.Unbounded,
-	},
-	{
-		messages: []*q.Message{
-			q.NewMessage([]byte("salyut")),
-			q.NewMessage([]byte("DOS")),
-			q.NewMessage([]byte("kosmos")),
-		},
-		limit: 2,
-	},
-	{
-		messages: []*q.Message{},
-		limit:    q.Unbounded,
-	},
-}
+// TestBoltReopen tests that messages persist when a queue is reopened.
+func TestBoltReopen(t *testing.T) {
+	tmp, err := ioutil.TempDir(".", "qtestbolt")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir(): %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	path := filepath.Join(tmp, "db")
+	opts := &bolt.Options{Timeout: 1 * time.Second}
+	db, err := bolt.Open(path, 0600, opts)
+	if err != nil {
+		t.Fatalf("bolt.Open(%v, %v, %v): %v", path, 0600, opts, err)
+	}
+	defer db.Close()
 
-func TestBolt(t *testing.T) {
-	for _, tt := range boltTests {
-		tmp, err := ioutil.TempDir(".", "qtestbolt")
+	queue, err := New(db, Limit(2))
+	if err != nil {
+		t.Fatalf("New(%v, Limit(%v)): %v", db, 2, err)
+	}
+	messages := []*q.Message{
+		q.NewMessage([]byte("salyut"), q.Tagged(q.Tag{Key: "country", Value: "USSR"})),
+		q.NewMessage([]byte("skylab")),
+	}
+	for _, m := range messages {
+		if err := queue.Add(m); err != nil {
+			t.Fatalf("queue.Add(%v): %v", m, err)
+		}
+	}
+
+	queue, err = Open(db, queue.ID())
+	if err != nil {
+		t.Fatalf("Open(%v, %v): %v", db, queue.ID(), err)
+	}
+	if got := queue.Config().Limit; got != 2 {
+		t.Errorf("queue.Config().Limit: want 2, got %v", got)
+	}
+	for _, want := range messages {
+		m, err := queue.Pop()
 		if err != nil {
-			t.Fatalf("ioutil.TempDir(): %v", err)
+			t.Errorf("queue.Pop(): %v", err)
+			continue
 		}
-		defer os.RemoveAll(tmp)
+		if !reflect.DeepEqual(want.Tags.Get(), m.Tags.Get()) || m.ID != want.ID || string(m.Payload) != string(want.Payload) {
+			t.Errorf("queue.Pop(): want %v, got %v", want, m)
+		}
+	}
+}
 
-		path := filepath.Join(tmp, "db")
-		opts := &bolt.Options{Timeout: 1 * time.Second}
-		db, err := bolt.Open(path, 0600, opts)
+func TestBoltSuite(t *testing.T) {
+	tmp, err := ioutil.TempDir(".", "qtestbolt")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir(): %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	path := filepath.Join(tmp, "db")
+	opts := &bolt.Options{Timeout: 1 * time.Second}
+	db, err := bolt.Open(path, 0600, opts)
+	if err != nil {
+		t.Fatalf("bolt.Open(%v, %v, %v): %v", path, 0600, opts, err)
+	}
+	defer db.Close()
+
+	// The suite calls this from its own subtests, so it panics rather than
+	// calling t.Fatalf.
+	queuetest.RunQueueSuite(t, func(limit int) q.Queue {
+		queue, err := New(db, Limit(limit))
 		if err != nil {
-			t.Fatalf("bolt.Open(%v, %v, %v): %v", path, 0600, opts, err)
+			panic(fmt.Sprintf("New(%v, Limit(%v)): %v", db, limit, err))
 		}
-		defer db.Close()
+		return queue
+	})
+}
+
+func TestBoltMatching(t *testing.T) {
+	tmp, err := ioutil.TempDir(".", "qtestbolt")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir(): %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	path := filepath.Join(tmp, "db")
+	opts := &bolt.Options{Timeout: 1 * time.Second}
+	db, err := bolt.Open(path, 0600, opts)
+	if err != nil {
+		t.Fatalf("bolt.Open(%v, %v, %v): %v", path, 0600, opts, err)
+	}
+	defer db.Close()
+
+	eu := q.Tag{Key: "region", Value: "eu"}
+	us := q.Tag{Key: "region", Value: "us"}
+	messages := []*q.Message{
+		q.NewMessage([]byte("vostok"), q.Tagged(us)),
+		q.NewMessage([]byte("ariane"), q.Tagged(eu)),
+		q.NewMessage([]byte("vega"), q.Tagged(eu)),
+		q.NewMessage([]byte("atlas"), q.Tagged(us)),
+	}
+
+	limit := len(messages)
+	queue, err := New(db, Limit(limit))
+	if err != nil {
+		t.Fatalf("New(%v, Limit(%v)): %v", db, limit, err)
+	}
+	for _, m := range messages {
+		if err := queue.Add(m); err != nil {
+			t.Fatalf("queue.Add(%v): %v", m, err)
+		}
+	}
 
-		queue, err := New(db, Limit(tt.limit))
+	t.Run("PeekMatching", func(t *testing.T) {
+		m, err := queue.PeekMatching(eu)
 		if err != nil {
-			t.Fatalf("New(%v, Limit(%v)): %v", db, tt.limit, err)
+			t.Errorf("queue.PeekMatching(%v): %v", eu, err)
+			return
 		}
+		if m.ID != messages[1].ID {
+			t.Errorf("queue.PeekMatching(%v): want message %v, got %v", eu, messages[1].ID, m.ID)
+		}
+	})
 
-		queue, err = Open(db, queue.ID())
+	t.Run("PopMatching", func(t *testing.T) {
+		m, err := queue.PopMatching(eu)
 		if err != nil {
-			t.Fatalf("Open(%v, %v): %v", db, queue.ID(), tt.limit, err)
+			t.Errorf("queue.PopMatching(%v): %v", eu, err)
+			return
+		}
+		if m.ID != messages[1].ID {
+			t.Errorf("queue.PopMatching(%v): want message %v, got %v", eu, messages[1].ID, m.ID)
+		}
+	})
+
+	t.Run("AddAfterPopMatching", func(t *testing.T) {
+		// Removing a message from the middle of a full queue should make room
+		// for exactly one more message.
+		m := q.NewMessage([]byte("soyuz"))
+		if err := queue.Add(m); err != nil {
+			t.Errorf("queue.Add(%v): %v", m, err)
 		}
+		m = q.NewMessage([]byte("proton"))
+		if err := queue.Add(m); !e.IsFull(err) {
+			t.Errorf("queue.Add(%v): want error satisfying e.IsFull(), got %v", m, err)
+		}
+	})
 
-		t.Run("Add", func(t *testing.T) {
-			for _, m := range tt.messages {
-				if err := queue.Add(m); err != nil {
-					if len(tt.messages) > tt.limit && e.IsFull(err) {
-						continue
-					}
-					t.Errorf("queue.Add(%v): %v", m, err)
-				}
+	t.Run("Walk", func(t *testing.T) {
+		// The message popped from the middle of the queue should be skipped,
+		// and walking should stop before the message added after it.
+		want := []uuid.UUID{messages[0].ID, messages[2].ID, messages[3].ID}
+		got := []uuid.UUID{}
+		var last uint64
+		err := queue.Walk(0, func(o uint64, m *q.Message) bool {
+			if o <= last {
+				t.Errorf("queue.Walk(): offset %v does not follow %v", o, last)
 			}
+			last = o
+			got = append(got, m.ID)
+			return len(got) < len(want)
 		})
+		if err != nil {
+			t.Errorf("queue.Walk(): %v", err)
+		}
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("queue.Walk(): want %v, got %v", want, got)
+		}
+	})
 
-		t.Run("Peek", func(t *testing.T) {
-			m, err := queue.Peek()
+	t.Run("Pop", func(t *testing.T) {
+		for _, want := range []*q.Message{messages[0], messages[2], messages[3]} {
+			m, err := queue.Pop()
 			if err != nil {
-				if len(tt.messages) < 1 && e.IsNotFound(err) {
-					return
-				}
-				t.Errorf("queue.Peek(): %v", err)
-				return
+				t.Errorf("queue.Pop(): %v", err)
+				continue
 			}
-			if !reflect.DeepEqual(tt.messages[0], m) {
-				t.Errorf("queue.Peek(): want %v, got %v", tt.messages[0], m)
+			if m.ID != want.ID {
+				t.Errorf("queue.Pop(): want message %v, got %v", want.ID, m.ID)
 			}
-		})
+		}
+		if _, err := queue.PopMatching(eu); !e.IsNotFound(err) {
+			t.Errorf("queue.PopMatching(%v): want error satisfying e.IsNotFound(), got %v", eu, err)
+		}
+	})
+}
+
+func TestBoltGetDelete(t *testing.T) {
+	tmp, err := ioutil.TempDir(".", "qtestbolt")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir(): %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	path := filepath.Join(tmp, "db")
+	opts := &bolt.Options{Timeout: 1 * time.Second}
+	db, err := bolt.Open(path, 0600, opts)
+	if err != nil {
+		t.Fatalf("bolt.Open(%v, %v, %v): %v", path, 0600, opts, err)
+	}
+	defer db.Close()
+
+	messages := []*q.Message{
+		q.NewMessage([]byte("gemini")),
+		q.NewMessage([]byte("apollo")),
+		q.NewMessage([]byte("skylab")),
+	}
+
+	limit := len(messages)
+	queue, err := New(db, Limit(limit))
+	if err != nil {
+		t.Fatalf("New(%v, Limit(%v)): %v", db, limit, err)
+	}
+	for _, m := range messages {
+		if err := queue.Add(m); err != nil {
+			t.Fatalf("queue.Add(%v): %v", m, err)
+		}
+	}
+
+	t.Run("Get", func(t *testing.T) {
+		m, err := queue.Get(messages[1].ID)
+		if err != nil {
+			t.Errorf("queue.Get(%v): %v", messages[1].ID, err)
+			return
+		}
+		if m.ID != messages[1].ID {
+			t.Errorf("queue.Get(%v): want message %v, got %v", messages[1].ID, messages[1].ID, m.ID)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		id := messages[1].ID
+		if err := queue.Delete(id); err != nil {
+			t.Errorf("queue.Delete(%v): %v", id, err)
+		}
+		if _, err := queue.Get(id); !e.IsNotFound(err) {
+			t.Errorf("queue.Get(%v): want error satisfying e.IsNotFound(), got %v", id, err)
+		}
+		if err := queue.Delete(id); !e.IsNotFound(err) {
+			t.Errorf("queue.Delete(%v): want error satisfying e.IsNotFound(), got %v", id, err)
+		}
+	})
+
+	t.Run("AddAfterDelete", func(t *testing.T) {
+		m := q.NewMessage([]byte("shuttle"))
+		if err := queue.Add(m); err != nil {
+			t.Errorf("queue.Add(%v): %v", m, err)
+		}
+	})
+
+	t.Run("Pop", func(t *testing.T) {
+		m, err := queue.Pop()
+		if err != nil {
+			t.Fatalf("queue.Pop(): %v", err)
+		}
+		if m.ID != messages[0].ID {
+			t.Errorf("queue.Pop(): want message %v, got %v", messages[0].ID, m.ID)
+		}
+		// Popped messages should be removed from the index.
+		if _, err := queue.Get(m.ID); !e.IsNotFound(err) {
+			t.Errorf("queue.Get(%v): want error satisfying e.IsNotFound(), got %v", m.ID, err)
+		}
+	})
+}
+
+func TestBoltOpenUnindexed(t *testing.T) {
+	tmp, err := ioutil.TempDir(".", "qtestbolt")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir(): %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	path := filepath.Join(tmp, "db")
+	opts := &bolt.Options{Timeout: 1 * time.Second}
+	db, err := bolt.Open(path, 0600, opts)
+	if err != nil {
+		t.Fatalf("bolt.Open(%v, %v, %v): %v", path, 0600, opts, err)
+	}
+	defer db.Close()
+
+	queue, err := New(db)
+	if err != nil {
+		t.Fatalf("New(%v): %v", db, err)
+	}
+	m := q.NewMessage([]byte("vostok"))
+	if err := queue.Add(m); err != nil {
+		t.Fatalf("queue.Add(%v): %v", m, err)
+	}
+
+	// Queues created before messages were indexed have no index bucket.
+	id := queue.ID()
+	if err := db.Update(func(tx *bolt.Tx) error { return tx.Bucket(id[:]).DeleteBucket(keyIndex) }); err != nil {
+		t.Fatalf("db.Update(): %v", err)
+	}
+
+	opened, err := Open(db, id)
+	if err != nil {
+		t.Fatalf("Open(%v, %v): %v", db, id, err)
+	}
+	if _, err := opened.Get(m.ID); err != nil {
+		t.Errorf("opened.Get(%v): %v", m.ID, err)
+	}
+	if err := opened.Delete(m.ID); err != nil {
+		t.Errorf("opened.Delete(%v): %v", m.ID, err)
+	}
+}
 
-		t.Run("Pop", func(t *testing.T) {
-			for i := range tt.messages {
-				m, err := queue.Pop()
-				if err != nil {
-					if i == len(tt.messages)-1 && e.IsNotFound(err) {
-						continue
-					}
-					t.Errorf("queue.Pop(): %v", err)
-					continue
-				}
-				if !reflect.DeepEqual(tt.messages[i], m) {
-					t.Errorf("queue.Pop(): want %v, got %v", tt.messages[i], m)
-				}
+func TestBoltPurge(t *testing.T) {
+	tmp, err := ioutil.TempDir(".", "qtestbolt")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir(): %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	path := filepath.Join(tmp, "db")
+	opts := &bolt.Options{Timeout: 1 * time.Second}
+	db, err := bolt.Open(path, 0600, opts)
+	if err != nil {
+		t.Fatalf("bolt.Open(%v, %v, %v): %v", path, 0600, opts, err)
+	}
+	defer db.Close()
+
+	messages := []*q.Message{
+		q.NewMessage([]byte("sputnik")),
+		q.NewMessage([]byte("explorer")),
+	}
+
+	limit := len(messages)
+	queue, err := New(db, Limit(limit))
+	if err != nil {
+		t.Fatalf("New(%v, Limit(%v)): %v", db, limit, err)
+	}
+	for _, m := range messages {
+		if err := queue.Add(m); err != nil {
+			t.Fatalf("queue.Add(%v): %v", m, err)
+		}
+	}
+	var last uint64
+	queue.Walk(0, func(o uint64, _ *q.Message) bool {
+		last = o
+		return true
+	})
+
+	n, err := queue.Purge()
+	if err != nil {
+		t.Fatalf("queue.Purge(): %v", err)
+	}
+	if n != len(messages) {
+		t.Errorf("queue.Purge(): want %v purged, got %v", len(messages), n)
+	}
+	if _, err := queue.Peek(); !e.IsNotFound(err) {
+		t.Errorf("queue.Peek(): want error satisfying e.IsNotFound(), got %v", err)
+	}
+	if _, err := queue.Get(messages[0].ID); !e.IsNotFound(err) {
+		t.Errorf("queue.Get(%v): want error satisfying e.IsNotFound(), got %v", messages[0].ID, err)
+	}
+
+	// A purged queue should accept messages up to its limit, and never reuse
+	// offsets.
+	for _, m := range messages {
+		if err := queue.Add(m); err != nil {
+			t.Errorf("queue.Add(%v): %v", m, err)
+		}
+	}
+	queue.Walk(0, func(o uint64, _ *q.Message) bool {
+		if o <= last {
+			t.Errorf("queue.Walk(): offset %v reused after purge", o)
+		}
+		return true
+	})
+}
+
+func TestBoltDestroy(t *testing.T) {
+	tmp, err := ioutil.TempDir(".", "qtestbolt")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir(): %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	path := filepath.Join(tmp, "db")
+	opts := &bolt.Options{Timeout: 1 * time.Second}
+	db, err := bolt.Open(path, 0600, opts)
+	if err != nil {
+		t.Fatalf("bolt.Open(%v, %v, %v): %v", path, 0600, opts, err)
+	}
+	defer db.Close()
+
+	queue, err := New(db)
+	if err != nil {
+		t.Fatalf("New(%v): %v", db, err)
+	}
+	m := q.NewMessage([]byte("skylab"))
+	if err := queue.Add(m); err != nil {
+		t.Fatalf("queue.Add(%v): %v", m, err)
+	}
+
+	if err := q.Destroy(queue, false); !e.IsFailedPrecondition(err) {
+		t.Errorf("q.Destroy(queue, false): want error satisfying e.IsFailedPrecondition(), got %v", err)
+	}
+	if err := q.Destroy(queue, true); err != nil {
+		t.Fatalf("q.Destroy(queue, true): %v", err)
+	}
+	if _, err := Open(db, queue.ID()); !e.IsNotFound(err) {
+		t.Errorf("Open(%v, %v): want error satisfying e.IsNotFound(), got %v", db, queue.ID(), err)
+	}
+	if err := q.Destroy(queue, true); !e.IsNotFound(err) {
+		t.Errorf("q.Destroy(queue, true): want error satisfying e.IsNotFound(), got %v", err)
+	}
+}
+
+func TestBoltConfigure(t *testing.T) {
+	tmp, err := ioutil.TempDir(".", "qtestbolt")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir(): %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	path := filepath.Join(tmp, "db")
+	opts := &bolt.Options{Timeout: 1 * time.Second}
+	db, err := bolt.Open(path, 0600, opts)
+	if err != nil {
+		t.Fatalf("bolt.Open(%v, %v, %v): %v", path, 0600, opts, err)
+	}
+	defer db.Close()
+
+	messages := []*q.Message{
+		q.NewMessage([]byte("mercury")),
+		q.NewMessage([]byte("gemini")),
+	}
+
+	limit := len(messages)
+	queue, err := New(db, Limit(limit))
+	if err != nil {
+		t.Fatalf("New(%v, Limit(%v)): %v", db, limit, err)
+	}
+	for _, m := range messages {
+		if err := queue.Add(m); err != nil {
+			t.Fatalf("queue.Add(%v): %v", m, err)
+		}
+	}
+
+	c := queue.Config()
+	c.Limit = 1
+	if err := queue.Configure(c); err != nil {
+		t.Fatalf("queue.Configure(%v): %v", c, err)
+	}
+
+	// Settings should persist across reopening the queue.
+	reopened, err := Open(db, queue.ID())
+	if err != nil {
+		t.Fatalf("Open(%v, %v): %v", db, queue.ID(), err)
+	}
+	if got := reopened.Config(); !reflect.DeepEqual(c, got) {
+		t.Errorf("reopened.Config(): want %v, got %v", c, got)
+	}
+
+	m := q.NewMessage([]byte("apollo"))
+	if err := reopened.Add(m); !e.IsFull(err) {
+		t.Errorf("reopened.Add(%v): want error satisfying e.IsFull(), got %v", m, err)
+	}
+	for _, want := range messages {
+		got, err := reopened.Pop()
+		if err != nil {
+			t.Errorf("reopened.Pop(): %v", err)
+			continue
+		}
+		if got.ID != want.ID {
+			t.Errorf("reopened.Pop(): want message %v, got %v", want.ID, got.ID)
+		}
+	}
+}
+
+func TestBoltMaxBytes(t *testing.T) {
+	tmp, err := ioutil.TempDir(".", "qtestbolt")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir(): %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	path := filepath.Join(tmp, "db")
+	opts := &bolt.Options{Timeout: 1 * time.Second}
+	db, err := bolt.Open(path, 0600, opts)
+	if err != nil {
+		t.Fatalf("bolt.Open(%v, %v, %v): %v", path, 0600, opts, err)
+	}
+	defer db.Close()
+
+	queue, err := New(db, MaxBytes(10), MaxMessageBytes(6))
+	if err != nil {
+		t.Fatalf("New(%v, MaxBytes(10), MaxMessageBytes(6)): %v", db, err)
+	}
+
+	big := q.NewMessage([]byte("vanguard"))
+	if err := queue.Add(big); !e.IsInvalid(err) {
+		t.Errorf("queue.Add(%v): want error satisfying e.IsInvalid(), got %v", big, err)
+	}
+
+	first := q.NewMessage([]byte("luna"))
+	second := q.NewMessage([]byte("venera"))
+	for _, m := range []*q.Message{first, second} {
+		if err := queue.Add(m); err != nil {
+			t.Fatalf("queue.Add(%v): %v", m, err)
+		}
+	}
+	third := q.NewMessage([]byte("mars"))
+	if err := queue.Add(third); !e.IsFull(err) {
+		t.Errorf("queue.Add(%v): want error satisfying e.IsFull(), got %v", third, err)
+	}
+
+	// Deleting a message should free its bytes.
+	if err := queue.Delete(second.ID); err != nil {
+		t.Fatalf("queue.Delete(%v): %v", second.ID, err)
+	}
+	if err := queue.Add(third); err != nil {
+		t.Errorf("queue.Add(%v): %v", third, err)
+	}
+
+	// Byte limits should persist across reopening the queue.
+	reopened, err := Open(db, queue.ID())
+	if err != nil {
+		t.Fatalf("Open(%v, %v): %v", db, queue.ID(), err)
+	}
+	want := q.Config{Limit: q.Unbounded, MaxBytes: 10, MaxMessageBytes: 6}
+	if got := reopened.Config(); !reflect.DeepEqual(want, got) {
+		t.Errorf("reopened.Config(): want %v, got %v", want, got)
+	}
+}
+
+func TestBoltOverflow(t *testing.T) {
+	tmp, err := ioutil.TempDir(".", "qtestbolt")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir(): %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	path := filepath.Join(tmp, "db")
+	opts := &bolt.Options{Timeout: 1 * time.Second}
+	db, err := bolt.Open(path, 0600, opts)
+	if err != nil {
+		t.Fatalf("bolt.Open(%v, %v, %v): %v", path, 0600, opts, err)
+	}
+	defer db.Close()
+
+	t.Run("DropOldest", func(t *testing.T) {
+		queue, err := New(db, Limit(2), Overflow(q.DropOldest))
+		if err != nil {
+			t.Fatalf("New(%v, Limit(2), Overflow(q.DropOldest)): %v", db, err)
+		}
+		evicted := []uuid.UUID{}
+		queue.OnEvict(func(m *q.Message) { evicted = append(evicted, m.ID) })
+
+		messages := []*q.Message{
+			q.NewMessage([]byte("luna")),
+			q.NewMessage([]byte("venera")),
+			q.NewMessage([]byte("mars")),
+		}
+		for _, m := range messages {
+			if err := queue.Add(m); err != nil {
+				t.Fatalf("queue.Add(%v): %v", m, err)
 			}
+		}
+		if want := []uuid.UUID{messages[0].ID}; !reflect.DeepEqual(want, evicted) {
+			t.Errorf("queue.OnEvict(): want %v evicted, got %v", want, evicted)
+		}
+		m, err := queue.Peek()
+		if err != nil {
+			t.Fatalf("queue.Peek(): %v", err)
+		}
+		if m.ID != messages[1].ID {
+			t.Errorf("queue.Peek(): want %v, got %v", messages[1].ID, m.ID)
+		}
+
+		// Overflow policies should persist across reopening the queue.
+		reopened, err := Open(db, queue.ID())
+		if err != nil {
+			t.Fatalf("Open(%v, %v): %v", db, queue.ID(), err)
+		}
+		if got := reopened.Config().Overflow; got != q.DropOldest {
+			t.Errorf("reopened.Config().Overflow: want %v, got %v", q.DropOldest, got)
+		}
+	})
+
+	t.Run("Block", func(t *testing.T) {
+		queue, err := New(db, Limit(1), Overflow(q.Block))
+		if err != nil {
+			t.Fatalf("New(%v, Limit(1), Overflow(q.Block)): %v", db, err)
+		}
+		first := q.NewMessage([]byte("luna"))
+		if err := queue.Add(first); err != nil {
+			t.Fatalf("queue.Add(%v): %v", first, err)
+		}
+
+		second := q.NewMessage([]byte("venera"))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := queue.AddContext(ctx, second); !e.IsFull(err) {
+			t.Errorf("queue.AddContext(%v): want error satisfying e.IsFull(), got %v", second, err)
+		}
+
+		added := make(chan error)
+		go func() { added <- queue.AddContext(context.Background(), second) }()
+		if _, err := queue.Pop(); err != nil {
+			t.Fatalf("queue.Pop(): %v", err)
+		}
+		if err := <-added; err != nil {
+			t.Fatalf("queue.AddContext(%v): %v", second, err)
+		}
+		m, err := queue.Peek()
+		if err != nil {
+			t.Fatalf("queue.Peek(): %v", err)
+		}
+		if m.ID != second.ID {
+			t.Errorf("queue.Peek(): want %v, got %v", second.ID, m.ID)
+		}
+	})
+}
+
+func TestBoltTransfer(t *testing.T) {
+	tmp, err := ioutil.TempDir(".", "qtestbolt")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir(): %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	path := filepath.Join(tmp, "db")
+	opts := &bolt.Options{Timeout: 1 * time.Second}
+	db, err := bolt.Open(path, 0600, opts)
+	if err != nil {
+		t.Fatalf("bolt.Open(%v, %v, %v): %v", path, 0600, opts, err)
+	}
+	defer db.Close()
+
+	src, err := New(db)
+	if err != nil {
+		t.Fatalf("New(%v): %v", db, err)
+	}
+	messages := []*q.Message{
+		q.NewMessage([]byte("explorer")),
+		q.NewMessage([]byte("vanguard")),
+		q.NewMessage([]byte("pioneer")),
+	}
+	for _, m := range messages {
+		if err := src.Add(m); err != nil {
+			t.Fatalf("src.Add(%v): %v", m, err)
+		}
+	}
+	d, err := New(db, Limit(2))
+	if err != nil {
+		t.Fatalf("New(%v, Limit(2)): %v", db, err)
+	}
+
+	// Transfers should pass through wrapped destination queues.
+	dst := metrics.Queue(d, metrics.NewNop())
+	if !src.(q.Transferer).CanTransfer(dst) {
+		t.Fatalf("src.CanTransfer(%v): want true, got false", dst.ID())
+	}
+	if src.(q.Transferer).CanTransfer(memory.New()) {
+		t.Errorf("src.CanTransfer(memory.New()): want false, got true")
+	}
+
+	// Messages that cannot be added to the destination should remain in the
+	// source.
+	n, err := q.Transfer(context.Background(), src, dst, q.Unbounded, true)
+	if !e.IsFull(err) {
+		t.Errorf("q.Transfer(): want error satisfying e.IsFull(), got %v", err)
+	}
+	if n != 2 {
+		t.Errorf("q.Transfer(): want 2 transferred, got %v", n)
+	}
+	m, err := src.Pop()
+	if err != nil {
+		t.Fatalf("src.Pop(): %v", err)
+	}
+	if m.ID != messages[2].ID {
+		t.Errorf("src.Pop(): want %v, got %v", messages[2].ID, m.ID)
+	}
+	for _, want := range messages[:2] {
+		got, err := dst.Pop()
+		if err != nil {
+			t.Fatalf("dst.Pop(): %v", err)
+		}
+		if got.ID != want.ID {
+			t.Errorf("dst.Pop(): want %v, got %v", want.ID, got.ID)
+		}
+	}
+}
+
+func TestBoltSeek(t *testing.T) {
+	tmp, err := ioutil.TempDir(".", "qtestbolt")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir(): %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	path := filepath.Join(tmp, "db")
+	opts := &bolt.Options{Timeout: 1 * time.Second}
+	db, err := bolt.Open(path, 0600, opts)
+	if err != nil {
+		t.Fatalf("bolt.Open(%v, %v, %v): %v", path, 0600, opts, err)
+	}
+	defer db.Close()
+
+	newQueue := func(t *testing.T, o ...Option) (q.Queue, []uint64) {
+		queue, err := New(db, o...)
+		if err != nil {
+			t.Fatalf("New(): %v", err)
+		}
+		expired := q.NewMessage([]byte("vanguard"))
+		expired.Created = time.Now().Add(-2 * time.Hour)
+		messages := []*q.Message{expired, q.NewMessage([]byte("explorer")), q.NewMessage([]byte("pioneer"))}
+		for _, m := range messages {
+			if err := queue.Add(m); err != nil {
+				t.Fatalf("queue.Add(%s): %v", m.Payload, err)
+			}
+		}
+		offsets := []uint64{}
+		queue.Walk(0, func(o uint64, _ *q.Message) bool {
+			offsets = append(offsets, o)
+			return true
 		})
+		for range messages {
+			if _, err := queue.Pop(); err != nil {
+				t.Fatalf("queue.Pop(): %v", err)
+			}
+		}
+		return queue, offsets
 	}
+
+	t.Run("Offset", func(t *testing.T) {
+		queue, offsets := newQueue(t, Retention(time.Hour))
+		replayed, err := queue.(q.Seeker).Seek(offsets[0])
+		if err != nil {
+			t.Fatalf("queue.Seek(%v): %v", offsets[0], err)
+		}
+		if len(replayed) != 2 {
+			t.Errorf("queue.Seek(%v): want 2 unexpired messages replayed, got %v", offsets[0], len(replayed))
+		}
+		for _, want := range []string{"explorer", "pioneer"} {
+			m, err := queue.Pop()
+			if err != nil {
+				t.Fatalf("queue.Pop(): %v", err)
+			}
+			if string(m.Payload) != want {
+				t.Errorf("queue.Pop(): want %v, got %s", want, m.Payload)
+			}
+		}
+	})
+
+	t.Run("Time", func(t *testing.T) {
+		queue, _ := newQueue(t, Retention(3*time.Hour))
+		since := time.Now().Add(-1 * time.Hour)
+		replayed, err := queue.(q.Seeker).SeekTime(since)
+		if err != nil {
+			t.Fatalf("queue.SeekTime(%v): %v", since, err)
+		}
+		if len(replayed) != 2 {
+			t.Errorf("queue.SeekTime(%v): want 2 messages replayed, got %v", since, len(replayed))
+		}
+		if m, err := queue.Peek(); err != nil || string(m.Payload) != "explorer" {
+			t.Errorf("queue.Peek(): want explorer, got %v, %v", m, err)
+		}
+	})
+
+	t.Run("NoRetention", func(t *testing.T) {
+		queue, _ := newQueue(t)
+		if replayed, err := queue.(q.Seeker).Seek(0); err != nil || len(replayed) != 0 {
+			t.Errorf("queue.Seek(0): want 0 replayed, got %v, %v", len(replayed), err)
+		}
+	})
 }
@@ -0,0 +1,184 @@
+// Package certs provides TLS configurations whose certificates are reloaded
+// from disk when they change, so that they may be rotated without a restart.
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// modified returns the latest modification time of the supplied files.
+func modified(files ...string) (time.Time, error) {
+	latest := time.Time{}
+	for _, f := range files {
+		fi, err := os.Stat(f)
+		if err != nil {
+			return latest, errors.Wrapf(err, "cannot stat %s", f)
+		}
+		if fi.ModTime().After(latest) {
+			latest = fi.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// A keyPair is a certificate and private key that are reloaded when either of
+// their files is modified.
+type keyPair struct {
+	cert, key string
+
+	mx       *sync.Mutex
+	modified time.Time
+	c        *tls.Certificate
+}
+
+func newKeyPair(cert, key string) (*keyPair, error) {
+	if cert == "" || key == "" {
+		return nil, errors.New("both a certificate and a key are required")
+	}
+	kp := &keyPair{cert: cert, key: key, mx: &sync.Mutex{}}
+	_, err := kp.get()
+	return kp, err
+}
+
+// get returns the key pair, reloading it if it has been modified since it was
+// last loaded. The previously loaded key pair is returned if reloading fails,
+// for example because only one of the files has been rewritten so far.
+func (kp *keyPair) get() (*tls.Certificate, error) {
+	kp.mx.Lock()
+	defer kp.mx.Unlock()
+	if err := kp.reload(); err != nil && kp.c == nil {
+		return nil, err
+	}
+	return kp.c, nil
+}
+
+func (kp *keyPair) reload() error {
+	m, err := modified(kp.cert, kp.key)
+	if err != nil {
+		return err
+	}
+	if !m.After(kp.modified) {
+		return nil
+	}
+	c, err := tls.LoadX509KeyPair(kp.cert, kp.key)
+	if err != nil {
+		return errors.Wrapf(err, "cannot load key pair %s and %s", kp.cert, kp.key)
+	}
+	kp.c = &c
+	kp.modified = m
+	return nil
+}
+
+// A pool of certificate authorities that is reloaded when its file is
+// modified.
+type pool struct {
+	file string
+
+	mx       *sync.Mutex
+	modified time.Time
+	p        *x509.CertPool
+}
+
+func newPool(file string) (*pool, error) {
+	p := &pool{file: file, mx: &sync.Mutex{}}
+	_, err := p.get()
+	return p, err
+}
+
+// get returns the pool, reloading it if it has been modified since it was last
+// loaded. The previously loaded pool is returned if reloading fails.
+func (p *pool) get() (*x509.CertPool, error) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	if err := p.reload(); err != nil && p.p == nil {
+		return nil, err
+	}
+	return p.p, nil
+}
+
+func (p *pool) reload() error {
+	m, err := modified(p.file)
+	if err != nil {
+		return err
+	}
+	if !m.After(p.modified) {
+		return nil
+	}
+	pem, err := ioutil.ReadFile(p.file)
+	if err != nil {
+		return errors.Wrapf(err, "cannot read %s", p.file)
+	}
+	cp := x509.NewCertPool()
+	if !cp.AppendCertsFromPEM(pem) {
+		return errors.Errorf("cannot find PEM encoded certificates in %s", p.file)
+	}
+	p.p = cp
+	p.modified = m
+	return nil
+}
+
+// Server returns a TLS configuration for a server that presents the supplied
+// certificate and key. Clients must present a certificate signed by one of the
+// certificate authorities in the supplied clientCA file, unless it is empty.
+// All files are reloaded when they change.
+func Server(cert, key, clientCA string) (*tls.Config, error) {
+	kp, err := newKeyPair(cert, key)
+	if err != nil {
+		return nil, err
+	}
+	getCertificate := func(*tls.ClientHelloInfo) (*tls.Certificate, error) { return kp.get() }
+	c := &tls.Config{GetCertificate: getCertificate}
+	if clientCA == "" {
+		return c, nil
+	}
+
+	ca, err := newPool(clientCA)
+	if err != nil {
+		return nil, err
+	}
+	c.ClientAuth = tls.RequireAndVerifyClientCert
+	c.ClientCAs, _ = ca.get()
+	c.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		cas, err := ca.get()
+		return &tls.Config{
+			GetCertificate: getCertificate,
+			ClientAuth:     tls.RequireAndVerifyClientCert,
+			ClientCAs:      cas,
+			NextProtos:     []string{"h2"},
+		}, err
+	}
+	return c, nil
+}
+
+// Client returns a TLS configuration for a client. Servers must present a
+// certificate signed by one of the certificate authorities in the supplied ca
+// file, or by the system's certificate authorities if it is empty. The ca file
+// is read once. The client presents the supplied certificate and key when they
+// are not empty, and reloads them when they change. serverName overrides the
+// name used to verify the server's certificate when it is not empty.
+func Client(ca, cert, key, serverName string) (*tls.Config, error) {
+	c := &tls.Config{ServerName: serverName}
+	if ca != "" {
+		p, err := newPool(ca)
+		if err != nil {
+			return nil, err
+		}
+		c.RootCAs, _ = p.get()
+	}
+	if cert == "" && key == "" {
+		return c, nil
+	}
+	kp, err := newKeyPair(cert, key)
+	if err != nil {
+		return nil, err
+	}
+	c.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) { return kp.get() }
+	return c, nil
+}
@@ -0,0 +1,154 @@
+package certs
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/negz/q/test/fixtures"
+)
+
+// handshake performs a TLS handshake between the supplied server and client
+// configurations, returning the common name of the server's certificate.
+func handshake(server, client *tls.Config) (string, error) {
+	l, err := tls.Listen("tcp", "localhost:0", server)
+	if err != nil {
+		return "", err
+	}
+	defer l.Close()
+
+	errs := make(chan error, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			errs <- err
+			return
+		}
+		defer conn.Close()
+		errs <- conn.(*tls.Conn).Handshake()
+	}()
+
+	c, err := tls.Dial("tcp", l.Addr().String(), client)
+	if err != nil {
+		return "", err
+	}
+	defer c.Close()
+	if err := <-errs; err != nil {
+		return "", err
+	}
+	return c.ConnectionState().PeerCertificates[0].Subject.CommonName, nil
+}
+
+func TestCerts(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "qtestcerts")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir(): %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	ca, err := fixtures.NewCA(tmp, "ca")
+	if err != nil {
+		t.Fatalf("fixtures.NewCA(): %v", err)
+	}
+	server, err := ca.Issue(tmp, "server")
+	if err != nil {
+		t.Fatalf("ca.Issue(): %v", err)
+	}
+	client, err := ca.Issue(tmp, "client")
+	if err != nil {
+		t.Fatalf("ca.Issue(): %v", err)
+	}
+
+	t.Run("TLS", func(t *testing.T) {
+		sc, err := Server(server.CertFile, server.KeyFile, "")
+		if err != nil {
+			t.Fatalf("Server(): %v", err)
+		}
+		cc, err := Client(ca.CertFile, "", "", "localhost")
+		if err != nil {
+			t.Fatalf("Client(): %v", err)
+		}
+		if _, err := handshake(sc, cc); err != nil {
+			t.Errorf("handshake(): %v", err)
+		}
+	})
+
+	t.Run("MutualTLS", func(t *testing.T) {
+		sc, err := Server(server.CertFile, server.KeyFile, ca.CertFile)
+		if err != nil {
+			t.Fatalf("Server(): %v", err)
+		}
+		cc, err := Client(ca.CertFile, client.CertFile, client.KeyFile, "localhost")
+		if err != nil {
+			t.Fatalf("Client(): %v", err)
+		}
+		if _, err := handshake(sc, cc); err != nil {
+			t.Errorf("handshake(): %v", err)
+		}
+
+		anonymous, err := Client(ca.CertFile, "", "", "localhost")
+		if err != nil {
+			t.Fatalf("Client(): %v", err)
+		}
+		if _, err := handshake(sc, anonymous); err == nil {
+			t.Errorf("handshake(): want error for client without a certificate")
+		}
+	})
+
+	t.Run("Reload", func(t *testing.T) {
+		sc, err := Server(server.CertFile, server.KeyFile, "")
+		if err != nil {
+			t.Fatalf("Server(): %v", err)
+		}
+		cc, err := Client(ca.CertFile, "", "", "localhost")
+		if err != nil {
+			t.Fatalf("Client(): %v", err)
+		}
+		if name, err := handshake(sc, cc); err != nil || name != "server" {
+			t.Fatalf("handshake(): want server certificate, got %v, %v", name, err)
+		}
+
+		// Overwrite the server's certificate, making sure its modification
+		// time changes regardless of the filesystem's resolution.
+		before, _ := sc.GetCertificate(nil)
+		if _, err := ca.Issue(tmp, "server"); err != nil {
+			t.Fatalf("ca.Issue(): %v", err)
+		}
+		later := time.Now().Add(time.Minute)
+		for _, f := range []string{server.CertFile, server.KeyFile} {
+			if err := os.Chtimes(f, later, later); err != nil {
+				t.Fatalf("os.Chtimes(%v): %v", f, err)
+			}
+		}
+		if _, err := handshake(sc, cc); err != nil {
+			t.Errorf("handshake(): %v", err)
+		}
+		after, _ := sc.GetCertificate(nil)
+		if before == after {
+			t.Errorf("sc.GetCertificate(): want reloaded certificate")
+		}
+
+		// A certificate that cannot be loaded is ignored.
+		if err := ioutil.WriteFile(server.KeyFile, []byte("not a key"), 0600); err != nil {
+			t.Fatalf("ioutil.WriteFile(%v): %v", server.KeyFile, err)
+		}
+		later = later.Add(time.Minute)
+		if err := os.Chtimes(server.KeyFile, later, later); err != nil {
+			t.Fatalf("os.Chtimes(%v): %v", server.KeyFile, err)
+		}
+		if _, err := handshake(sc, cc); err != nil {
+			t.Errorf("handshake(): want previous certificate to be used, got %v", err)
+		}
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		if _, err := Server(server.CertFile, "", ""); err == nil {
+			t.Errorf("Server(): want error for missing key")
+		}
+		if _, err := Client("/nonexistent", "", "", ""); err == nil {
+			t.Errorf("Client(): want error for missing CA")
+		}
+	})
+}
@@ -0,0 +1,42 @@
+package certs
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	kingpin "gopkg.in/alecthomas/kingpin.v2"
+)
+
+// ClientFlags are the command line flags with which a gRPC client connects to
+// the queue server using TLS.
+type ClientFlags struct {
+	tls        *bool
+	ca         *string
+	cert       *string
+	key        *string
+	serverName *string
+}
+
+// NewClientFlags adds flags that configure a TLS connection to the queue server
+// to the supplied application.
+func NewClientFlags(app *kingpin.Application) *ClientFlags {
+	return &ClientFlags{
+		tls:        app.Flag("tls", "Connect to the queue server using TLS. Implied by the other TLS flags.").Bool(),
+		ca:         app.Flag("tls-ca", "PEM encoded certificate authorities with which to verify the queue server. Defaults to the system's.").ExistingFile(),
+		cert:       app.Flag("tls-cert", "PEM encoded client certificate to present to the queue server. Reloaded when it changes.").ExistingFile(),
+		key:        app.Flag("tls-key", "PEM encoded private key of the client certificate. Reloaded when it changes.").ExistingFile(),
+		serverName: app.Flag("tls-server-name", "Name with which to verify the queue server's certificate. Defaults to its address.").String(),
+	}
+}
+
+// DialOption returns a gRPC dial option that connects using TLS if any of the
+// flags were set, or insecurely if none were.
+func (f *ClientFlags) DialOption() (grpc.DialOption, error) {
+	if !*f.tls && *f.ca == "" && *f.cert == "" && *f.key == "" && *f.serverName == "" {
+		return grpc.WithInsecure(), nil
+	}
+	c, err := Client(*f.ca, *f.cert, *f.key, *f.serverName)
+	if err != nil {
+		return nil, err
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(c)), nil
+}
@@ -0,0 +1,205 @@
+package chaos
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+	qmanager "github.com/negz/q/manager"
+	"github.com/negz/q/memory"
+	"github.com/negz/q/queuetest"
+)
+
+func TestChaosSuite(t *testing.T) {
+	newManager := func() q.Manager { return Manager(qmanager.New(), NewInjector(Faults{})) }
+	newQueue := func(limit int) q.Queue { return memory.New(memory.Limit(limit)) }
+	t.Run("Manager", func(t *testing.T) { queuetest.RunManagerSuite(t, newManager, newQueue) })
+	t.Run("Queue", func(t *testing.T) {
+		queuetest.RunQueueSuite(t, func(limit int) q.Queue { return Queue(newQueue(limit), NewInjector(Faults{})) })
+	})
+}
+
+func TestChaosErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		f    Faults
+		is   func(error) bool
+	}{
+		{name: "Full", f: Faults{Full: 1}, is: e.IsFull},
+		{name: "NotFound", f: Faults{NotFound: 1}, is: e.IsNotFound},
+		{name: "Unknown", f: Faults{Unknown: 1}, is: func(err error) bool { return err != nil && !e.IsFull(err) && !e.IsNotFound(err) }},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			i := NewInjector(Faults{})
+			queue := Queue(memory.New(), i)
+			if err := queue.Add(q.NewMessage([]byte("apollo"))); err != nil {
+				t.Fatalf("queue.Add(): %v", err)
+			}
+
+			i.Set(tt.f)
+			if err := queue.Add(q.NewMessage([]byte("gemini"))); !tt.is(err) {
+				t.Errorf("queue.Add(): want injected %s error, got %v", tt.name, err)
+			}
+			if _, err := queue.Pop(); !tt.is(err) {
+				t.Errorf("queue.Pop(): want injected %s error, got %v", tt.name, err)
+			}
+
+			i.Set(Faults{})
+			m, err := queue.Pop()
+			if err != nil {
+				t.Fatalf("queue.Pop(): %v", err)
+			}
+			if string(m.Payload) != "apollo" {
+				t.Errorf("queue.Pop(): want apollo, got %s", m.Payload)
+			}
+		})
+	}
+}
+
+func TestChaosDeliveries(t *testing.T) {
+	t.Run("Drop", func(t *testing.T) {
+		queue := Queue(memory.New(), NewInjector(Faults{Drop: 1}))
+		if err := queue.Add(q.NewMessage([]byte("mercury"))); err != nil {
+			t.Fatalf("queue.Add(): %v", err)
+		}
+		if _, err := queue.Pop(); e.ReasonOf(err) != e.QUEUE_EMPTY {
+			t.Errorf("queue.Pop(): want error with reason %v for dropped message, got %v", e.QUEUE_EMPTY, err)
+		}
+		if _, err := queue.Peek(); !e.IsNotFound(err) {
+			t.Errorf("queue.Peek(): want dropped message to be consumed, got %v", err)
+		}
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		i := NewInjector(Faults{Duplicate: 1})
+		queue := Queue(memory.New(), i)
+		if err := queue.Add(q.NewMessage([]byte("mercury"))); err != nil {
+			t.Fatalf("queue.Add(): %v", err)
+		}
+		first, err := queue.Pop()
+		if err != nil {
+			t.Fatalf("queue.Pop(): %v", err)
+		}
+		i.Set(Faults{})
+		second, err := queue.Pop()
+		if err != nil {
+			t.Fatalf("queue.Pop(): %v", err)
+		}
+		if first.ID != second.ID {
+			t.Errorf("queue.Pop(): want duplicate delivery of %v, got %v", first.ID, second.ID)
+		}
+	})
+}
+
+func TestChaosLatency(t *testing.T) {
+	queue := Queue(memory.New(), NewInjector(Faults{Latency: time.Hour}))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := queue.AddContext(ctx, q.NewMessage([]byte("voskhod"))); err == nil {
+		t.Errorf("queue.AddContext(): want error when context is done during injected latency")
+	}
+
+	queue = Queue(memory.New(), NewInjector(Faults{Latency: 20 * time.Millisecond}))
+	start := time.Now()
+	if err := queue.Add(q.NewMessage([]byte("voskhod"))); err != nil {
+		t.Errorf("queue.Add(): %v", err)
+	}
+	if took := time.Since(start); took < 20*time.Millisecond {
+		t.Errorf("queue.Add(): want at least 20ms injected latency, took %v", took)
+	}
+}
+
+func TestChaosManager(t *testing.T) {
+	i := NewInjector(Faults{})
+	m := Manager(qmanager.New(), i)
+	queue := memory.New()
+	if err := m.Add(queue); err != nil {
+		t.Fatalf("m.Add(): %v", err)
+	}
+
+	got, err := m.Get(queue.ID())
+	if err != nil {
+		t.Fatalf("m.Get(%v): %v", queue.ID(), err)
+	}
+
+	i.Set(Faults{NotFound: 1})
+	if _, err := m.Get(queue.ID()); !e.IsNotFound(err) {
+		t.Errorf("m.Get(%v): want injected error satisfying e.IsNotFound(), got %v", queue.ID(), err)
+	}
+
+	// Queues added to the manager are subject to the same faults.
+	i.Set(Faults{Full: 1})
+	if err := got.Add(q.NewMessage([]byte("shenzhou"))); !e.IsFull(err) {
+		t.Errorf("queue.Add(): want injected error satisfying e.IsFull(), got %v", err)
+	}
+}
+
+func TestChaosHTTP(t *testing.T) {
+	i := NewInjector(Faults{})
+	s := httptest.NewServer(i)
+	defer s.Close()
+
+	rsp, err := http.PostForm(s.URL, url.Values{"full": {"0.25"}, "latency": {"50ms"}, "duplicate": {"1"}})
+	if err != nil {
+		t.Fatalf("http.PostForm(): %v", err)
+	}
+	rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		t.Errorf("http.PostForm(): want status %v, got %v", http.StatusOK, rsp.StatusCode)
+	}
+	want := Faults{Full: 0.25, Latency: 50 * time.Millisecond, Duplicate: 1}
+	if got := i.Faults(); !reflect.DeepEqual(want, got) {
+		t.Errorf("i.Faults(): want %+v, got %+v", want, got)
+	}
+
+	rsp, err = http.Get(s.URL)
+	if err != nil {
+		t.Fatalf("http.Get(): %v", err)
+	}
+	got := map[string]interface{}{}
+	if err := json.NewDecoder(rsp.Body).Decode(&got); err != nil {
+		t.Errorf("json.Decode(): %v", err)
+	}
+	rsp.Body.Close()
+	if got["full"] != 0.25 || got["latency"] != "50ms" {
+		t.Errorf("http.Get(): want full 0.25 and latency 50ms, got %v", got)
+	}
+
+	rsp, err = http.Post(s.URL, "application/x-www-form-urlencoded", strings.NewReader("not_found=0.9"))
+	if err != nil {
+		t.Fatalf("http.Post(): %v", err)
+	}
+	rsp.Body.Close()
+	if rsp.StatusCode != http.StatusBadRequest {
+		t.Errorf("http.Post(): want status %v for error rates over 1, got %v", http.StatusBadRequest, rsp.StatusCode)
+	}
+	rsp, err = http.PostForm(s.URL, url.Values{"full": {"0.8"}, "unknown": {"0.8"}})
+	if err != nil {
+		t.Fatalf("http.PostForm(): %v", err)
+	}
+	rsp.Body.Close()
+	if rsp.StatusCode != http.StatusBadRequest {
+		t.Errorf("http.PostForm(): want status %v for error rates over 1, got %v", http.StatusBadRequest, rsp.StatusCode)
+	}
+
+	req, _ := http.NewRequest(http.MethodDelete, s.URL, nil)
+	rsp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("http.Do(): %v", err)
+	}
+	rsp.Body.Close()
+	if got := i.Faults(); !reflect.DeepEqual(Faults{}, got) {
+		t.Errorf("i.Faults(): want no faults after DELETE, got %+v", got)
+	}
+}
@@ -0,0 +1,169 @@
+// Package chaos provides wrappers for queues and queue managers that inject
+// faults, in order to test the resilience of producers and consumers.
+package chaos
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+
+	"github.com/negz/q/e"
+)
+
+// Faults specifies which faults to inject, and how often. Rates are the
+// probability, between zero and one, that an operation is affected. Error
+// rates are cumulative; their sum should not exceed one.
+type Faults struct {
+	Full     float64 // Full is the rate of errors satisfying e.IsFull.
+	NotFound float64 // NotFound is the rate of errors satisfying e.IsNotFound.
+	Unknown  float64 // Unknown is the rate of errors of no particular kind.
+
+	Latency time.Duration // Latency is added to every operation.
+
+	Drop      float64 // Drop is the rate at which popped messages are lost.
+	Duplicate float64 // Duplicate is the rate at which popped messages are delivered again.
+}
+
+// An Injector decides which faults to inject into the queues and managers it
+// is used to wrap. Its faults may be changed at any time, including via HTTP.
+type Injector struct {
+	mx *sync.RWMutex
+	f  Faults
+}
+
+// NewInjector returns an injector that initially injects the supplied faults.
+func NewInjector(f Faults) *Injector {
+	return &Injector{mx: &sync.RWMutex{}, f: f}
+}
+
+// Faults returns the faults the injector currently injects.
+func (i *Injector) Faults() Faults {
+	i.mx.RLock()
+	defer i.mx.RUnlock()
+	return i.f
+}
+
+// Set the faults the injector injects.
+func (i *Injector) Set(f Faults) {
+	i.mx.Lock()
+	defer i.mx.Unlock()
+	i.f = f
+}
+
+// fault delays an operation by the configured latency, then returns an error
+// with the configured probabilities. The delay ends early if the supplied
+// context is done.
+func (i *Injector) fault(ctx context.Context, op string) error {
+	f := i.Faults()
+	if f.Latency > 0 {
+		t := time.NewTimer(f.Latency)
+		defer t.Stop()
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "%s interrupted by injected latency", op)
+		case <-t.C:
+		}
+	}
+	r := rand.Float64()
+	switch {
+	case r < f.Full:
+		return e.ErrFull(errors.Errorf("%s failed with injected full error", op))
+	case r < f.Full+f.NotFound:
+		return e.ErrNotFound(errors.Errorf("%s failed with injected not found error", op))
+	case r < f.Full+f.NotFound+f.Unknown:
+		return errors.Errorf("%s failed with injected error", op)
+	}
+	return nil
+}
+
+// drop determines whether a popped message should be lost.
+func (i *Injector) drop() bool {
+	return rand.Float64() < i.Faults().Drop
+}
+
+// duplicate determines whether a popped message should be delivered again.
+func (i *Injector) duplicate() bool {
+	return rand.Float64() < i.Faults().Duplicate
+}
+
+// ServeHTTP allows an injector's faults to be inspected and changed via HTTP.
+// GET returns the current faults as JSON. POST or PUT changes the faults named
+// by form values, e.g. full=0.1&latency=50ms, leaving the others unchanged.
+// DELETE stops injecting faults.
+func (i *Injector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost, http.MethodPut:
+		f, err := parseFaults(r, i.Faults())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		i.Set(f)
+	case http.MethodDelete:
+		i.Set(Faults{})
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	f := i.Faults()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"full":      f.Full,
+		"not_found": f.NotFound,
+		"unknown":   f.Unknown,
+		"latency":   f.Latency.String(),
+		"drop":      f.Drop,
+		"duplicate": f.Duplicate,
+	})
+}
+
+// parseFaults returns the supplied faults, updated with any faults named by
+// the request's form values.
+func parseFaults(r *http.Request, f Faults) (Faults, error) {
+	if err := r.ParseForm(); err != nil {
+		return f, errors.Wrap(err, "cannot parse form")
+	}
+	rates := map[string]*float64{
+		"full":      &f.Full,
+		"not_found": &f.NotFound,
+		"unknown":   &f.Unknown,
+		"drop":      &f.Drop,
+		"duplicate": &f.Duplicate,
+	}
+	for k, rate := range rates {
+		v := r.Form.Get(k)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return f, errors.Wrapf(err, "cannot parse %s", k)
+		}
+		if n < 0 || n > 1 {
+			return f, errors.Errorf("%s must be between 0 and 1", k)
+		}
+		*rate = n
+	}
+	if v := r.Form.Get("latency"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return f, errors.Wrap(err, "cannot parse latency")
+		}
+		if d < 0 {
+			return f, errors.New("latency must not be negative")
+		}
+		f.Latency = d
+	}
+	if f.Full+f.NotFound+f.Unknown > 1 {
+		return f, errors.New("error rates must not sum to more than 1")
+	}
+	return f, nil
+}
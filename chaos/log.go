@@ -0,0 +1,72 @@
+package chaos
+
+import (
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/negz/q"
+)
+
+// A logQueue is a chaos queue that wraps a log.
+type logQueue struct {
+	*queue
+	wl q.Log
+}
+
+func (c *logQueue) AddGroup(name string) (q.Group, error) {
+	if err := c.i.fault(context.Background(), "add group"); err != nil {
+		return q.Group{}, err
+	}
+	return c.wl.AddGroup(name)
+}
+
+func (c *logQueue) GetGroup(name string) (q.Group, error) {
+	if err := c.i.fault(context.Background(), "get group"); err != nil {
+		return q.Group{}, err
+	}
+	return c.wl.GetGroup(name)
+}
+
+func (c *logQueue) DeleteGroup(name string) error {
+	if err := c.i.fault(context.Background(), "delete group"); err != nil {
+		return err
+	}
+	return c.wl.DeleteGroup(name)
+}
+
+func (c *logQueue) Groups() []q.Group {
+	return c.wl.Groups()
+}
+
+func (c *logQueue) Read(group string, max int) ([]*q.Message, uint64, error) {
+	if err := c.i.fault(context.Background(), "read"); err != nil {
+		return nil, 0, err
+	}
+	return c.wl.Read(group, max)
+}
+
+func (c *logQueue) Commit(group string, offset uint64) (q.Group, error) {
+	if err := c.i.fault(context.Background(), "commit"); err != nil {
+		return q.Group{}, err
+	}
+	return c.wl.Commit(group, offset)
+}
+
+func (c *logQueue) Seek(group string, offset uint64) (q.Group, error) {
+	if err := c.i.fault(context.Background(), "seek group"); err != nil {
+		return q.Group{}, err
+	}
+	return c.wl.Seek(group, offset)
+}
+
+func (c *logQueue) SeekTime(group string, t time.Time) (q.Group, error) {
+	if err := c.i.fault(context.Background(), "seek group"); err != nil {
+		return q.Group{}, err
+	}
+	return c.wl.SeekTime(group, t)
+}
+
+func (c *logQueue) OnLag(fn func(group string, lag uint64)) {
+	c.wl.OnLag(fn)
+}
@@ -0,0 +1,82 @@
+package chaos
+
+import (
+	"github.com/google/uuid"
+	"golang.org/x/net/context"
+
+	"github.com/negz/q"
+)
+
+type manager struct {
+	w q.Manager
+	i *Injector
+}
+
+// Manager wraps a queue manager such that its operations, and those of the
+// queues added to it, are subject to the faults of the supplied injector.
+//
+// Manager wraps the queues added to it before passing them to the supplied
+// manager, so it should wrap any instrumented manager in order for errors
+// injected into queues to be logged and counted. It should be wrapped by any
+// budgeted manager. Messages duplicated by injected faults are added to the
+// queue as wrapped by any budgeted manager, so they are budgeted, but they are
+// not logged or counted by any instrumented manager.
+func Manager(wrap q.Manager, i *Injector) q.Manager {
+	return &manager{w: wrap, i: i}
+}
+
+func (c *manager) Add(queue q.Queue) error {
+	if err := c.i.fault(context.Background(), "add queue"); err != nil {
+		return err
+	}
+	return c.w.Add(Queue(queue, c.i))
+}
+
+func (c *manager) Get(id uuid.UUID) (q.Queue, error) {
+	if err := c.i.fault(context.Background(), "get queue"); err != nil {
+		return nil, err
+	}
+	return c.w.Get(id)
+}
+
+func (c *manager) Delete(id uuid.UUID, force bool) error {
+	if err := c.i.fault(context.Background(), "delete queue"); err != nil {
+		return err
+	}
+	return c.w.Delete(id, force)
+}
+
+func (c *manager) List() ([]q.Queue, error) {
+	if err := c.i.fault(context.Background(), "list queues"); err != nil {
+		return nil, err
+	}
+	return c.w.List()
+}
+
+func (c *manager) AddTopic(t q.Topic) error {
+	if err := c.i.fault(context.Background(), "add topic"); err != nil {
+		return err
+	}
+	return c.w.AddTopic(t)
+}
+
+func (c *manager) GetTopic(id uuid.UUID) (q.Topic, error) {
+	if err := c.i.fault(context.Background(), "get topic"); err != nil {
+		return nil, err
+	}
+	return c.w.GetTopic(id)
+}
+
+func (c *manager) DeleteTopic(id uuid.UUID) error {
+	if err := c.i.fault(context.Background(), "delete topic"); err != nil {
+		return err
+	}
+	return c.w.DeleteTopic(id)
+}
+
+func (c *manager) ListTopics() ([]q.Topic, error) {
+	if err := c.i.fault(context.Background(), "list topics"); err != nil {
+		return nil, err
+	}
+	return c.w.ListTopics()
+}
@@ -0,0 +1,175 @@
+package chaos
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+)
+
+// duplicateTimeout bounds how long a duplicated message may block a pop while
+// it is added back to a full queue.
+const duplicateTimeout = 100 * time.Millisecond
+
+type queue struct {
+	w q.Queue
+	i *Injector
+}
+
+// Queue wraps a queue such that its operations are subject to the faults of the
+// supplied injector. A dropped message is popped from the wrapped queue, but an
+// error satisfying e.IsNotFound with the reason e.QUEUE_EMPTY is returned in
+// its place, as though the queue were empty. A duplicated message is returned,
+// and also added back to the tail of the wrapped queue. The returned queue is a
+// q.Log or a q.Seeker if the wrapped queue is.
+func Queue(wrap q.Queue, i *Injector) q.Queue {
+	c := &queue{w: wrap, i: i}
+	if wl, ok := wrap.(q.Log); ok {
+		return &logQueue{queue: c, wl: wl}
+	}
+	if ws, ok := wrap.(q.Seeker); ok {
+		return &seekerQueue{queue: c, ws: ws}
+	}
+	return c
+}
+
+func (c *queue) ID() uuid.UUID {
+	return c.w.ID()
+}
+
+func (c *queue) Store() q.Store {
+	return c.w.Store()
+}
+
+func (c *queue) Created() time.Time {
+	return c.w.Created()
+}
+
+func (c *queue) Tags() *q.Tags {
+	return c.w.Tags()
+}
+
+func (c *queue) Config() q.Config {
+	return c.w.Config()
+}
+
+func (c *queue) Configure(cfg q.Config) error {
+	if err := c.i.fault(context.Background(), "configure"); err != nil {
+		return err
+	}
+	return c.w.Configure(cfg)
+}
+
+func (c *queue) OnEvict(fn func(*q.Message)) {
+	c.w.OnEvict(fn)
+}
+
+func (c *queue) Add(m *q.Message) error {
+	return c.AddContext(context.Background(), m)
+}
+
+func (c *queue) AddContext(ctx context.Context, m *q.Message) error {
+	if err := c.i.fault(ctx, "add"); err != nil {
+		return err
+	}
+	return c.w.AddContext(ctx, m)
+}
+
+func (c *queue) Pop() (*q.Message, error) {
+	if err := c.i.fault(context.Background(), "pop"); err != nil {
+		return nil, err
+	}
+	return c.deliver(c.w.Pop())
+}
+
+func (c *queue) Peek() (*q.Message, error) {
+	if err := c.i.fault(context.Background(), "peek"); err != nil {
+		return nil, err
+	}
+	return c.w.Peek()
+}
+
+func (c *queue) PopMatching(t ...q.Tag) (*q.Message, error) {
+	if err := c.i.fault(context.Background(), "pop matching"); err != nil {
+		return nil, err
+	}
+	return c.deliver(c.w.PopMatching(t...))
+}
+
+func (c *queue) PeekMatching(t ...q.Tag) (*q.Message, error) {
+	if err := c.i.fault(context.Background(), "peek matching"); err != nil {
+		return nil, err
+	}
+	return c.w.PeekMatching(t...)
+}
+
+// deliver drops or duplicates a popped message. A dropped message is reported
+// as though the queue were empty, so that it is silently lost.
+func (c *queue) deliver(m *q.Message, err error) (*q.Message, error) {
+	if err != nil {
+		return nil, err
+	}
+	if c.i.drop() {
+		err := e.ErrNotFound(errors.Errorf("queue %s is empty", c.ID()))
+		return nil, e.WithReason(err, e.QUEUE_EMPTY)
+	}
+	if c.i.duplicate() {
+		// The duplicate is a best effort; the original is delivered regardless.
+		ctx, cancel := context.WithTimeout(context.Background(), duplicateTimeout)
+		c.w.AddContext(ctx, m)
+		cancel()
+	}
+	return m, nil
+}
+
+func (c *queue) Get(id uuid.UUID) (*q.Message, error) {
+	if err := c.i.fault(context.Background(), "get"); err != nil {
+		return nil, err
+	}
+	return c.w.Get(id)
+}
+
+func (c *queue) Delete(id uuid.UUID) error {
+	if err := c.i.fault(context.Background(), "delete"); err != nil {
+		return err
+	}
+	return c.w.Delete(id)
+}
+
+func (c *queue) Purge() (int, error) {
+	if err := c.i.fault(context.Background(), "purge"); err != nil {
+		return 0, err
+	}
+	return c.w.Purge()
+}
+
+func (c *queue) Walk(offset uint64, fn q.WalkFunc) error {
+	if err := c.i.fault(context.Background(), "walk"); err != nil {
+		return err
+	}
+	return c.w.Walk(offset, fn)
+}
+
+func (c *queue) Added() <-chan struct{} {
+	return q.Added(c.w)
+}
+
+func (c *queue) Unwrap() q.Queue {
+	return c.w
+}
+
+func (c *queue) CanTransfer(dst q.Queue) bool {
+	t, ok := c.w.(q.Transferer)
+	return ok && t.CanTransfer(dst)
+}
+
+func (c *queue) Transfer(ctx context.Context, dst q.Queue, max int, move bool, t ...q.Tag) ([]*q.Message, error) {
+	if err := c.i.fault(ctx, "transfer"); err != nil {
+		return nil, err
+	}
+	return c.w.(q.Transferer).Transfer(ctx, dst, max, move, t...)
+}
@@ -0,0 +1,29 @@
+package chaos
+
+import (
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/negz/q"
+)
+
+// A seekerQueue is a chaos queue that wraps a seeker.
+type seekerQueue struct {
+	*queue
+	ws q.Seeker
+}
+
+func (c *seekerQueue) Seek(offset uint64) ([]*q.Message, error) {
+	if err := c.i.fault(context.Background(), "seek"); err != nil {
+		return nil, err
+	}
+	return c.ws.Seek(offset)
+}
+
+func (c *seekerQueue) SeekTime(t time.Time) ([]*q.Message, error) {
+	if err := c.i.fault(context.Background(), "seek"); err != nil {
+		return nil, err
+	}
+	return c.ws.SeekTime(t)
+}
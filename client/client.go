@@ -0,0 +1,337 @@
+// Package client provides a Go client for the q queue service.
+package client
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+	"github.com/negz/q/proto"
+)
+
+// A Backoff specifies how calls that fail with a retryable error are retried.
+type Backoff struct {
+	Attempts   int           // Attempts is the maximum number of attempts, including the first.
+	Initial    time.Duration // Initial is the delay before the first retry.
+	Max        time.Duration // Max is the maximum delay between retries.
+	Multiplier float64       // Multiplier grows the delay after each retry.
+}
+
+// DefaultBackoff is the backoff used by clients that do not specify their own.
+var DefaultBackoff = Backoff{Attempts: 5, Initial: 50 * time.Millisecond, Max: 2 * time.Second, Multiplier: 2}
+
+// NoRetries is a backoff that never retries failed calls.
+var NoRetries = Backoff{Attempts: 1}
+
+// delay returns how long to wait before the supplied retry, counting from zero.
+// Delays are jittered to avoid many clients retrying in lockstep.
+func (b Backoff) delay(retry int) time.Duration {
+	d := float64(b.Initial) * math.Pow(b.Multiplier, float64(retry))
+	if d > float64(b.Max) {
+		d = float64(b.Max)
+	}
+	return time.Duration(d/2 + rand.Float64()*d/2)
+}
+
+// Retryable determines whether a call that failed with the supplied error may
+// succeed if retried, i.e. whether the service was unavailable or a queue or
+// budget was full.
+func Retryable(err error) bool {
+	return e.IsUnavailable(err) || e.IsFull(err)
+}
+
+// A Client of the q queue service. Errors returned by a client are rebuilt via
+// e.FromGRPC, so they may be handled using the predicates of package e. Calls
+// that fail with a retryable error are retried, so messages may be added more
+// than once.
+type Client struct {
+	c       proto.QClient
+	conn    *grpc.ClientConn
+	backoff Backoff
+	dial    []grpc.DialOption
+}
+
+// An Option represents an optional argument to a new client.
+type Option func(*Client)
+
+// WithBackoff specifies how calls that fail with a retryable error are
+// retried. Defaults to DefaultBackoff.
+func WithBackoff(b Backoff) Option {
+	return func(c *Client) {
+		c.backoff = b
+	}
+}
+
+// WithDialOptions specifies options used to dial the q service. Clients dial
+// without transport security unless credentials are supplied.
+func WithDialOptions(o ...grpc.DialOption) Option {
+	return func(c *Client) {
+		c.dial = append(c.dial, o...)
+	}
+}
+
+// WithToken specifies a bearer token with which to authenticate calls to the q
+// service. Tokens are sent even without transport security, so they should only
+// be used without it when the network is trusted.
+func WithToken(token string) Option {
+	return WithDialOptions(grpc.WithPerRPCCredentials(BearerToken(token)))
+}
+
+// A BearerToken is gRPC per-RPC credentials that authenticate calls to the q
+// service with a static bearer token.
+type BearerToken string
+
+// GetRequestMetadata returns the authorization metadata of each call.
+func (t BearerToken) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+// RequireTransportSecurity returns false, allowing tokens to be sent without
+// transport security.
+func (t BearerToken) RequireTransportSecurity() bool {
+	return false
+}
+
+// Dial returns a client of the q service at the supplied address.
+func Dial(addr string, o ...Option) (*Client, error) {
+	c := &Client{backoff: DefaultBackoff, dial: []grpc.DialOption{grpc.WithInsecure()}}
+	for _, opt := range o {
+		opt(c)
+	}
+	conn, err := grpc.Dial(addr, c.dial...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot dial %s", addr)
+	}
+	c.c = proto.NewQClient(conn)
+	c.conn = conn
+	return c, nil
+}
+
+// New returns a client that calls the q service via the supplied gRPC client.
+// Dial options are ignored.
+func New(pc proto.QClient, o ...Option) *Client {
+	c := &Client{c: pc, backoff: DefaultBackoff}
+	for _, opt := range o {
+		opt(c)
+	}
+	return c
+}
+
+// Close the client's connection, if it dialed one.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return errors.Wrap(c.conn.Close(), "cannot close connection")
+}
+
+// call the supplied function until it succeeds, fails with an error that is not
+// retryable, runs out of attempts, or the supplied context is done.
+func (c *Client) call(ctx context.Context, fn func() error) error {
+	for retry := 0; ; retry++ {
+		err := e.FromGRPC(fn())
+		if err == nil || !Retryable(err) || retry+1 >= c.backoff.Attempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(c.backoff.delay(retry)):
+		}
+	}
+}
+
+// NewQueue creates a queue, returning its ID. Config fields that are zero use
+// the server's defaults, except Limit; use q.Unbounded for an unlimited queue.
+func (c *Client) NewQueue(ctx context.Context, s q.Store, cfg q.Config, t ...q.Tag) (uuid.UUID, error) {
+	r := &proto.NewQueueRequest{
+		Store:           proto.FromStore[s],
+		Limit:           int64(cfg.Limit),
+		Tags:            proto.FromTags(t),
+		MaxBytes:        int64(cfg.MaxBytes),
+		MaxMessageBytes: int64(cfg.MaxMessageBytes),
+		Overflow:        proto.FromOverflow[cfg.Overflow],
+	}
+	if cfg.Retention > 0 {
+		r.Retention = ptypes.DurationProto(cfg.Retention)
+	}
+	if cfg.ExpireAfterIdle > 0 {
+		r.ExpireAfterIdle = ptypes.DurationProto(cfg.ExpireAfterIdle)
+	}
+	return c.newQueue(ctx, r)
+}
+
+func (c *Client) newQueue(ctx context.Context, r *proto.NewQueueRequest) (uuid.UUID, error) {
+	var rsp *proto.NewQueueResponse
+	if err := c.call(ctx, func() (err error) {
+		rsp, err = c.c.NewQueue(ctx, r)
+		return err
+	}); err != nil {
+		return uuid.Nil, errors.Wrap(err, "cannot create queue")
+	}
+	id, err := proto.ParseID(rsp.GetQueue().GetMeta().GetId())
+	return id, errors.Wrap(err, "cannot parse queue ID")
+}
+
+// DeleteQueue deletes a queue. Queues that still hold messages are deleted
+// only if force is true.
+func (c *Client) DeleteQueue(ctx context.Context, id uuid.UUID, force bool) error {
+	r := &proto.DeleteQueueRequest{QueueId: id.String(), Force: force}
+	err := c.call(ctx, func() error {
+		_, err := c.c.DeleteQueue(ctx, r)
+		return err
+	})
+	return errors.Wrapf(err, "cannot delete queue %s", id)
+}
+
+// Add a message to a queue, returning the message as stored by the server.
+// The server assigns the stored message a new ID and creation time.
+func (c *Client) Add(ctx context.Context, queue uuid.UUID, m *q.Message) (*q.Message, error) {
+	r := &proto.AddRequest{QueueId: queue.String(), Message: proto.FromNewMessage(m)}
+	var rsp *proto.AddResponse
+	if err := c.call(ctx, func() (err error) {
+		rsp, err = c.c.Add(ctx, r)
+		return err
+	}); err != nil {
+		return nil, errors.Wrapf(err, "cannot add message to queue %s", queue)
+	}
+	stored, err := proto.ToMessage(rsp.GetMessage())
+	return stored, errors.Wrap(err, "cannot parse message")
+}
+
+// AddBatch adds messages to a queue in order, returning the messages as stored
+// by the server. Adding stops at the first message that cannot be added, so
+// fewer messages than were supplied may be returned. AddBatch returns an error
+// only if the first message cannot be added.
+func (c *Client) AddBatch(ctx context.Context, queue uuid.UUID, ms []*q.Message) ([]*q.Message, error) {
+	r := &proto.AddBatchRequest{QueueId: queue.String(), Messages: make([]*proto.NewMessage, 0, len(ms))}
+	for _, m := range ms {
+		r.Messages = append(r.Messages, proto.FromNewMessage(m))
+	}
+	var rsp *proto.AddBatchResponse
+	if err := c.call(ctx, func() (err error) {
+		rsp, err = c.c.AddBatch(ctx, r)
+		return err
+	}); err != nil {
+		return nil, errors.Wrapf(err, "cannot add messages to queue %s", queue)
+	}
+	stored := make([]*q.Message, 0, len(rsp.GetMessages()))
+	for _, pm := range rsp.GetMessages() {
+		m, err := proto.ToMessage(pm)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot parse message")
+		}
+		stored = append(stored, m)
+	}
+	return stored, nil
+}
+
+// Pop consumes the first message in a queue that is tagged with all of the
+// supplied tags. The error returned for an empty queue satisfies e.IsNotFound
+// and has the reason e.QUEUE_EMPTY.
+func (c *Client) Pop(ctx context.Context, queue uuid.UUID, t ...q.Tag) (*q.Message, error) {
+	return c.PopWait(ctx, queue, 0, t...)
+}
+
+// PopWait consumes the first message in a queue that is tagged with all of the
+// supplied tags, waiting up to the supplied duration for one to be added if
+// there is none. The error returned for a queue that remained empty satisfies
+// e.IsNotFound and has the reason e.QUEUE_EMPTY.
+func (c *Client) PopWait(ctx context.Context, queue uuid.UUID, wait time.Duration, t ...q.Tag) (*q.Message, error) {
+	r := &proto.PopRequest{QueueId: queue.String(), Filter: proto.FromTags(t)}
+	if wait > 0 {
+		r.Wait = ptypes.DurationProto(wait)
+	}
+	var rsp *proto.PopResponse
+	if err := c.call(ctx, func() (err error) {
+		rsp, err = c.c.Pop(ctx, r)
+		return err
+	}); err != nil {
+		return nil, errors.Wrapf(err, "cannot pop message from queue %s", queue)
+	}
+	m, err := proto.ToMessage(rsp.GetMessage())
+	return m, errors.Wrap(err, "cannot parse message")
+}
+
+// Peek returns the first message in a queue that is tagged with all of the
+// supplied tags without consuming it.
+func (c *Client) Peek(ctx context.Context, queue uuid.UUID, t ...q.Tag) (*q.Message, error) {
+	r := &proto.PeekRequest{QueueId: queue.String(), Filter: proto.FromTags(t)}
+	var rsp *proto.PeekResponse
+	if err := c.call(ctx, func() (err error) {
+		rsp, err = c.c.Peek(ctx, r)
+		return err
+	}); err != nil {
+		return nil, errors.Wrapf(err, "cannot peek into queue %s", queue)
+	}
+	m, err := proto.ToMessage(rsp.GetMessage())
+	return m, errors.Wrap(err, "cannot parse message")
+}
+
+// Delete a message from a queue regardless of its position in the queue.
+func (c *Client) Delete(ctx context.Context, queue, id uuid.UUID) error {
+	r := &proto.DeleteMessageRequest{QueueId: queue.String(), MessageId: id.String()}
+	err := c.call(ctx, func() error {
+		_, err := c.c.DeleteMessage(ctx, r)
+		return err
+	})
+	return errors.Wrapf(err, "cannot delete message %s from queue %s", id, queue)
+}
+
+// Publish a message to a topic, returning the number of queues to which it was
+// delivered.
+func (c *Client) Publish(ctx context.Context, topic uuid.UUID, m *q.Message) (int, error) {
+	r := &proto.PublishRequest{TopicId: topic.String(), Message: proto.FromNewMessage(m)}
+	var rsp *proto.PublishResponse
+	if err := c.call(ctx, func() (err error) {
+		rsp, err = c.c.Publish(ctx, r)
+		return err
+	}); err != nil {
+		return 0, errors.Wrapf(err, "cannot publish message to topic %s", topic)
+	}
+	return int(rsp.GetDelivered()), nil
+}
+
+// Subscribe to a topic, returning the ID of a new temporary queue to which
+// messages tagged with all of the supplied tags are delivered. The queue is
+// deleted when the client's connection closes.
+func (c *Client) Subscribe(ctx context.Context, topic uuid.UUID, t ...q.Tag) (uuid.UUID, error) {
+	id, err := c.newQueue(ctx, &proto.NewQueueRequest{Store: proto.MEMORY, Limit: int64(q.Unbounded), Temporary: true})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	r := &proto.SubscribeRequest{
+		TopicId:      topic.String(),
+		Subscription: &proto.Subscription{QueueId: id.String(), Filter: proto.FromTags(t)},
+	}
+	if err := c.call(ctx, func() error {
+		_, err := c.c.Subscribe(ctx, r)
+		return err
+	}); err != nil {
+		return uuid.Nil, errors.Wrapf(err, "cannot subscribe to topic %s", topic)
+	}
+	return id, nil
+}
+
+// Request adds a message to a queue and waits for a correlated reply until the
+// supplied context is done.
+func (c *Client) Request(ctx context.Context, queue uuid.UUID, m *q.Message) (*q.Message, error) {
+	r := &proto.RequestRequest{QueueId: queue.String(), Message: proto.FromNewMessage(m)}
+	var rsp *proto.RequestResponse
+	if err := c.call(ctx, func() (err error) {
+		rsp, err = c.c.Request(ctx, r)
+		return err
+	}); err != nil {
+		return nil, errors.Wrapf(err, "cannot request reply via queue %s", queue)
+	}
+	reply, err := proto.ToMessage(rsp.GetReply())
+	return reply, errors.Wrap(err, "cannot parse reply")
+}
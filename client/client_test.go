@@ -0,0 +1,98 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+	"github.com/negz/q/proto"
+)
+
+// A flakyQClient fails each Pop with the supplied errors before succeeding.
+type flakyQClient struct {
+	proto.QClient
+	errs  []error
+	calls int
+}
+
+func (f *flakyQClient) Pop(_ context.Context, r *proto.PopRequest, _ ...grpc.CallOption) (*proto.PopResponse, error) {
+	f.calls++
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return nil, err
+	}
+	m, err := proto.FromMessage(q.NewMessage([]byte("vostok")))
+	return &proto.PopResponse{Message: m}, err
+}
+
+var fast = Backoff{Attempts: 3, Initial: time.Millisecond, Max: time.Millisecond, Multiplier: 2}
+
+var retryTests = []struct {
+	name   string
+	errs   []error
+	calls  int
+	tester func(error) bool
+}{
+	{
+		name:  "Succeeds",
+		calls: 1,
+	},
+	{
+		name:  "RetriesUnavailable",
+		errs:  []error{status.Error(codes.Unavailable, "down"), status.Error(codes.ResourceExhausted, "full")},
+		calls: 3,
+	},
+	{
+		name:   "GivesUp",
+		errs:   []error{status.Error(codes.Unavailable, "down"), status.Error(codes.Unavailable, "down"), status.Error(codes.Unavailable, "down")},
+		calls:  3,
+		tester: e.IsUnavailable,
+	},
+	{
+		name:   "DoesNotRetryNotFound",
+		errs:   []error{e.GRPC(e.WithReason(e.ErrNotFound(errors.New("empty")), e.QUEUE_EMPTY))},
+		calls:  1,
+		tester: e.IsNotFound,
+	},
+}
+
+func TestRetries(t *testing.T) {
+	for _, tt := range retryTests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &flakyQClient{errs: tt.errs}
+			c := New(f, WithBackoff(fast))
+			_, err := c.Pop(context.Background(), uuid.New())
+			if f.calls != tt.calls {
+				t.Errorf("c.Pop(): want %v calls, got %v", tt.calls, f.calls)
+			}
+			if tt.tester == nil {
+				if err != nil {
+					t.Errorf("c.Pop(): %v", err)
+				}
+				return
+			}
+			if !tt.tester(err) {
+				t.Errorf("c.Pop(): error %v does not satisfy the expected predicate", err)
+			}
+		})
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	b := Backoff{Attempts: 10, Initial: 10 * time.Millisecond, Max: 50 * time.Millisecond, Multiplier: 2}
+	for retry, max := range []time.Duration{10, 20, 40, 50, 50} {
+		max *= time.Millisecond
+		if d := b.delay(retry); d < max/2 || d > max {
+			t.Errorf("b.delay(%v): want between %v and %v, got %v", retry, max/2, max, d)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package client
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+)
+
+const (
+	// DefaultWait is how long consumers wait by default for a message to be
+	// added to an empty queue before popping it again.
+	DefaultWait = 20 * time.Second
+
+	// DefaultNackDelay is how long consumers wait by default before adding a
+	// nacked message back to its queue.
+	DefaultNackDelay = time.Second
+
+	// DefaultNackTimeout is how long consumers wait by default for a nacked
+	// message to be added back to its queue.
+	DefaultNackTimeout = 10 * time.Second
+
+	// RedeliveriesTag is the key of the tag with which consumers record how
+	// many times a message has been nacked.
+	RedeliveriesTag = "q.redeliveries"
+)
+
+// A Handler processes a message. Returning an error nacks the message.
+type Handler func(ctx context.Context, m *q.Message) error
+
+// A Consumer pops messages from a queue and passes them to a handler using a
+// pool of worker goroutines. Messages are acked by popping them, so delivery is
+// at most once: a message is consumed before its handler is called. A message
+// is nacked when its handler returns an error by adding it back to the tail of
+// its queue, as a new message with the same payload, tags, reply queue, and
+// correlation ID, after the nack delay. The number of times a message has been
+// nacked is recorded in its RedeliveriesTag tag. A message that cannot be nacked
+// within the nack timeout is discarded.
+type Consumer struct {
+	c            *Client
+	queue        uuid.UUID
+	workers      int
+	wait         time.Duration
+	filter       []q.Tag
+	delay        time.Duration
+	timeout      time.Duration
+	redeliveries int
+	discarded    func(m *q.Message, err error)
+}
+
+// A ConsumerOption represents an optional argument to a new consumer.
+type ConsumerOption func(*Consumer)
+
+// WithWorkers specifies how many messages may be handled concurrently.
+// Defaults to one.
+func WithWorkers(n int) ConsumerOption {
+	return func(cs *Consumer) {
+		cs.workers = n
+	}
+}
+
+// WithWait specifies how long each pop waits for a message to be added to an
+// empty queue. Defaults to DefaultWait.
+func WithWait(d time.Duration) ConsumerOption {
+	return func(cs *Consumer) {
+		cs.wait = d
+	}
+}
+
+// WithNackDelay specifies how long to wait before adding a nacked message back
+// to its queue, so that a message its handler cannot process is not handled
+// repeatedly in quick succession. The worker that nacked the message waits too.
+// Defaults to DefaultNackDelay.
+func WithNackDelay(d time.Duration) ConsumerOption {
+	return func(cs *Consumer) {
+		cs.delay = d
+	}
+}
+
+// WithNackTimeout specifies how long to wait for a nacked message to be added
+// back to its queue, for example while the queue is full and blocks producers.
+// A message that cannot be added in time is discarded, and passed to the
+// function supplied via WithDiscarded along with the error adding it. Defaults
+// to DefaultNackTimeout.
+func WithNackTimeout(d time.Duration) ConsumerOption {
+	return func(cs *Consumer) {
+		cs.timeout = d
+	}
+}
+
+// WithMaxRedeliveries specifies how many times a message may be nacked and
+// added back to its queue. A message whose handler fails once it has been
+// redelivered this many times is discarded rather than nacked, and passed to
+// the function supplied via WithDiscarded, if any. Defaults to q.Unbounded.
+func WithMaxRedeliveries(n int) ConsumerOption {
+	return func(cs *Consumer) {
+		cs.redeliveries = n
+	}
+}
+
+// WithDiscarded specifies a function to call with each message that is
+// discarded, either because it reached the maximum number of redeliveries or
+// because it could not be nacked. The function is passed the error its handler
+// returned, or the error adding it back to its queue, respectively. It may for
+// example log the message, or add it to a dead letter queue. It is called by
+// the worker that discarded the message.
+func WithDiscarded(fn func(m *q.Message, err error)) ConsumerOption {
+	return func(cs *Consumer) {
+		cs.discarded = fn
+	}
+}
+
+// WithFilter specifies that only messages tagged with all of the supplied tags
+// should be consumed.
+func WithFilter(t ...q.Tag) ConsumerOption {
+	return func(cs *Consumer) {
+		cs.filter = t
+	}
+}
+
+// NewConsumer returns a consumer of the supplied queue. Use Subscribe to
+// create a queue that consumes a topic.
+func (c *Client) NewConsumer(queue uuid.UUID, o ...ConsumerOption) *Consumer {
+	cs := &Consumer{
+		c:            c,
+		queue:        queue,
+		workers:      1,
+		wait:         DefaultWait,
+		delay:        DefaultNackDelay,
+		timeout:      DefaultNackTimeout,
+		redeliveries: q.Unbounded,
+		discarded:    func(*q.Message, error) {},
+	}
+	for _, opt := range o {
+		opt(cs)
+	}
+	return cs
+}
+
+// Run consumes messages until the supplied context is canceled or a worker
+// encounters an error it cannot recover from, for example because the queue
+// was deleted. When the context is canceled workers stop popping messages and
+// Run returns once the messages they already popped have been handled. Run
+// returns nil if it stopped because the context was canceled.
+func (cs *Consumer) Run(ctx context.Context, h Handler) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make(chan error, cs.workers)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < cs.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := cs.work(ctx, h); err != nil {
+				errs <- err
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	return <-errs
+}
+
+// work pops and handles messages until the supplied context is canceled or it
+// encounters a fatal error. Other errors, which may be transient, are retried
+// after a backoff.
+func (cs *Consumer) work(ctx context.Context, h Handler) error {
+	for failures := 0; ; {
+		m, err := cs.c.PopWait(ctx, cs.queue, cs.wait, cs.filter...)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if e.ReasonOf(err) == e.QUEUE_EMPTY {
+				continue
+			}
+			if fatal(err) {
+				return err
+			}
+			cs.pause(ctx, failures)
+			failures++
+			continue
+		}
+		failures = 0
+		if herr := h(ctx, m); herr != nil {
+			if err := cs.nack(ctx, m, herr); err != nil {
+				cs.discarded(m, err)
+				if fatal(err) {
+					return errors.Wrapf(err, "cannot nack message %s after handler error: %s", m.ID, herr)
+				}
+			}
+		}
+	}
+}
+
+// fatal determines whether a consumer cannot recover from the supplied error,
+// i.e. whether its queue does not exist, it is not allowed to use the queue,
+// or its requests are invalid.
+func fatal(err error) bool {
+	return e.ReasonOf(err) == e.QUEUE_NOT_FOUND || e.IsPermissionDenied(err) || e.IsUnauthenticated(err) || e.IsInvalid(err)
+}
+
+// pause waits before a worker retries after the supplied number of consecutive
+// failures, using the client's backoff, or DefaultBackoff if the client does
+// not retry. It returns early if the supplied context is canceled.
+func (cs *Consumer) pause(ctx context.Context, failures int) {
+	b := cs.c.backoff
+	if b.Initial <= 0 {
+		b = DefaultBackoff
+	}
+	t := time.NewTimer(b.delay(failures))
+	defer t.Stop()
+	select {
+	case <-t.C:
+	case <-ctx.Done():
+	}
+}
+
+// nack adds a message back to the consumer's queue after the nack delay,
+// unless it has already been redelivered the maximum number of times. The
+// message has already been popped, so it is added without waiting for the
+// delay once the consumer's context is canceled, but only for up to the nack
+// timeout.
+func (cs *Consumer) nack(ctx context.Context, m *q.Message, herr error) error {
+	n := redeliveries(m)
+	if cs.redeliveries != q.Unbounded && n >= cs.redeliveries {
+		cs.discarded(m, herr)
+		return nil
+	}
+	t := time.NewTimer(cs.delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+	case <-ctx.Done():
+	}
+	m.Tags.Remove(RedeliveriesTag, strconv.Itoa(n))
+	m.Tags.Add(RedeliveriesTag, strconv.Itoa(n+1))
+	actx, cancel := context.WithTimeout(context.Background(), cs.timeout)
+	defer cancel()
+	_, err := cs.c.Add(actx, cs.queue, m)
+	return err
+}
+
+// redeliveries returns how many times the supplied message has been nacked.
+func redeliveries(m *q.Message) int {
+	for _, t := range m.Tags.Get() {
+		if t.Key != RedeliveriesTag {
+			continue
+		}
+		if n, err := strconv.Atoi(t.Value); err == nil {
+			return n
+		}
+	}
+	return 0
+}
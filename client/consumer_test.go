@@ -0,0 +1,248 @@
+package client
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+	"github.com/negz/q/memory"
+	"github.com/negz/q/proto"
+)
+
+// A popQClient pops and adds messages to an in-memory queue. Pops of an empty
+// queue wait for a message to be added until the requested wait elapses.
+type popQClient struct {
+	proto.QClient
+	queue   q.Queue
+	err     error
+	popErrs []error // Returned by successive pops before any message.
+
+	mx    *sync.Mutex
+	waits []time.Duration
+}
+
+func (c *popQClient) Pop(ctx context.Context, r *proto.PopRequest, _ ...grpc.CallOption) (*proto.PopResponse, error) {
+	wait := proto.ToDuration(r.GetWait())
+	c.mx.Lock()
+	c.waits = append(c.waits, wait)
+	if len(c.popErrs) > 0 {
+		err := c.popErrs[0]
+		c.popErrs = c.popErrs[1:]
+		c.mx.Unlock()
+		return nil, err
+	}
+	c.mx.Unlock()
+
+	timeout := time.After(wait)
+	for {
+		added := q.Added(c.queue)
+		m, err := c.queue.Pop()
+		if err == nil {
+			pm, err := proto.FromMessage(m)
+			return &proto.PopResponse{Message: pm}, err
+		}
+		select {
+		case <-added:
+		case <-timeout:
+			return nil, e.GRPC(err)
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (c *popQClient) Add(ctx context.Context, r *proto.AddRequest, _ ...grpc.CallOption) (*proto.AddResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	m, _ := proto.ToNewMessage(r.GetMessage())
+	if err := c.queue.AddContext(ctx, m); err != nil {
+		return nil, e.GRPC(err)
+	}
+	pm, err := proto.FromMessage(m)
+	return &proto.AddResponse{Message: pm}, err
+}
+
+func TestConsumer(t *testing.T) {
+	queue := memory.New()
+	qc := &popQClient{queue: queue, mx: &sync.Mutex{}}
+	cs := New(qc, WithBackoff(NoRetries)).NewConsumer(queue.ID(), WithWorkers(3), WithWait(time.Minute), WithNackDelay(time.Millisecond))
+
+	want := map[string]bool{}
+	for i := 0; i < 10; i++ {
+		m := q.NewMessage([]byte(fmt.Sprintf("salyut %d", i)))
+		if err := queue.Add(m); err != nil {
+			t.Fatalf("queue.Add(%v): %v", m, err)
+		}
+		want[string(m.Payload)] = true
+	}
+
+	// Each message fails once, and so is nacked, before it is handled.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mx := &sync.Mutex{}
+	failed := map[string]bool{}
+	got := map[string]bool{}
+	h := func(_ context.Context, m *q.Message) error {
+		mx.Lock()
+		defer mx.Unlock()
+		p := string(m.Payload)
+		if !failed[p] {
+			failed[p] = true
+			return errors.New("kaboom!")
+		}
+		got[p] = true
+		if len(got) == len(want) {
+			cancel()
+		}
+		return nil
+	}
+	if err := cs.Run(ctx, h); err != nil {
+		t.Errorf("cs.Run(): %v", err)
+	}
+	if len(got) != len(want) {
+		t.Errorf("cs.Run(): want %v handled, got %v", want, got)
+	}
+	if _, err := queue.Peek(); !e.IsNotFound(err) {
+		t.Errorf("queue.Peek(): want error satisfying e.IsNotFound(), got %v", err)
+	}
+
+	// Workers wait for messages rather than repeatedly popping an empty queue.
+	qc.mx.Lock()
+	defer qc.mx.Unlock()
+	for _, wait := range qc.waits {
+		if wait != time.Minute {
+			t.Errorf("Pop(): want wait %v, got %v", time.Minute, wait)
+		}
+	}
+}
+
+func TestConsumerNackFailure(t *testing.T) {
+	queue := memory.New()
+	qc := &popQClient{queue: queue, err: e.GRPC(e.ErrInvalid(errors.New("kaboom!"))), mx: &sync.Mutex{}}
+	cs := New(qc, WithBackoff(NoRetries)).NewConsumer(queue.ID(), WithWait(time.Minute))
+
+	m := q.NewMessage([]byte("mir"))
+	if err := queue.Add(m); err != nil {
+		t.Fatalf("queue.Add(%v): %v", m, err)
+	}
+	h := func(_ context.Context, _ *q.Message) error { return errors.New("kaboom!") }
+	if err := cs.Run(context.Background(), h); !e.IsInvalid(err) {
+		t.Errorf("cs.Run(): want error satisfying e.IsInvalid(), got %v", err)
+	}
+}
+
+func TestConsumerMaxRedeliveries(t *testing.T) {
+	queue := memory.New()
+	qc := &popQClient{queue: queue, mx: &sync.Mutex{}}
+	discarded := []string{}
+	d := func(m *q.Message, err error) { discarded = append(discarded, fmt.Sprintf("%s: %s", m.Payload, err)) }
+	cs := New(qc, WithBackoff(NoRetries)).NewConsumer(queue.ID(), WithWait(time.Minute), WithNackDelay(0), WithMaxRedeliveries(2), WithDiscarded(d))
+
+	m := q.NewMessage([]byte("mir"))
+	if err := queue.Add(m); err != nil {
+		t.Fatalf("queue.Add(%v): %v", m, err)
+	}
+
+	// The message is handled once, then redelivered twice before it is
+	// discarded.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	handled := 0
+	h := func(_ context.Context, m *q.Message) error {
+		if want := fmt.Sprintf("%d", handled); handled > 0 && !m.Tags.Contains(RedeliveriesTag, want) {
+			t.Errorf("m.Tags.Contains(%q, %q): want true, got false", RedeliveriesTag, want)
+		}
+		handled++
+		if handled == 3 {
+			cancel()
+		}
+		return errors.New("kaboom!")
+	}
+	if err := cs.Run(ctx, h); err != nil {
+		t.Errorf("cs.Run(): %v", err)
+	}
+	if handled != 3 {
+		t.Errorf("cs.Run(): want 3 handled, got %d", handled)
+	}
+	if want := []string{"mir: kaboom!"}; !reflect.DeepEqual(want, discarded) {
+		t.Errorf("cs.Run(): want discarded %v, got %v", want, discarded)
+	}
+	if _, err := queue.Peek(); !e.IsNotFound(err) {
+		t.Errorf("queue.Peek(): want error satisfying e.IsNotFound(), got %v", err)
+	}
+}
+
+func TestConsumerNackTimeout(t *testing.T) {
+	queue := memory.New(memory.Limit(1), memory.Overflow(q.Block))
+	qc := &popQClient{queue: queue, mx: &sync.Mutex{}}
+	discarded := []*q.Message{}
+	d := func(m *q.Message, err error) {
+		if err == nil {
+			t.Errorf("discarded(%v): want error adding message back to queue, got nil", m.ID)
+		}
+		discarded = append(discarded, m)
+	}
+	cs := New(qc, WithBackoff(NoRetries)).NewConsumer(queue.ID(), WithWait(time.Minute), WithNackDelay(0), WithNackTimeout(10*time.Millisecond), WithDiscarded(d))
+
+	m := q.NewMessage([]byte("mir"))
+	if err := queue.Add(m); err != nil {
+		t.Fatalf("queue.Add(%v): %v", m, err)
+	}
+
+	// The handler fills the queue, so the nacked message cannot be added back
+	// and is discarded.
+	ctx, cancel := context.WithCancel(context.Background())
+	h := func(_ context.Context, _ *q.Message) error {
+		cancel()
+		if err := queue.Add(q.NewMessage([]byte("salyut"))); err != nil {
+			t.Errorf("queue.Add(): %v", err)
+		}
+		return errors.New("kaboom!")
+	}
+	if err := cs.Run(ctx, h); err != nil {
+		t.Errorf("cs.Run(): %v", err)
+	}
+	if len(discarded) != 1 || discarded[0].ID != m.ID {
+		t.Errorf("cs.Run(): want message %v discarded, got %v", m.ID, discarded)
+	}
+}
+
+func TestConsumerPopErrors(t *testing.T) {
+	queue := memory.New()
+	qc := &popQClient{queue: queue, mx: &sync.Mutex{}, popErrs: []error{e.GRPC(errors.New("connection reset"))}}
+	cs := New(qc, WithBackoff(Backoff{Attempts: 1, Initial: time.Millisecond, Max: time.Millisecond, Multiplier: 1})).NewConsumer(queue.ID(), WithWait(time.Minute))
+
+	m := q.NewMessage([]byte("mir"))
+	if err := queue.Add(m); err != nil {
+		t.Fatalf("queue.Add(%v): %v", m, err)
+	}
+
+	// Workers keep consuming after errors that may be transient.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	h := func(_ context.Context, _ *q.Message) error {
+		cancel()
+		return nil
+	}
+	if err := cs.Run(ctx, h); err != nil {
+		t.Errorf("cs.Run(): %v", err)
+	}
+	if _, err := queue.Peek(); !e.IsNotFound(err) {
+		t.Errorf("queue.Peek(): want error satisfying e.IsNotFound(), got %v", err)
+	}
+
+	// Workers stop after errors they cannot recover from.
+	qc.popErrs = []error{e.GRPC(e.ErrPermissionDenied(errors.New("go away")))}
+	if err := cs.Run(context.Background(), h); !e.IsPermissionDenied(err) {
+		t.Errorf("cs.Run(): want error satisfying e.IsPermissionDenied(), got %v", err)
+	}
+}
@@ -0,0 +1,338 @@
+package client
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+
+	"github.com/negz/q"
+)
+
+const (
+	// DefaultBufferSize is the default number of messages a producer buffers.
+	DefaultBufferSize = 1000
+
+	// DefaultBatchSize is the default maximum number of messages a producer
+	// adds in one batch.
+	DefaultBatchSize = 100
+
+	// DefaultBatchBytes is the default maximum total size of the message
+	// payloads a producer adds in one batch. It is well within the default
+	// maximum request size of the q service.
+	DefaultBatchBytes = 1 << 20
+
+	// DefaultLinger is how long a producer waits for a batch to fill by
+	// default.
+	DefaultLinger = 10 * time.Millisecond
+
+	// DefaultSendTimeout is how long a producer waits for a batch to be added
+	// by default.
+	DefaultSendTimeout = 30 * time.Second
+)
+
+// ErrClosed is returned when producing to a closed producer.
+var ErrClosed = errors.New("producer is closed")
+
+// A Report describes the delivery of a produced message.
+type Report struct {
+	Message *q.Message // Message is the message that was produced.
+	ID      uuid.UUID  // ID is the ID the server assigned the message, if it was added.
+	Err     error      // Err describes why the message could not be added, if it was not.
+}
+
+// A Producer adds messages to a queue asynchronously. Produced messages are
+// buffered and added in batches, which are sent when they are full or have
+// lingered long enough.
+type Producer struct {
+	c      *Client
+	queue  uuid.UUID
+	buffer int
+	size   int
+	bytes  int
+	linger time.Duration
+	send   time.Duration
+
+	reporting bool
+
+	in      chan *q.Message
+	flush   chan *flushRequest
+	reports chan Report
+	done    chan struct{}
+
+	// closing is closed when the producer starts closing, waking any callers
+	// of Produce and Flush so that they release mx.
+	closing chan struct{}
+	closer  sync.Once
+
+	mx     *sync.RWMutex // Guards closed and sends to in.
+	closed bool
+
+	failures int   // Messages that could not be added since the last flush.
+	err      error // The last reason a message could not be added.
+}
+
+// A flushRequest asks the producer's goroutine to add all buffered messages.
+type flushRequest struct {
+	result chan error    // Receives the outcome of the flush.
+	gone   chan struct{} // Closed if the caller stops waiting for the outcome.
+}
+
+// A ProducerOption represents an optional argument to a new producer.
+type ProducerOption func(*Producer)
+
+// WithBufferSize specifies the maximum number of messages that may be buffered
+// waiting to be added. Produce blocks while the buffer is full. Defaults to
+// DefaultBufferSize.
+func WithBufferSize(n int) ProducerOption {
+	return func(p *Producer) {
+		p.buffer = n
+	}
+}
+
+// WithBatchSize specifies the maximum number of messages to add in one batch.
+// Defaults to DefaultBatchSize.
+func WithBatchSize(n int) ProducerOption {
+	return func(p *Producer) {
+		p.size = n
+	}
+}
+
+// WithBatchBytes specifies the maximum total size of the message payloads to
+// add in one batch. A message larger than this is added in a batch of its own.
+// Defaults to DefaultBatchBytes.
+func WithBatchBytes(n int) ProducerOption {
+	return func(p *Producer) {
+		p.bytes = n
+	}
+}
+
+// WithLinger specifies how long to wait for a batch to fill before adding it.
+// Defaults to DefaultLinger.
+func WithLinger(d time.Duration) ProducerOption {
+	return func(p *Producer) {
+		p.linger = d
+	}
+}
+
+// WithSendTimeout specifies how long to wait for each batch to be added,
+// including any retries. Messages in a batch that is not added in time are
+// reported as failed. Defaults to DefaultSendTimeout.
+func WithSendTimeout(d time.Duration) ProducerOption {
+	return func(p *Producer) {
+		p.send = d
+	}
+}
+
+// WithReports specifies that a delivery report should be sent for every
+// produced message. Reports must be received from the producer's Reports
+// channel, or the producer will stop adding messages once it fills.
+func WithReports() ProducerOption {
+	return func(p *Producer) {
+		p.reporting = true
+	}
+}
+
+// NewProducer returns a producer that adds messages to the supplied queue.
+func (c *Client) NewProducer(queue uuid.UUID, o ...ProducerOption) *Producer {
+	p := &Producer{
+		c:       c,
+		queue:   queue,
+		buffer:  DefaultBufferSize,
+		size:    DefaultBatchSize,
+		bytes:   DefaultBatchBytes,
+		linger:  DefaultLinger,
+		send:    DefaultSendTimeout,
+		flush:   make(chan *flushRequest),
+		done:    make(chan struct{}),
+		closing: make(chan struct{}),
+		mx:      &sync.RWMutex{},
+	}
+	for _, opt := range o {
+		opt(p)
+	}
+	p.in = make(chan *q.Message, p.buffer)
+	if p.reporting {
+		p.reports = make(chan Report, p.buffer)
+	}
+	go p.run()
+	return p
+}
+
+// Produce buffers a message to be added to the producer's queue, blocking
+// until there is room in the buffer, the supplied context is done, or the
+// producer is closed.
+func (p *Producer) Produce(ctx context.Context, m *q.Message) error {
+	p.mx.RLock()
+	defer p.mx.RUnlock()
+	if p.closed {
+		return ErrClosed
+	}
+	select {
+	case p.in <- m:
+		return nil
+	case <-p.closing:
+		return ErrClosed
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "cannot buffer message")
+	}
+}
+
+// Reports returns a channel of delivery reports, or nil if the producer was
+// not created WithReports. The channel is closed when the producer is closed.
+func (p *Producer) Reports() <-chan Report {
+	return p.reports
+}
+
+// Flush adds all buffered messages, blocking until they have been added or the
+// supplied context is done. Producers without reports return an error if any
+// message could not be added since the last flush.
+func (p *Producer) Flush(ctx context.Context) error {
+	p.mx.RLock()
+	defer p.mx.RUnlock()
+	if p.closed {
+		return ErrClosed
+	}
+	r := &flushRequest{result: make(chan error), gone: make(chan struct{})}
+	select {
+	case p.flush <- r:
+	case <-p.closing:
+		return ErrClosed
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "cannot flush messages")
+	}
+	// Failures are reported by whichever of Flush or Close next receives them.
+	select {
+	case err := <-r.result:
+		return err
+	case <-p.closing:
+		close(r.gone)
+		return ErrClosed
+	case <-ctx.Done():
+		close(r.gone)
+		return errors.Wrap(ctx.Err(), "cannot flush messages")
+	}
+}
+
+// Close flushes all buffered messages and stops the producer, blocking until
+// they have been added or the supplied context is done. Buffered messages are
+// still added after the context is done. Producers without reports return an
+// error if any message could not be added since the last flush.
+func (p *Producer) Close(ctx context.Context) error {
+	// Wake any blocked callers of Produce and Flush so that we don't wait for
+	// room in a full buffer in order to take the lock.
+	p.closer.Do(func() { close(p.closing) })
+	p.mx.Lock()
+	if p.closed {
+		p.mx.Unlock()
+		return ErrClosed
+	}
+	p.closed = true
+	close(p.in)
+	p.mx.Unlock()
+
+	select {
+	case <-p.done:
+		return p.failed()
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "cannot flush messages")
+	}
+}
+
+func (p *Producer) run() {
+	defer close(p.done)
+	if p.reports != nil {
+		defer close(p.reports)
+	}
+	batch := []*q.Message{}
+	bytes := 0
+	var linger <-chan time.Time
+	send := func() {
+		p.sendBatch(batch)
+		batch = []*q.Message{}
+		bytes = 0
+		linger = nil
+	}
+	add := func(m *q.Message) {
+		if len(batch) > 0 && bytes+len(m.Payload) > p.bytes {
+			send()
+		}
+		if len(batch) == 0 {
+			linger = time.After(p.linger)
+		}
+		batch = append(batch, m)
+		bytes += len(m.Payload)
+		if len(batch) >= p.size {
+			send()
+		}
+	}
+	for {
+		select {
+		case m, ok := <-p.in:
+			if !ok {
+				send()
+				return
+			}
+			add(m)
+		case <-linger:
+			send()
+		case r := <-p.flush:
+			// Messages produced before the flush may still be buffered.
+			for len(p.in) > 0 {
+				add(<-p.in)
+			}
+			send()
+			// Failures accumulate until a caller receives them.
+			select {
+			case r.result <- p.failed():
+				p.failures, p.err = 0, nil
+			case <-r.gone:
+			}
+		}
+	}
+}
+
+// sendBatch adds a batch of messages, reporting the outcome for each message.
+// Messages that cannot be added are reported and skipped, and the rest of the
+// batch resent.
+func (p *Producer) sendBatch(batch []*q.Message) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.send)
+	defer cancel()
+	for len(batch) > 0 {
+		stored, err := p.c.AddBatch(ctx, p.queue, batch)
+		if err == nil && len(stored) == 0 {
+			err = errors.Errorf("queue %s accepted no messages", p.queue)
+		}
+		for i, m := range stored {
+			p.report(Report{Message: batch[i], ID: m.ID})
+		}
+		batch = batch[len(stored):]
+		if err != nil {
+			p.report(Report{Message: batch[0], Err: err})
+			batch = batch[1:]
+		}
+	}
+}
+
+func (p *Producer) report(r Report) {
+	if p.reports != nil {
+		p.reports <- r
+		return
+	}
+	if r.Err != nil {
+		p.failures++
+		p.err = r.Err
+	}
+}
+
+// failed returns an error describing the messages that could not be added
+// since failures were last received by a caller of Flush. It must be called
+// from the producer's goroutine, or once that goroutine has stopped.
+func (p *Producer) failed() error {
+	if p.failures == 0 {
+		return nil
+	}
+	return errors.Wrapf(p.err, "cannot add %d messages", p.failures)
+}
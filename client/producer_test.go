@@ -0,0 +1,226 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+	"github.com/negz/q/memory"
+	"github.com/negz/q/proto"
+)
+
+// A queueQClient adds batches of messages to an in-memory queue.
+type queueQClient struct {
+	proto.QClient
+	queue q.Queue
+
+	mx      *sync.Mutex
+	batches int
+}
+
+func (c *queueQClient) AddBatch(ctx context.Context, r *proto.AddBatchRequest, _ ...grpc.CallOption) (*proto.AddBatchResponse, error) {
+	c.mx.Lock()
+	c.batches++
+	c.mx.Unlock()
+	rsp := &proto.AddBatchResponse{}
+	for i, nm := range r.GetMessages() {
+		m, _ := proto.ToNewMessage(nm)
+		if err := c.queue.AddContext(ctx, m); err != nil {
+			if i > 0 {
+				break
+			}
+			return nil, e.GRPC(err)
+		}
+		pm, _ := proto.FromMessage(m)
+		rsp.Messages = append(rsp.Messages, pm)
+	}
+	return rsp, nil
+}
+
+func TestProducer(t *testing.T) {
+	queue := memory.New()
+	qc := &queueQClient{queue: queue, mx: &sync.Mutex{}}
+	p := New(qc, WithBackoff(NoRetries)).NewProducer(queue.ID(), WithBatchSize(10), WithLinger(time.Hour), WithReports())
+
+	produced := 25
+	for i := 0; i < produced; i++ {
+		m := q.NewMessage([]byte(fmt.Sprintf("soyuz %d", i)))
+		if err := p.Produce(context.Background(), m); err != nil {
+			t.Fatalf("p.Produce(%v): %v", m, err)
+		}
+	}
+	go p.Close(context.Background())
+
+	i := 0
+	for r := range p.Reports() {
+		if r.Err != nil {
+			t.Errorf("p.Reports(): report for %s: %v", r.Message.Payload, r.Err)
+			continue
+		}
+		want := fmt.Sprintf("soyuz %d", i)
+		if string(r.Message.Payload) != want {
+			t.Errorf("p.Reports(): want report for %s, got %s", want, r.Message.Payload)
+		}
+		m, err := queue.Get(r.ID)
+		if err != nil {
+			t.Errorf("queue.Get(%v): %v", r.ID, err)
+			continue
+		}
+		if string(m.Payload) != want {
+			t.Errorf("queue.Get(%v): want %s, got %s", r.ID, want, m.Payload)
+		}
+		i++
+	}
+	if i != produced {
+		t.Errorf("p.Reports(): want %v reports, got %v", produced, i)
+	}
+	// Batches are sent when full, and the remainder when the producer closes.
+	if qc.batches != 3 {
+		t.Errorf("p.Close(): want 3 batches, got %v", qc.batches)
+	}
+}
+
+func TestProducerFailures(t *testing.T) {
+	queue := memory.New(memory.Limit(3))
+	qc := &queueQClient{queue: queue, mx: &sync.Mutex{}}
+	p := New(qc, WithBackoff(NoRetries)).NewProducer(queue.ID(), WithLinger(time.Millisecond))
+
+	for i := 0; i < 5; i++ {
+		m := q.NewMessage([]byte(fmt.Sprintf("salyut %d", i)))
+		if err := p.Produce(context.Background(), m); err != nil {
+			t.Fatalf("p.Produce(%v): %v", m, err)
+		}
+	}
+	if err := p.Flush(context.Background()); !e.IsFull(err) {
+		t.Errorf("p.Flush(): want error satisfying e.IsFull(), got %v", err)
+	}
+	if err := p.Close(context.Background()); err != nil {
+		t.Errorf("p.Close(): %v", err)
+	}
+	if err := p.Produce(context.Background(), q.NewMessage([]byte("mir"))); err != ErrClosed {
+		t.Errorf("p.Produce(): want %v, got %v", ErrClosed, err)
+	}
+}
+
+func TestProducerBackPressure(t *testing.T) {
+	queue := memory.New()
+	qc := &queueQClient{queue: queue, mx: &sync.Mutex{}}
+	p := New(qc).NewProducer(queue.ID(), WithBufferSize(1), WithBatchSize(1), WithReports())
+	defer func() {
+		go func() {
+			for range p.Reports() {
+			}
+		}()
+		p.Close(context.Background())
+	}()
+
+	// Nothing receives reports, so once the report channel and buffer fill
+	// the producer stops accepting messages.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	for i := 0; i < 10; i++ {
+		if err := p.Produce(ctx, q.NewMessage([]byte("skylab"))); err != nil {
+			return
+		}
+	}
+	t.Errorf("p.Produce(): want error once buffer is full")
+}
+
+func TestProducerSendTimeout(t *testing.T) {
+	queue := memory.New(memory.Limit(1), memory.Overflow(q.Block))
+	qc := &queueQClient{queue: queue, mx: &sync.Mutex{}}
+	p := New(qc, WithBackoff(NoRetries)).NewProducer(queue.ID(), WithSendTimeout(10*time.Millisecond))
+
+	for i := 0; i < 2; i++ {
+		m := q.NewMessage([]byte(fmt.Sprintf("tiangong %d", i)))
+		if err := p.Produce(context.Background(), m); err != nil {
+			t.Fatalf("p.Produce(%v): %v", m, err)
+		}
+	}
+	// The second message blocks until the send times out.
+	if err := p.Flush(context.Background()); !e.IsFull(err) {
+		t.Errorf("p.Flush(): want error satisfying e.IsFull(), got %v", err)
+	}
+	if err := p.Close(context.Background()); err != nil {
+		t.Errorf("p.Close(): %v", err)
+	}
+
+	p = New(qc, WithBackoff(NoRetries)).NewProducer(queue.ID(), WithSendTimeout(time.Second))
+	m := q.NewMessage([]byte("tiangong"))
+	if err := p.Produce(context.Background(), m); err != nil {
+		t.Fatalf("p.Produce(%v): %v", m, err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := p.Close(ctx); errors.Cause(err) != context.DeadlineExceeded {
+		t.Errorf("p.Close(): want %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestProducerCloseDuringFlush(t *testing.T) {
+	queue := memory.New(memory.Limit(1), memory.Overflow(q.Block))
+	qc := &queueQClient{queue: queue, mx: &sync.Mutex{}}
+	p := New(qc, WithBackoff(NoRetries)).NewProducer(queue.ID(), WithLinger(time.Hour), WithSendTimeout(100*time.Millisecond))
+
+	for i := 0; i < 2; i++ {
+		m := q.NewMessage([]byte(fmt.Sprintf("tiangong %d", i)))
+		if err := p.Produce(context.Background(), m); err != nil {
+			t.Fatalf("p.Produce(%v): %v", m, err)
+		}
+	}
+
+	// The second message blocks until the send times out, so the producer is
+	// closed while the flush is in flight. Its failure must not be lost.
+	flushed := make(chan error, 1)
+	go func() { flushed <- p.Flush(context.Background()) }()
+	time.Sleep(20 * time.Millisecond)
+	if err := p.Close(context.Background()); !e.IsFull(err) {
+		t.Errorf("p.Close(): want error satisfying e.IsFull(), got %v", err)
+	}
+	if err := <-flushed; err != ErrClosed {
+		t.Errorf("p.Flush(): want %v, got %v", ErrClosed, err)
+	}
+}
+
+func TestProducerCloseFullBuffer(t *testing.T) {
+	queue := memory.New()
+	qc := &queueQClient{queue: queue, mx: &sync.Mutex{}}
+	p := New(qc).NewProducer(queue.ID(), WithBufferSize(1), WithBatchSize(1), WithReports())
+
+	// Nothing receives reports, so the producer stops adding messages and
+	// these calls block waiting for room in the buffer.
+	produced := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		go func() { produced <- p.Produce(context.Background(), q.NewMessage([]byte("skylab"))) }()
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	closed := make(chan error, 1)
+	go func() { closed <- p.Close(ctx) }()
+	select {
+	case err := <-closed:
+		if errors.Cause(err) != context.DeadlineExceeded {
+			t.Errorf("p.Close(): want %v, got %v", context.DeadlineExceeded, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("p.Close(): still blocked long after its context was done")
+	}
+
+	// Calls to Produce that were blocked when the producer closed fail.
+	for i := 0; i < 10; i++ {
+		if err := <-produced; err != nil && err != ErrClosed {
+			t.Errorf("p.Produce(): want nil or %v, got %v", ErrClosed, err)
+		}
+	}
+	for range p.Reports() {
+	}
+}
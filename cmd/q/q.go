@@ -5,11 +5,16 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
+	"golang.org/x/net/context"
 	kingpin "gopkg.in/alecthomas/kingpin.v2"
 
+	"github.com/negz/q"
+	"github.com/negz/q/certs"
+	"github.com/negz/q/chaos"
 	"github.com/negz/q/manager"
 	"github.com/negz/q/metrics"
 	"github.com/negz/q/rpc"
@@ -18,6 +23,7 @@ import (
 const (
 	metricsEndpoint  = "/metrics"
 	shutdownEndpoint = "/quitquitquit"
+	chaosEndpoint    = "/chaos"
 )
 
 func main() {
@@ -27,6 +33,25 @@ func main() {
 		debug    = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
 		listen   = app.Flag("listen", "Address at which to listen for gRPC connections.").Default(":10002").String()
 		listenMx = app.Flag("metrics", "Address at which to expose Prometheus metrics.").Default(":10003").String()
+		maxBytes = app.Flag("max-queue-bytes", "Default maximum total payload bytes of new queues. -1 for unlimited.").Default("-1").Int()
+		maxMsg   = app.Flag("max-message-bytes", "Maximum message payload bytes. Also the default for new queues.").Default(strconv.Itoa(rpc.DefaultMaxMessageBytes)).Int()
+		expiry   = app.Flag("expiry-interval", "How often to delete queues that have been idle for longer than their expire-after-idle setting. 0 to never delete idle queues.").Default(manager.DefaultExpiryInterval.String()).Duration()
+		tmpIdle  = app.Flag("temporary-idle-timeout", "Default expire_after_idle of temporary queues. 0 to delete them only when their client disconnects.").Default(rpc.DefaultTemporaryIdleTimeout.String()).Duration()
+		tlsCert  = app.Flag("tls-cert", "PEM encoded certificate with which to serve gRPC over TLS. Reloaded when it changes.").ExistingFile()
+		tlsKey   = app.Flag("tls-key", "PEM encoded private key of the TLS certificate. Reloaded when it changes.").ExistingFile()
+		clientCA = app.Flag("client-ca", "PEM encoded certificate authorities. Require TLS clients to present a certificate signed by one of them. Reloaded when it changes.").ExistingFile()
+		tokens   = app.Flag("auth-tokens", "File of principals and their bearer tokens, one pair per line. Clients must authenticate with a token, or with a certificate if --auth-client-certs is set.").ExistingFile()
+		certAuth = app.Flag("auth-client-certs", "Authenticate clients by the common name of their certificate. Requires --client-ca.").Bool()
+		policy   = app.Flag("auth-policy", "JSON file of grants that authorize principals to operate on queues.").ExistingFile()
+		chaotic  = app.Flag("chaos", "Allow faults to be injected via the "+chaosEndpoint+" endpoint of the metrics address. Do not use in production.").Bool()
+
+		budgetQueues   = app.Flag("budget-queues", "Maximum number of queues. -1 for unlimited.").Default("-1").Int()
+		budgetMessages = app.Flag("budget-messages", "Maximum number of messages across all queues. -1 for unlimited.").Default("-1").Int()
+		budgetBytes    = app.Flag("budget-bytes", "Maximum total payload bytes across all queues. -1 for unlimited.").Default("-1").Int()
+		tenantTag      = app.Flag("tenant-tag", "Queue tag key that identifies the tenant a queue belongs to.").String()
+		tenantQueues   = app.Flag("tenant-queues", "Maximum number of queues per tenant. -1 for unlimited.").Default("-1").Int()
+		tenantMessages = app.Flag("tenant-messages", "Maximum number of messages across each tenant's queues. -1 for unlimited.").Default("-1").Int()
+		tenantBytes    = app.Flag("tenant-bytes", "Maximum total payload bytes across each tenant's queues. -1 for unlimited.").Default("-1").Int()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -38,15 +63,70 @@ func main() {
 	kingpin.FatalIfError(err, "cannot create logger")
 
 	mx, gatherer := metrics.NewPrometheus()
-	m := manager.Instrumented(
+	budget := []manager.BudgetOption{
+		manager.WithGlobalBudget(manager.Budget{Queues: *budgetQueues, Messages: *budgetMessages, Bytes: *budgetBytes}),
+		manager.WithBudgetMetrics(mx),
+	}
+	if *tenantTag != "" {
+		tenant := manager.Budget{Queues: *tenantQueues, Messages: *tenantMessages, Bytes: *tenantBytes}
+		budget = append(budget, manager.WithTenantBudget(*tenantTag, tenant))
+	}
+	var m q.Manager = manager.Instrumented(
 		manager.New(),
 		manager.WithMetrics(mx),
 		manager.WithLogger(log),
 	)
+	injector := chaos.NewInjector(chaos.Faults{})
+	if *chaotic {
+		log.Warn("fault injection enabled", zap.String("endpoint", chaosEndpoint))
+		m = chaos.Manager(m, injector)
+	}
+	m = manager.Expiring(
+		context.Background(),
+		manager.Budgeted(m, budget...),
+		manager.WithExpiryInterval(*expiry),
+		manager.WithExpiryMetrics(mx),
+		manager.WithExpiryLogger(log),
+	)
 
 	l, err := net.Listen("tcp", *listen)
 	kingpin.FatalIfError(err, "cannot listen on requested address")
-	grpc := rpc.NewServer(l, m)
+	o := []rpc.Option{
+		rpc.WithMaxBytes(*maxBytes),
+		rpc.WithMaxMessageBytes(*maxMsg),
+		rpc.WithTemporaryIdleTimeout(*tmpIdle),
+	}
+	if *tlsCert != "" || *tlsKey != "" {
+		c, err := certs.Server(*tlsCert, *tlsKey, *clientCA)
+		kingpin.FatalIfError(err, "cannot load TLS certificates")
+		o = append(o, rpc.WithTLS(c))
+	} else if *clientCA != "" {
+		kingpin.Fatalf("--client-ca requires --tls-cert and --tls-key")
+	}
+	auth := []rpc.Authenticator{}
+	if *tokens != "" {
+		t, err := rpc.LoadTokens(*tokens)
+		kingpin.FatalIfError(err, "cannot load bearer tokens")
+		auth = append(auth, t)
+	}
+	if *certAuth {
+		if *clientCA == "" {
+			kingpin.Fatalf("--auth-client-certs requires --client-ca")
+		}
+		auth = append(auth, rpc.ClientCertificates())
+	}
+	if len(auth) > 0 {
+		o = append(o, rpc.WithAuthentication(rpc.Authenticators(auth...)))
+	}
+	if *policy != "" {
+		p, err := rpc.LoadPolicy(*policy)
+		kingpin.FatalIfError(err, "cannot load authorization policy")
+		if len(auth) == 0 {
+			log.Warn("authorizing unauthenticated clients; only grants to \"*\" apply")
+		}
+		o = append(o, rpc.WithAuthorization(p))
+	}
+	grpc := rpc.NewServer(l, m, o...)
 
 	r := http.NewServeMux()
 	r.Handle(metricsEndpoint, promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}))
@@ -54,6 +134,9 @@ func main() {
 		log.Info("shutdown requested", zap.String("remote", r.RemoteAddr))
 		os.Exit(0)
 	})
+	if *chaotic {
+		r.Handle(chaosEndpoint, injector)
+	}
 
 	e := make(chan error, 1)
 	go func(e chan error) {
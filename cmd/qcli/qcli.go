@@ -1,22 +1,35 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gogo/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
 	"golang.org/x/net/context"
+	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc"
 	kingpin "gopkg.in/alecthomas/kingpin.v2"
 
+	"github.com/negz/q/certs"
+	"github.com/negz/q/client"
 	"github.com/negz/q/proto"
 )
 
 var (
 	ctx        = context.Background()
 	marshaller = &jsonpb.Marshaler{Indent: "  ", EmitDefaults: true}
+
+	// compact marshals one JSON document per line.
+	compact = &jsonpb.Marshaler{EmitDefaults: true}
 )
 
 func queueStores() []string {
@@ -27,23 +40,53 @@ func queueStores() []string {
 	return s
 }
 
+func overflowPolicies() []string {
+	s := make([]string, 0, len(proto.QueueConfig_Overflow_value))
+	for value := range proto.QueueConfig_Overflow_value {
+		s = append(s, value)
+	}
+	return s
+}
+
 func main() {
 	var (
 		app    = kingpin.New(filepath.Base(os.Args[0]), "Queries and manages a queue server.").DefaultEnvars()
 		server = app.Flag("server", "Address at which to query queue server.").Short('s').Default(":10002").String()
 
+		tlsFlags = certs.NewClientFlags(app)
+		token    = app.Flag("token", "Bearer token with which to authenticate to the queue server.").String()
+
 		listQueues = app.Command("list", "List of all queues.")
 
 		getQueue   = app.Command("get", "Get details of a queue.")
 		getQueueID = getQueue.Arg("id", "ID of queue.").String()
 
-		deleteQueue   = app.Command("delete", "Delete a queue.")
-		deleteQueueID = deleteQueue.Arg("id", "ID of queue.").String()
+		deleteQueue      = app.Command("delete", "Delete a queue.")
+		deleteQueueID    = deleteQueue.Arg("id", "ID of queue.").String()
+		deleteQueueForce = deleteQueue.Flag("force", "Delete the queue even if it still holds messages.").Short('f').Bool()
+
+		updateQueue      = app.Command("update", "Change the settings of a queue. Only the supplied settings are changed.")
+		updateQueueID    = updateQueue.Arg("id", "ID of queue.").String()
+		updateQueueLimit = updateQueue.Flag("limit", "Message limit of queue. -1 for unlimited.").PlaceHolder("LIMIT").String()
+		updateQueueBytes = updateQueue.Flag("max-bytes", "Maximum total payload bytes of queue. -1 for unlimited.").PlaceHolder("BYTES").String()
+		updateQueueMsg   = updateQueue.Flag("max-message-bytes", "Maximum message payload bytes. -1 for unlimited.").PlaceHolder("BYTES").String()
+		updateQueuePol   = updateQueue.Flag("overflow", "What to do when adding to a full queue.").PlaceHolder("POLICY").HintAction(overflowPolicies).String()
+		updateQueueRet   = updateQueue.Flag("retention", "How long BoltDB queues and logs retain consumed messages for replay. Logs also discard older unconsumed messages. Not supported by MEMORY queues.").PlaceHolder("DURATION").String()
+		updateQueueIdle  = updateQueue.Flag("expire-after-idle", "Delete queue after this long without adds, pops, or peeks. 0 to never delete it.").PlaceHolder("DURATION").String()
+
+		purgeQueue    = app.Command("purge", "Remove all messages from a queue.")
+		purgeQueueID  = purgeQueue.Arg("id", "ID of queue.").String()
+		purgeQueueYes = purgeQueue.Flag("yes", "Do not prompt for confirmation.").Short('y').Bool()
 
 		newQueue      = app.Command("new", "Create a queue.")
 		newQueueStore = newQueue.Arg("store", "Backing store for queue.").HintAction(queueStores).String()
 		newQueueLimit = newQueue.Arg("limit", "Message limit of queue. -1 for unlimited.").Int64()
 		newQueueTags  = newQueue.Flag("tag", "Tag to apply to queue.").Short('t').StringMap()
+		newQueueBytes = newQueue.Flag("max-bytes", "Maximum total payload bytes of queue. -1 for unlimited, 0 for the server default.").Int64()
+		newQueueMsg   = newQueue.Flag("max-message-bytes", "Maximum message payload bytes. -1 for unlimited, 0 for the server default.").Int64()
+		newQueuePol   = newQueue.Flag("overflow", "What to do when adding to a full queue.").Default(proto.REJECT.String()).HintAction(overflowPolicies).String()
+		newQueueRet   = newQueue.Flag("retention", "How long BoltDB queues and logs retain consumed messages for replay. Logs also discard older unconsumed messages. Not supported by MEMORY queues.").Duration()
+		newQueueIdle  = newQueue.Flag("expire-after-idle", "Delete queue after this long without adds, pops, or peeks. 0 to never delete it.").Duration()
 
 		addQueueTag      = app.Command("tag", "Tag a queue.")
 		addQueueTagID    = addQueueTag.Arg("id", "ID of queue.").String()
@@ -58,16 +101,115 @@ func main() {
 		addMessage      = app.Command("add", "Add a message to a queue. Message payload is read from stdin.")
 		addMessageQueue = addMessage.Arg("id", "ID of queue in which to add message.").String()
 		addMessageTags  = addMessage.Flag("tag", "Tag to apply to message.").Short('t').StringMap()
+		addMessageReply = addMessage.Flag("reply-to", "ID of queue to which replies should be added.").String()
+		addMessageCorr  = addMessage.Flag("correlation-id", "Correlation ID of message, e.g. that of the message it replies to.").String()
+
+		request        = app.Command("request", "Add a message to a queue and wait for a reply. Message payload is read from stdin.")
+		requestQueue   = request.Arg("id", "ID of queue in which to add message.").String()
+		requestTags    = request.Flag("tag", "Tag to apply to message.").Short('t').StringMap()
+		requestTimeout = request.Flag("timeout", "How long to wait for a reply.").Default("30s").Duration()
 
 		popMessage      = app.Command("pop", "Consume a message from the queue.")
 		popMessageQueue = popMessage.Arg("queue", "ID of queue from which to pop message.").String()
+		popMessageTags  = popMessage.Flag("tag", "Only pop a message with this tag.").Short('t').StringMap()
 
 		peekMessage      = app.Command("peek", "Preview a message from the queue.")
 		peekMessageQueue = peekMessage.Arg("queue", "ID of queue in which to peek at message.").String()
+		peekMessageTags  = peekMessage.Flag("tag", "Only preview a message with this tag.").Short('t').StringMap()
+
+		browseMessages        = app.Command("browse", "List messages in a queue without consuming them, one JSON document per line.")
+		browseMessagesQueue   = browseMessages.Arg("queue", "ID of queue to browse.").String()
+		browseMessagesTags    = browseMessages.Flag("tag", "Only list messages with this tag.").Short('t').StringMap()
+		browseMessagesPreview = browseMessages.Flag("preview", "Truncate payloads to this many bytes. 0 for full payloads.").Int32()
+
+		getMessage      = app.Command("message", "Get a message from a queue by ID without consuming it.")
+		getMessageQueue = getMessage.Arg("queue", "ID of queue containing message.").String()
+		getMessageID    = getMessage.Arg("id", "ID of message.").String()
+
+		deleteMessage      = app.Command("remove", "Remove a message from a queue by ID.")
+		deleteMessageQueue = deleteMessage.Arg("queue", "ID of queue containing message.").String()
+		deleteMessageID    = deleteMessage.Arg("id", "ID of message.").String()
+
+		moveMessages     = app.Command("move", "Move messages from one queue to another.")
+		moveMessagesFrom = moveMessages.Arg("from", "ID of queue from which to move messages.").String()
+		moveMessagesTo   = moveMessages.Arg("to", "ID of queue to which to move messages.").String()
+		moveMessagesMax  = moveMessages.Flag("max", "Maximum number of messages to move. 0 for all messages.").Short('n').Int64()
+		moveMessagesTags = moveMessages.Flag("tag", "Only move messages with this tag.").Short('t').StringMap()
+
+		copyMessages     = app.Command("copy", "Copy messages from one queue to another.")
+		copyMessagesFrom = copyMessages.Arg("from", "ID of queue from which to copy messages.").String()
+		copyMessagesTo   = copyMessages.Arg("to", "ID of queue to which to copy messages.").String()
+		copyMessagesMax  = copyMessages.Flag("max", "Maximum number of messages to copy. 0 for all messages.").Short('n').Int64()
+		copyMessagesTags = copyMessages.Flag("tag", "Only copy messages with this tag.").Short('t').StringMap()
+
+		topic = app.Command("topic", "Manage topics, which fan out messages to subscribed queues.")
+
+		listTopics = topic.Command("list", "List all topics.")
+
+		getTopic   = topic.Command("get", "Get a topic.")
+		getTopicID = getTopic.Arg("id", "ID of topic.").String()
+
+		newTopic     = topic.Command("new", "Create a topic.")
+		newTopicTags = newTopic.Flag("tag", "Tag to apply to topic.").Short('t').StringMap()
+
+		deleteTopic   = topic.Command("delete", "Delete a topic.")
+		deleteTopicID = deleteTopic.Arg("id", "ID of topic.").String()
+
+		subscribe      = topic.Command("subscribe", "Subscribe a queue to a topic.")
+		subscribeTopic = subscribe.Arg("topic", "ID of topic.").String()
+		subscribeQueue = subscribe.Arg("queue", "ID of queue.").String()
+		subscribeTags  = subscribe.Flag("tag", "Only route messages with this tag to the queue.").Short('t').StringMap()
+
+		unsubscribe      = topic.Command("unsubscribe", "Unsubscribe a queue from a topic.")
+		unsubscribeTopic = unsubscribe.Arg("topic", "ID of topic.").String()
+		unsubscribeQueue = unsubscribe.Arg("queue", "ID of queue.").String()
+
+		publish      = topic.Command("publish", "Publish a message to a topic. Message payload is read from stdin.")
+		publishTopic = publish.Arg("id", "ID of topic to which to publish message.").String()
+		publishTags  = publish.Flag("tag", "Tag to apply to message.").Short('t').StringMap()
+
+		group = app.Command("group", "Manage the consumer groups of a log.")
+
+		listGroups      = group.Command("list", "List the consumer groups of a log.")
+		listGroupsQueue = listGroups.Arg("queue", "ID of log.").String()
+
+		getGroup      = group.Command("get", "Get a consumer group.")
+		getGroupQueue = getGroup.Arg("queue", "ID of log.").String()
+		getGroupName  = getGroup.Arg("group", "Name of consumer group.").String()
+
+		newGroup      = group.Command("new", "Create a consumer group that reads from the oldest message in a log.")
+		newGroupQueue = newGroup.Arg("queue", "ID of log.").String()
+		newGroupName  = newGroup.Arg("group", "Name of consumer group.").String()
+
+		deleteGroup      = group.Command("delete", "Delete a consumer group.")
+		deleteGroupQueue = deleteGroup.Arg("queue", "ID of log.").String()
+		deleteGroupName  = deleteGroup.Arg("group", "Name of consumer group.").String()
+
+		read      = group.Command("read", "Read messages from a log without committing the consumer group's offset.")
+		readQueue = read.Arg("queue", "ID of log.").String()
+		readGroup = read.Arg("group", "Name of consumer group.").String()
+		readMax   = read.Flag("max", "Maximum number of messages to read. 0 for all messages.").Short('n').Int64()
+
+		commit       = group.Command("commit", "Commit the offset of a consumer group.")
+		commitQueue  = commit.Arg("queue", "ID of log.").String()
+		commitGroup  = commit.Arg("group", "Name of consumer group.").String()
+		commitOffset = commit.Arg("offset", "Offset of the next message the group will read.").Uint64()
+
+		replay       = app.Command("replay", "Replay retained messages of a queue, or rewind a consumer group of a log.")
+		replayQueue  = replay.Arg("queue", "ID of queue.").String()
+		replayGroup  = replay.Flag("group", "Name of consumer group to rewind.").Short('g').String()
+		replaySince  = replay.Flag("since", "Replay messages created within this long ago.").Duration()
+		replayOffset = replay.Flag("offset", "Replay messages from this offset. Ignored if --since is set.").Uint64()
 	)
 	kp := kingpin.MustParse(app.Parse(os.Args[1:]))
 
-	conn, err := grpc.Dial(*server, grpc.WithInsecure())
+	tls, err := tlsFlags.DialOption()
+	kingpin.FatalIfError(err, "cannot load TLS certificates")
+	dial := []grpc.DialOption{tls}
+	if *token != "" {
+		dial = append(dial, grpc.WithPerRPCCredentials(client.BearerToken(*token)))
+	}
+	conn, err := grpc.Dial(*server, dial...)
 	kingpin.FatalIfError(err, "cannot dial server %s", *server)
 	defer conn.Close()
 	h := &handlers{proto.NewQClient(conn)}
@@ -78,19 +220,70 @@ func main() {
 	case getQueue.FullCommand():
 		h.getQueue(*getQueueID)
 	case deleteQueue.FullCommand():
-		h.deleteQueue(*deleteQueueID)
+		h.deleteQueue(*deleteQueueID, *deleteQueueForce)
+	case updateQueue.FullCommand():
+		h.updateQueue(*updateQueueID, map[string]string{
+			"limit":             *updateQueueLimit,
+			"max_bytes":         *updateQueueBytes,
+			"max_message_bytes": *updateQueueMsg,
+			"overflow":          *updateQueuePol,
+			"retention":         *updateQueueRet,
+			"expire_after_idle": *updateQueueIdle,
+		})
+	case purgeQueue.FullCommand():
+		h.purgeQueue(*purgeQueueID, *purgeQueueYes)
 	case newQueue.FullCommand():
-		h.newQueue(*newQueueStore, *newQueueLimit, *newQueueTags)
+		h.newQueue(*newQueueStore, *newQueueLimit, *newQueueBytes, *newQueueMsg, *newQueuePol, *newQueueRet, *newQueueIdle, *newQueueTags)
 	case addQueueTag.FullCommand():
 		h.addQueueTag(*addQueueTagID, *addQueueTagKey, *addQueueTagValue)
 	case deleteQueueTag.FullCommand():
 		h.deleteQueueTag(*deleteQueueTagID, *deleteQueueTagKey, *deleteQueueTagValue)
 	case addMessage.FullCommand():
-		h.addMessage(*addMessageQueue, *addMessageTags)
+		h.addMessage(*addMessageQueue, *addMessageTags, *addMessageReply, *addMessageCorr)
+	case request.FullCommand():
+		h.request(*requestQueue, *requestTags, *requestTimeout)
 	case popMessage.FullCommand():
-		h.popMessage(*popMessageQueue)
+		h.popMessage(*popMessageQueue, *popMessageTags)
 	case peekMessage.FullCommand():
-		h.peekMessage(*peekMessageQueue)
+		h.peekMessage(*peekMessageQueue, *peekMessageTags)
+	case browseMessages.FullCommand():
+		h.browseMessages(*browseMessagesQueue, *browseMessagesTags, *browseMessagesPreview)
+	case getMessage.FullCommand():
+		h.getMessage(*getMessageQueue, *getMessageID)
+	case deleteMessage.FullCommand():
+		h.deleteMessage(*deleteMessageQueue, *deleteMessageID)
+	case moveMessages.FullCommand():
+		h.moveMessages(*moveMessagesFrom, *moveMessagesTo, *moveMessagesMax, *moveMessagesTags)
+	case copyMessages.FullCommand():
+		h.copyMessages(*copyMessagesFrom, *copyMessagesTo, *copyMessagesMax, *copyMessagesTags)
+	case listTopics.FullCommand():
+		h.listTopics()
+	case getTopic.FullCommand():
+		h.getTopic(*getTopicID)
+	case newTopic.FullCommand():
+		h.newTopic(*newTopicTags)
+	case deleteTopic.FullCommand():
+		h.deleteTopic(*deleteTopicID)
+	case subscribe.FullCommand():
+		h.subscribe(*subscribeTopic, *subscribeQueue, *subscribeTags)
+	case unsubscribe.FullCommand():
+		h.unsubscribe(*unsubscribeTopic, *unsubscribeQueue)
+	case publish.FullCommand():
+		h.publish(*publishTopic, *publishTags)
+	case listGroups.FullCommand():
+		h.listGroups(*listGroupsQueue)
+	case getGroup.FullCommand():
+		h.getGroup(*getGroupQueue, *getGroupName)
+	case newGroup.FullCommand():
+		h.newGroup(*newGroupQueue, *newGroupName)
+	case deleteGroup.FullCommand():
+		h.deleteGroup(*deleteGroupQueue, *deleteGroupName)
+	case read.FullCommand():
+		h.read(*readQueue, *readGroup, *readMax)
+	case commit.FullCommand():
+		h.commit(*commitQueue, *commitGroup, *commitOffset)
+	case replay.FullCommand():
+		h.replay(*replayQueue, *replayGroup, *replaySince, *replayOffset)
 	}
 }
 
@@ -99,8 +292,17 @@ type handlers struct {
 }
 
 func (h *handlers) listQueues() {
-	rsp, err := h.c.ListQueues(ctx, &proto.ListQueuesRequest{})
-	kingpin.FatalIfError(err, "cannot list queues")
+	rsp := &proto.ListQueuesResponse{}
+	req := &proto.ListQueuesRequest{}
+	for {
+		page, err := h.c.ListQueues(ctx, req)
+		kingpin.FatalIfError(err, "cannot list queues")
+		rsp.Queues = append(rsp.Queues, page.GetQueues()...)
+		if page.GetNextPageToken() == "" {
+			break
+		}
+		req.PageToken = page.GetNextPageToken()
+	}
 	j, err := marshaller.MarshalToString(rsp)
 	kingpin.FatalIfError(err, "cannot marshal queues to JSON:\n%#v", rsp)
 	fmt.Printf("%s\n", j)
@@ -114,16 +316,84 @@ func (h *handlers) getQueue(id string) {
 	fmt.Printf("%s\n", j)
 }
 
-func (h *handlers) deleteQueue(id string) {
-	_, err := h.c.DeleteQueue(ctx, &proto.DeleteQueueRequest{QueueId: id})
+func (h *handlers) deleteQueue(id string, force bool) {
+	_, err := h.c.DeleteQueue(ctx, &proto.DeleteQueueRequest{QueueId: id, Force: force})
 	kingpin.FatalIfError(err, "cannot delete queue")
 }
 
-func (h *handlers) newQueue(store string, limit int64, tags map[string]string) {
+// updateQueue updates the settings of a queue. Settings are keyed by their
+// update mask path and passed as strings so that those left empty can be
+// omitted from the update mask.
+func (h *handlers) updateQueue(id string, settings map[string]string) {
+	req := &proto.UpdateQueueRequest{QueueId: id, Config: &proto.QueueConfig{}, UpdateMask: &field_mask.FieldMask{}}
+	fields := map[string]*int64{
+		"limit":             &req.Config.Limit,
+		"max_bytes":         &req.Config.MaxBytes,
+		"max_message_bytes": &req.Config.MaxMessageBytes,
+	}
+	durations := map[string]**duration.Duration{
+		"retention":         &req.Config.Retention,
+		"expire_after_idle": &req.Config.ExpireAfterIdle,
+	}
+	for path, value := range settings {
+		if value == "" {
+			continue
+		}
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, path)
+		switch path {
+		case "overflow":
+			req.Config.Overflow = overflowPolicy(value)
+			continue
+		case "retention", "expire_after_idle":
+			d, err := time.ParseDuration(value)
+			kingpin.FatalIfError(err, "cannot parse %s %s", path, value)
+			*durations[path] = ptypes.DurationProto(d)
+			continue
+		}
+		v, err := strconv.ParseInt(value, 10, 64)
+		kingpin.FatalIfError(err, "cannot parse %s %s", path, value)
+		*fields[path] = v
+	}
+	if len(req.UpdateMask.Paths) == 0 {
+		kingpin.Fatalf("no settings to update")
+	}
+	rsp, err := h.c.UpdateQueue(ctx, req)
+	kingpin.FatalIfError(err, "cannot update queue")
+	j, err := marshaller.MarshalToString(rsp)
+	kingpin.FatalIfError(err, "cannot marshal updated queue to JSON:\n%#v", rsp)
+	fmt.Printf("%s\n", j)
+}
+
+func (h *handlers) purgeQueue(id string, yes bool) {
+	if !yes && !confirm(fmt.Sprintf("Remove all messages from queue %s?", id)) {
+		kingpin.Fatalf("not purging queue %s", id)
+	}
+	rsp, err := h.c.PurgeQueue(ctx, &proto.PurgeQueueRequest{QueueId: id})
+	kingpin.FatalIfError(err, "cannot purge queue")
+	j, err := marshaller.MarshalToString(rsp)
+	kingpin.FatalIfError(err, "cannot marshal purge result to JSON:\n%#v", rsp)
+	fmt.Printf("%s\n", j)
+}
+
+// overflowPolicy parses the name of an overflow policy.
+func overflowPolicy(name string) proto.QueueConfig_Overflow {
+	o, ok := proto.QueueConfig_Overflow_value[name]
+	if !ok {
+		kingpin.Fatalf("unknown overflow policy %s", name)
+	}
+	return proto.QueueConfig_Overflow(o)
+}
+
+func (h *handlers) newQueue(store string, limit, maxBytes, maxMsg int64, overflow string, retention, idle time.Duration, tags map[string]string) {
 	req := &proto.NewQueueRequest{
-		Store: proto.Queue_Store(proto.Queue_Store_value[store]),
-		Limit: limit,
-		Tags:  tagsFromMap(tags),
+		Store:           proto.Queue_Store(proto.Queue_Store_value[store]),
+		Limit:           limit,
+		Tags:            tagsFromMap(tags),
+		MaxBytes:        maxBytes,
+		MaxMessageBytes: maxMsg,
+		Overflow:        overflowPolicy(overflow),
+		Retention:       ptypes.DurationProto(retention),
+		ExpireAfterIdle: ptypes.DurationProto(idle),
 	}
 	rsp, err := h.c.NewQueue(ctx, req)
 	kingpin.FatalIfError(err, "cannot create new queue")
@@ -144,12 +414,12 @@ func (h *handlers) deleteQueueTag(id, k, v string) {
 	kingpin.FatalIfError(err, "cannot untag queue")
 }
 
-func (h *handlers) addMessage(id string, tags map[string]string) {
+func (h *handlers) addMessage(id string, tags map[string]string, replyTo, correlationID string) {
 	payload, err := ioutil.ReadAll(os.Stdin)
 	kingpin.FatalIfError(err, "cannot read message payload from stdin")
 	req := &proto.AddRequest{
 		QueueId: id,
-		Message: &proto.NewMessage{Payload: payload, Tags: tagsFromMap(tags)},
+		Message: &proto.NewMessage{Payload: payload, Tags: tagsFromMap(tags), ReplyTo: replyTo, CorrelationId: correlationID},
 	}
 	rsp, err := h.c.Add(ctx, req)
 	kingpin.FatalIfError(err, "cannot add message to queue")
@@ -158,22 +428,68 @@ func (h *handlers) addMessage(id string, tags map[string]string) {
 	fmt.Printf("%s\n", j)
 }
 
-func (h *handlers) popMessage(id string) {
-	rsp, err := h.c.Pop(ctx, &proto.PopRequest{QueueId: id})
+func (h *handlers) request(id string, tags map[string]string, timeout time.Duration) {
+	payload, err := ioutil.ReadAll(os.Stdin)
+	kingpin.FatalIfError(err, "cannot read message payload from stdin")
+	req := &proto.RequestRequest{
+		QueueId: id,
+		Message: &proto.NewMessage{Payload: payload, Tags: tagsFromMap(tags)},
+	}
+	tctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	rsp, err := h.c.Request(tctx, req)
+	kingpin.FatalIfError(err, "cannot request reply")
+	j, err := marshaller.MarshalToString(rsp)
+	kingpin.FatalIfError(err, "cannot marshal reply to JSON:\n%#v", rsp)
+	fmt.Printf("%s\n", j)
+}
+
+func (h *handlers) popMessage(id string, tags map[string]string) {
+	rsp, err := h.c.Pop(ctx, &proto.PopRequest{QueueId: id, Filter: tagsFromMap(tags)})
 	kingpin.FatalIfError(err, "cannot pop message from queue")
 	j, err := marshaller.MarshalToString(rsp)
 	kingpin.FatalIfError(err, "cannot marshal popped message to JSON:\n%#v", rsp)
 	fmt.Printf("%s\n", j)
 }
 
-func (h *handlers) peekMessage(id string) {
-	rsp, err := h.c.Peek(ctx, &proto.PeekRequest{QueueId: id})
+func (h *handlers) peekMessage(id string, tags map[string]string) {
+	rsp, err := h.c.Peek(ctx, &proto.PeekRequest{QueueId: id, Filter: tagsFromMap(tags)})
 	kingpin.FatalIfError(err, "cannot peek at message in queue")
 	j, err := marshaller.MarshalToString(rsp)
 	kingpin.FatalIfError(err, "cannot marshal message to JSON:\n%#v", rsp)
 	fmt.Printf("%s\n", j)
 }
 
+func (h *handlers) browseMessages(id string, tags map[string]string, preview int32) {
+	req := &proto.ListMessagesRequest{QueueId: id, Filter: tagsFromMap(tags), PreviewLength: preview}
+	for {
+		page, err := h.c.ListMessages(ctx, req)
+		kingpin.FatalIfError(err, "cannot list messages in queue")
+		for _, m := range page.GetMessages() {
+			j, err := compact.MarshalToString(m)
+			kingpin.FatalIfError(err, "cannot marshal message to JSON:\n%#v", m)
+			fmt.Printf("%s\n", j)
+		}
+		if page.GetNextPageToken() == "" {
+			return
+		}
+		req.PageToken = page.GetNextPageToken()
+	}
+}
+
+func (h *handlers) getMessage(queue, id string) {
+	rsp, err := h.c.GetMessage(ctx, &proto.GetMessageRequest{QueueId: queue, MessageId: id})
+	kingpin.FatalIfError(err, "cannot get message")
+	j, err := marshaller.MarshalToString(rsp)
+	kingpin.FatalIfError(err, "cannot marshal message to JSON:\n%#v", rsp)
+	fmt.Printf("%s\n", j)
+}
+
+func (h *handlers) deleteMessage(queue, id string) {
+	_, err := h.c.DeleteMessage(ctx, &proto.DeleteMessageRequest{QueueId: queue, MessageId: id})
+	kingpin.FatalIfError(err, "cannot remove message")
+}
+
 func tagsFromMap(tags map[string]string) []*proto.Tag {
 	t := make([]*proto.Tag, 0, len(tags))
 	for k, v := range tags {
@@ -181,3 +497,163 @@ func tagsFromMap(tags map[string]string) []*proto.Tag {
 	}
 	return t
 }
+
+// confirm prompts the user on stderr and reports whether they answered yes.
+func confirm(prompt string) bool {
+	fmt.Fprintf(os.Stderr, "%s [y/N] ", prompt)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
+func (h *handlers) moveMessages(from, to string, max int64, tags map[string]string) {
+	req := &proto.MoveMessagesRequest{SourceQueueId: from, DestinationQueueId: to, MaxCount: max, Filter: tagsFromMap(tags)}
+	rsp, err := h.c.MoveMessages(ctx, req)
+	kingpin.FatalIfError(err, "cannot move messages")
+	j, err := marshaller.MarshalToString(rsp)
+	kingpin.FatalIfError(err, "cannot marshal move result to JSON:\n%#v", rsp)
+	fmt.Printf("%s\n", j)
+}
+
+func (h *handlers) copyMessages(from, to string, max int64, tags map[string]string) {
+	req := &proto.CopyMessagesRequest{SourceQueueId: from, DestinationQueueId: to, MaxCount: max, Filter: tagsFromMap(tags)}
+	rsp, err := h.c.CopyMessages(ctx, req)
+	kingpin.FatalIfError(err, "cannot copy messages")
+	j, err := marshaller.MarshalToString(rsp)
+	kingpin.FatalIfError(err, "cannot marshal copy result to JSON:\n%#v", rsp)
+	fmt.Printf("%s\n", j)
+}
+
+func (h *handlers) listTopics() {
+	rsp := &proto.ListTopicsResponse{}
+	req := &proto.ListTopicsRequest{}
+	for {
+		page, err := h.c.ListTopics(ctx, req)
+		kingpin.FatalIfError(err, "cannot list topics")
+		rsp.Topics = append(rsp.Topics, page.GetTopics()...)
+		if page.GetNextPageToken() == "" {
+			break
+		}
+		req.PageToken = page.GetNextPageToken()
+	}
+	j, err := marshaller.MarshalToString(rsp)
+	kingpin.FatalIfError(err, "cannot marshal topics to JSON:\n%#v", rsp)
+	fmt.Printf("%s\n", j)
+}
+
+func (h *handlers) getTopic(id string) {
+	rsp, err := h.c.GetTopic(ctx, &proto.GetTopicRequest{TopicId: id})
+	kingpin.FatalIfError(err, "cannot get topic")
+	j, err := marshaller.MarshalToString(rsp)
+	kingpin.FatalIfError(err, "cannot marshal topic to JSON:\n%#v", rsp)
+	fmt.Printf("%s\n", j)
+}
+
+func (h *handlers) newTopic(tags map[string]string) {
+	rsp, err := h.c.NewTopic(ctx, &proto.NewTopicRequest{Tags: tagsFromMap(tags)})
+	kingpin.FatalIfError(err, "cannot create new topic")
+	j, err := marshaller.MarshalToString(rsp)
+	kingpin.FatalIfError(err, "cannot marshal new topic to JSON:\n%#v", rsp)
+	fmt.Printf("%s\n", j)
+}
+
+func (h *handlers) deleteTopic(id string) {
+	_, err := h.c.DeleteTopic(ctx, &proto.DeleteTopicRequest{TopicId: id})
+	kingpin.FatalIfError(err, "cannot delete topic")
+}
+
+func (h *handlers) subscribe(topic, queue string, tags map[string]string) {
+	req := &proto.SubscribeRequest{
+		TopicId:      topic,
+		Subscription: &proto.Subscription{QueueId: queue, Filter: tagsFromMap(tags)},
+	}
+	rsp, err := h.c.Subscribe(ctx, req)
+	kingpin.FatalIfError(err, "cannot subscribe queue to topic")
+	j, err := marshaller.MarshalToString(rsp)
+	kingpin.FatalIfError(err, "cannot marshal topic to JSON:\n%#v", rsp)
+	fmt.Printf("%s\n", j)
+}
+
+func (h *handlers) unsubscribe(topic, queue string) {
+	_, err := h.c.Unsubscribe(ctx, &proto.UnsubscribeRequest{TopicId: topic, QueueId: queue})
+	kingpin.FatalIfError(err, "cannot unsubscribe queue from topic")
+}
+
+func (h *handlers) publish(id string, tags map[string]string) {
+	payload, err := ioutil.ReadAll(os.Stdin)
+	kingpin.FatalIfError(err, "cannot read message payload from stdin")
+	req := &proto.PublishRequest{
+		TopicId: id,
+		Message: &proto.NewMessage{Payload: payload, Tags: tagsFromMap(tags)},
+	}
+	rsp, err := h.c.Publish(ctx, req)
+	kingpin.FatalIfError(err, "cannot publish message to topic")
+	j, err := marshaller.MarshalToString(rsp)
+	kingpin.FatalIfError(err, "cannot marshal published message to JSON:\n%#v", rsp)
+	fmt.Printf("%s\n", j)
+}
+
+func (h *handlers) listGroups(queue string) {
+	rsp, err := h.c.ListGroups(ctx, &proto.ListGroupsRequest{QueueId: queue})
+	kingpin.FatalIfError(err, "cannot list consumer groups")
+	j, err := marshaller.MarshalToString(rsp)
+	kingpin.FatalIfError(err, "cannot marshal consumer groups to JSON:\n%#v", rsp)
+	fmt.Printf("%s\n", j)
+}
+
+func (h *handlers) getGroup(queue, group string) {
+	rsp, err := h.c.GetGroup(ctx, &proto.GetGroupRequest{QueueId: queue, Group: group})
+	kingpin.FatalIfError(err, "cannot get consumer group")
+	j, err := marshaller.MarshalToString(rsp)
+	kingpin.FatalIfError(err, "cannot marshal consumer group to JSON:\n%#v", rsp)
+	fmt.Printf("%s\n", j)
+}
+
+func (h *handlers) newGroup(queue, group string) {
+	rsp, err := h.c.NewGroup(ctx, &proto.NewGroupRequest{QueueId: queue, Group: group})
+	kingpin.FatalIfError(err, "cannot create new consumer group")
+	j, err := marshaller.MarshalToString(rsp)
+	kingpin.FatalIfError(err, "cannot marshal new consumer group to JSON:\n%#v", rsp)
+	fmt.Printf("%s\n", j)
+}
+
+func (h *handlers) deleteGroup(queue, group string) {
+	_, err := h.c.DeleteGroup(ctx, &proto.DeleteGroupRequest{QueueId: queue, Group: group})
+	kingpin.FatalIfError(err, "cannot delete consumer group")
+}
+
+func (h *handlers) read(queue, group string, max int64) {
+	rsp, err := h.c.Read(ctx, &proto.ReadRequest{QueueId: queue, Group: group, MaxCount: max})
+	kingpin.FatalIfError(err, "cannot read from consumer group")
+	j, err := marshaller.MarshalToString(rsp)
+	kingpin.FatalIfError(err, "cannot marshal messages to JSON:\n%#v", rsp)
+	fmt.Printf("%s\n", j)
+}
+
+func (h *handlers) commit(queue, group string, offset uint64) {
+	rsp, err := h.c.Commit(ctx, &proto.CommitRequest{QueueId: queue, Group: group, Offset: offset})
+	kingpin.FatalIfError(err, "cannot commit consumer group offset")
+	j, err := marshaller.MarshalToString(rsp)
+	kingpin.FatalIfError(err, "cannot marshal consumer group to JSON:\n%#v", rsp)
+	fmt.Printf("%s\n", j)
+}
+
+func (h *handlers) replay(queue, group string, since time.Duration, offset uint64) {
+	req := &proto.SeekRequest{QueueId: queue, Group: group, Offset: offset}
+	if since > 0 {
+		t, err := ptypes.TimestampProto(time.Now().Add(-since))
+		kingpin.FatalIfError(err, "cannot convert replay time to protobuf")
+		req.Time = t
+	}
+	rsp, err := h.c.Seek(ctx, req)
+	kingpin.FatalIfError(err, "cannot replay queue")
+	j, err := marshaller.MarshalToString(rsp)
+	kingpin.FatalIfError(err, "cannot marshal replay response to JSON:\n%#v", rsp)
+	fmt.Printf("%s\n", j)
+}
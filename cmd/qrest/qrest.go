@@ -16,6 +16,7 @@ import (
 	"google.golang.org/grpc"
 	kingpin "gopkg.in/alecthomas/kingpin.v2"
 
+	"github.com/negz/q/certs"
 	"github.com/negz/q/proto"
 )
 
@@ -56,6 +57,8 @@ func main() {
 		debug  = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
 		stop   = app.Flag("close-after", "Wait this long at shutdown before closing HTTP connections.").Default("1m").Duration()
 		kill   = app.Flag("kill-after", "Wait this long at shutdown before exiting.").Default("2m").Duration()
+
+		tlsFlags = certs.NewClientFlags(app)
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -71,7 +74,9 @@ func main() {
 	defer cancel()
 
 	gw := runtime.NewServeMux(runtime.WithForwardResponseOption(logForwardedResponse(log)))
-	kingpin.FatalIfError(proto.RegisterQHandlerFromEndpoint(ctx, gw, *server, []grpc.DialOption{grpc.WithInsecure()}), "cannot register REST handler")
+	dial, err := tlsFlags.DialOption()
+	kingpin.FatalIfError(err, "cannot load TLS certificates")
+	kingpin.FatalIfError(proto.RegisterQHandlerFromEndpoint(ctx, gw, *server, []grpc.DialOption{dial}), "cannot register REST handler")
 
 	r := http.NewServeMux()
 	r.Handle("/", gw)
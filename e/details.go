@@ -0,0 +1,76 @@
+package e
+
+import (
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+type withDetail struct {
+	error
+	detail proto.Message
+}
+
+// WithResource annotates an error with the type and name of the resource it
+// concerns, for example a queue and its ID. WithResource returns nil if err is
+// nil.
+func WithResource(err error, kind, name string) error {
+	if err == nil {
+		return nil
+	}
+	return &withDetail{err, &errdetails.ResourceInfo{ResourceType: kind, ResourceName: name}}
+}
+
+// WithQuota annotates an error with the subject of a quota that was exhausted,
+// for example a queue or tenant, and a description of the limit that was hit.
+// WithQuota returns nil if err is nil.
+func WithQuota(err error, subject, description string) error {
+	if err == nil {
+		return nil
+	}
+	v := &errdetails.QuotaFailure_Violation{Subject: subject, Description: description}
+	return &withDetail{err, &errdetails.QuotaFailure{Violations: []*errdetails.QuotaFailure_Violation{v}}}
+}
+
+// WithReason annotates an error with a machine-readable reason, which
+// distinguishes it from other errors that map to the same gRPC status code.
+// WithReason returns nil if err is nil.
+func WithReason(err error, r Reason) error {
+	if err == nil {
+		return nil
+	}
+	return &withDetail{err, &ErrorReason{Reason: r}}
+}
+
+// Cause returns the annotated error.
+func (w *withDetail) Cause() error {
+	return w.error
+}
+
+// Unwrap returns the annotated error.
+func (w *withDetail) Unwrap() error {
+	return w.error
+}
+
+// Details returns the details with which an error was annotated, outermost
+// first. It does this by walking down the chain of wrapped errors.
+func Details(err error) []proto.Message {
+	d := []proto.Message{}
+	is(err, func(err error) bool {
+		if w, ok := err.(*withDetail); ok {
+			d = append(d, w.detail)
+		}
+		return false
+	})
+	return d
+}
+
+// ReasonOf returns the outermost reason with which an error was annotated, or
+// UNKNOWN if it was annotated with no reason.
+func ReasonOf(err error) Reason {
+	for _, d := range Details(err) {
+		if r, ok := d.(*ErrorReason); ok {
+			return r.GetReason()
+		}
+	}
+	return UNKNOWN
+}
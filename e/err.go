@@ -4,6 +4,9 @@
 package e
 
 import (
+	"errors"
+
+	"github.com/golang/protobuf/ptypes"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -20,6 +23,11 @@ func ErrFull(err error) error {
 // Full signals that this error indicates something was full.
 func (e *errFull) Full() {}
 
+// Unwrap returns the wrapped error.
+func (e *errFull) Unwrap() error {
+	return e.error
+}
+
 type errNotFound struct {
 	error
 }
@@ -32,6 +40,11 @@ func ErrNotFound(err error) error {
 // NotFound signals that this error indicates something was not found.
 func (e *errNotFound) NotFound() {}
 
+// Unwrap returns the wrapped error.
+func (e *errNotFound) Unwrap() error {
+	return e.error
+}
+
 type errInvalid struct {
 	error
 }
@@ -44,91 +57,427 @@ func ErrInvalid(err error) error {
 // Invalid signals that this error indicates an input was invalid.
 func (e *errInvalid) Invalid() {}
 
+// Unwrap returns the wrapped error.
+func (e *errInvalid) Unwrap() error {
+	return e.error
+}
+
+type errFailedPrecondition struct {
+	error
+}
+
+// ErrFailedPrecondition wraps an error such that it will fulfill
+// IsFailedPrecondition.
+func ErrFailedPrecondition(err error) error {
+	return &errFailedPrecondition{err}
+}
+
+// FailedPrecondition signals that this error indicates something was not in
+// the state required by an operation.
+func (e *errFailedPrecondition) FailedPrecondition() {}
+
+// Unwrap returns the wrapped error.
+func (e *errFailedPrecondition) Unwrap() error {
+	return e.error
+}
+
+type errAlreadyExists struct {
+	error
+}
+
+// ErrAlreadyExists wraps an error such that it will fulfill IsAlreadyExists.
+func ErrAlreadyExists(err error) error {
+	return &errAlreadyExists{err}
+}
+
+// AlreadyExists signals that this error indicates something already existed.
+func (e *errAlreadyExists) AlreadyExists() {}
+
+// Unwrap returns the wrapped error.
+func (e *errAlreadyExists) Unwrap() error {
+	return e.error
+}
+
+type errUnavailable struct {
+	error
+}
+
+// ErrUnavailable wraps an error such that it will fulfill IsUnavailable.
+func ErrUnavailable(err error) error {
+	return &errUnavailable{err}
+}
+
+// Unavailable signals that this error indicates something was temporarily
+// unavailable.
+func (e *errUnavailable) Unavailable() {}
+
+// Unwrap returns the wrapped error.
+func (e *errUnavailable) Unwrap() error {
+	return e.error
+}
+
+type errDeadlineExceeded struct {
+	error
+}
+
+// ErrDeadlineExceeded wraps an error such that it will fulfill
+// IsDeadlineExceeded.
+func ErrDeadlineExceeded(err error) error {
+	return &errDeadlineExceeded{err}
+}
+
+// DeadlineExceeded signals that this error indicates an operation did not
+// complete before its deadline.
+func (e *errDeadlineExceeded) DeadlineExceeded() {}
+
+// Unwrap returns the wrapped error.
+func (e *errDeadlineExceeded) Unwrap() error {
+	return e.error
+}
+
+type errCanceled struct {
+	error
+}
+
+// ErrCanceled wraps an error such that it will fulfill IsCanceled.
+func ErrCanceled(err error) error {
+	return &errCanceled{err}
+}
+
+// Canceled signals that this error indicates an operation was abandoned by its
+// caller before it completed.
+func (e *errCanceled) Canceled() {}
+
+// Unwrap returns the wrapped error.
+func (e *errCanceled) Unwrap() error {
+	return e.error
+}
+
+type errPermissionDenied struct {
+	error
+}
+
+// ErrPermissionDenied wraps an error such that it will fulfill
+// IsPermissionDenied.
+func ErrPermissionDenied(err error) error {
+	return &errPermissionDenied{err}
+}
+
+// PermissionDenied signals that this error indicates the caller was not
+// permitted to do something.
+func (e *errPermissionDenied) PermissionDenied() {}
+
+// Unwrap returns the wrapped error.
+func (e *errPermissionDenied) Unwrap() error {
+	return e.error
+}
+
+type errUnauthenticated struct {
+	error
+}
+
+// ErrUnauthenticated wraps an error such that it will fulfill
+// IsUnauthenticated.
+func ErrUnauthenticated(err error) error {
+	return &errUnauthenticated{err}
+}
+
+// Unauthenticated signals that this error indicates the caller could not be
+// identified.
+func (e *errUnauthenticated) Unauthenticated() {}
+
+// Unwrap returns the wrapped error.
+func (e *errUnauthenticated) Unwrap() error {
+	return e.error
+}
+
+// is walks down the chain of errors built by pkg/errors, or by wrapping errors
+// that implement Unwrap, and returns true for the first error for which fn
+// returns true.
+func is(err error, fn func(error) bool) bool {
+	for err != nil {
+		if fn(err) {
+			return true
+		}
+		switch c := err.(type) {
+		case interface {
+			Cause() error
+		}:
+			err = c.Cause()
+		case interface {
+			Unwrap() error
+		}:
+			err = c.Unwrap()
+		default:
+			return false
+		}
+	}
+	return false
+}
+
 // IsNotFound determines whether an error indicates something was not found.
-// It does this by walking down the stack of errors built by pkg/errors and
-// returning true for the first error that implements the following interface:
+// It does this by walking down the chain of wrapped errors and returning true
+// for the first error that implements the following interface:
 //
 // type notfounder interface {
 //   NotFound()
 // }
 func IsNotFound(err error) bool {
-	for {
-		if _, ok := err.(interface {
+	return is(err, func(err error) bool {
+		_, ok := err.(interface {
 			NotFound()
-		}); ok {
-			return true
-		}
-		if c, ok := err.(interface {
-			Cause() error
-		}); ok {
-			err = c.Cause()
-			continue
-		}
-		return false
-	}
+		})
+		return ok
+	})
 }
 
 // IsFull determines whether an error indicates something was full.
-// It does this by walking down the stack of errors built by pkg/errors and
-// returning true for the first error that implements the following interface:
+// It does this by walking down the chain of wrapped errors and returning true
+// for the first error that implements the following interface:
 //
 // type fuller interface {
 //   Full()
 // }
 func IsFull(err error) bool {
-	for {
-		if _, ok := err.(interface {
+	return is(err, func(err error) bool {
+		_, ok := err.(interface {
 			Full()
-		}); ok {
-			return true
-		}
-		if c, ok := err.(interface {
-			Cause() error
-		}); ok {
-			err = c.Cause()
-			continue
-		}
-		return false
-	}
+		})
+		return ok
+	})
 }
 
 // IsInvalid determines whether an error indicates an input was invalid.
-// It does this by walking down the stack of errors built by pkg/errors and
-// returning true for the first error that implements the following interface:
+// It does this by walking down the chain of wrapped errors and returning true
+// for the first error that implements the following interface:
 //
 // type invalider interface {
 //   Invalid()
 // }
 func IsInvalid(err error) bool {
-	for {
-		if _, ok := err.(interface {
+	return is(err, func(err error) bool {
+		_, ok := err.(interface {
 			Invalid()
-		}); ok {
-			return true
-		}
-		if c, ok := err.(interface {
-			Cause() error
-		}); ok {
-			err = c.Cause()
+		})
+		return ok
+	})
+}
+
+// IsFailedPrecondition determines whether an error indicates something was not
+// in the state required by an operation.
+// It does this by walking down the chain of wrapped errors and returning true
+// for the first error that implements the following interface:
+//
+// type failedpreconditioner interface {
+//   FailedPrecondition()
+// }
+func IsFailedPrecondition(err error) bool {
+	return is(err, func(err error) bool {
+		_, ok := err.(interface {
+			FailedPrecondition()
+		})
+		return ok
+	})
+}
+
+// IsAlreadyExists determines whether an error indicates something already
+// existed.
+// It does this by walking down the chain of wrapped errors and returning true
+// for the first error that implements the following interface:
+//
+// type alreadyexister interface {
+//   AlreadyExists()
+// }
+func IsAlreadyExists(err error) bool {
+	return is(err, func(err error) bool {
+		_, ok := err.(interface {
+			AlreadyExists()
+		})
+		return ok
+	})
+}
+
+// IsUnavailable determines whether an error indicates something was
+// temporarily unavailable.
+// It does this by walking down the chain of wrapped errors and returning true
+// for the first error that implements the following interface:
+//
+// type unavailabler interface {
+//   Unavailable()
+// }
+func IsUnavailable(err error) bool {
+	return is(err, func(err error) bool {
+		_, ok := err.(interface {
+			Unavailable()
+		})
+		return ok
+	})
+}
+
+// IsDeadlineExceeded determines whether an error indicates an operation did
+// not complete before its deadline.
+// It does this by walking down the chain of wrapped errors and returning true
+// for the first error that implements the following interface:
+//
+// type deadlineexceeder interface {
+//   DeadlineExceeded()
+// }
+func IsDeadlineExceeded(err error) bool {
+	return is(err, func(err error) bool {
+		_, ok := err.(interface {
+			DeadlineExceeded()
+		})
+		return ok
+	})
+}
+
+// IsCanceled determines whether an error indicates an operation was abandoned
+// by its caller before it completed.
+// It does this by walking down the chain of wrapped errors and returning true
+// for the first error that implements the following interface:
+//
+// type canceler interface {
+//   Canceled()
+// }
+func IsCanceled(err error) bool {
+	return is(err, func(err error) bool {
+		_, ok := err.(interface {
+			Canceled()
+		})
+		return ok
+	})
+}
+
+// IsPermissionDenied determines whether an error indicates the caller was not
+// permitted to do something.
+// It does this by walking down the chain of wrapped errors and returning true
+// for the first error that implements the following interface:
+//
+// type permissiondenier interface {
+//   PermissionDenied()
+// }
+func IsPermissionDenied(err error) bool {
+	return is(err, func(err error) bool {
+		_, ok := err.(interface {
+			PermissionDenied()
+		})
+		return ok
+	})
+}
+
+// IsUnauthenticated determines whether an error indicates the caller could not
+// be identified.
+// It does this by walking down the chain of wrapped errors and returning true
+// for the first error that implements the following interface:
+//
+// type unauthenticateder interface {
+//   Unauthenticated()
+// }
+func IsUnauthenticated(err error) bool {
+	return is(err, func(err error) bool {
+		_, ok := err.(interface {
+			Unauthenticated()
+		})
+		return ok
+	})
+}
+
+// GRPC annotates an error with the appropriate gRPC status code based on the
+// error interfaces it fulfills. Any details with which the error was annotated
+// are attached to the gRPC status.
+func GRPC(err error) error {
+	if err == nil {
+		return nil
+	}
+	s := status.New(Code(err), err.Error()).Proto()
+	for _, d := range Details(err) {
+		a, aerr := ptypes.MarshalAny(d)
+		if aerr != nil {
 			continue
 		}
-		return false
+		s.Details = append(s.Details, a)
 	}
+	return status.ErrorProto(s)
 }
 
-// GRPC annotates an error with the appropriate gRPC status code based on the
-// error interfaces it fulfills.
-func GRPC(err error) error {
+// Code returns the gRPC status code appropriate for an error based on the error
+// interfaces it fulfills.
+func Code(err error) codes.Code {
 	switch {
 	case err == nil:
-		return nil
+		return codes.OK
 	case IsNotFound(err):
-		return status.Error(codes.NotFound, err.Error())
+		return codes.NotFound
 	case IsFull(err):
-		return status.Error(codes.ResourceExhausted, err.Error())
+		return codes.ResourceExhausted
 	case IsInvalid(err):
-		return status.Error(codes.InvalidArgument, err.Error())
+		return codes.InvalidArgument
+	case IsFailedPrecondition(err):
+		return codes.FailedPrecondition
+	case IsAlreadyExists(err):
+		return codes.AlreadyExists
+	case IsUnavailable(err):
+		return codes.Unavailable
+	case IsDeadlineExceeded(err):
+		return codes.DeadlineExceeded
+	case IsCanceled(err):
+		return codes.Canceled
+	case IsPermissionDenied(err):
+		return codes.PermissionDenied
+	case IsUnauthenticated(err):
+		return codes.Unauthenticated
+	default:
+		return codes.Unknown
+	}
+}
+
+// FromGRPC rebuilds an error returned by a gRPC client such that it fulfills
+// the error interfaces implied by its gRPC status code, and carries any details
+// attached to its gRPC status. This allows clients to handle errors using the
+// same predicates as the server, for example IsNotFound and ReasonOf. Errors
+// without a gRPC status are returned unchanged.
+func FromGRPC(err error) error {
+	s, ok := status.FromError(err)
+	if !ok || s.Code() == codes.OK {
+		return err
+	}
+	rebuilt := kind(s.Code(), errors.New(s.Message()))
+	// Details are listed outermost first, so we wrap the innermost first.
+	details := s.Proto().GetDetails()
+	for i := len(details) - 1; i >= 0; i-- {
+		var d ptypes.DynamicAny
+		if err := ptypes.UnmarshalAny(details[i], &d); err != nil {
+			continue
+		}
+		rebuilt = &withDetail{rebuilt, d.Message}
+	}
+	return rebuilt
+}
+
+// kind wraps an error in the kind of error implied by a gRPC status code.
+func kind(c codes.Code, err error) error {
+	switch c {
+	case codes.NotFound:
+		return ErrNotFound(err)
+	case codes.ResourceExhausted:
+		return ErrFull(err)
+	case codes.InvalidArgument:
+		return ErrInvalid(err)
+	case codes.FailedPrecondition:
+		return ErrFailedPrecondition(err)
+	case codes.AlreadyExists:
+		return ErrAlreadyExists(err)
+	case codes.Unavailable:
+		return ErrUnavailable(err)
+	case codes.DeadlineExceeded:
+		return ErrDeadlineExceeded(err)
+	case codes.Canceled:
+		return ErrCanceled(err)
+	case codes.PermissionDenied:
+		return ErrPermissionDenied(err)
+	case codes.Unauthenticated:
+		return ErrUnauthenticated(err)
 	default:
-		return status.Error(codes.Unknown, err.Error())
+		return err
 	}
 }
@@ -1,10 +1,16 @@
 package e
 
 import (
+	stderrors "errors"
 	"fmt"
 	"testing"
 
+	pb "github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var errTests = []struct {
@@ -77,6 +83,91 @@ var errTests = []struct {
 		tester: IsNotFound,
 		want:   false,
 	},
+	{
+		err:    ErrFailedPrecondition(errors.New("kaboom!")),
+		tester: IsFailedPrecondition,
+		want:   true,
+	},
+	{
+		err:    errors.Wrap(ErrFailedPrecondition(errors.New("kaboom!")), "not empty!"),
+		tester: IsFailedPrecondition,
+		want:   true,
+	},
+	{
+		err:    ErrInvalid(errors.New("kaboom!")),
+		tester: IsFailedPrecondition,
+		want:   false,
+	},
+	{
+		err:    errors.Wrap(ErrAlreadyExists(errors.New("kaboom!")), "wrapped!"),
+		tester: IsAlreadyExists,
+		want:   true,
+	},
+	{
+		err:    ErrInvalid(errors.New("kaboom!")),
+		tester: IsAlreadyExists,
+		want:   false,
+	},
+	{
+		err:    errors.Wrap(ErrUnavailable(errors.New("kaboom!")), "wrapped!"),
+		tester: IsUnavailable,
+		want:   true,
+	},
+	{
+		err:    ErrInvalid(errors.New("kaboom!")),
+		tester: IsUnavailable,
+		want:   false,
+	},
+	{
+		err:    errors.Wrap(ErrDeadlineExceeded(errors.New("kaboom!")), "wrapped!"),
+		tester: IsDeadlineExceeded,
+		want:   true,
+	},
+	{
+		err:    ErrInvalid(errors.New("kaboom!")),
+		tester: IsDeadlineExceeded,
+		want:   false,
+	},
+	{
+		err:    errors.Wrap(ErrCanceled(errors.New("kaboom!")), "wrapped!"),
+		tester: IsCanceled,
+		want:   true,
+	},
+	{
+		err:    ErrDeadlineExceeded(errors.New("kaboom!")),
+		tester: IsCanceled,
+		want:   false,
+	},
+	{
+		err:    errors.Wrap(ErrPermissionDenied(errors.New("kaboom!")), "wrapped!"),
+		tester: IsPermissionDenied,
+		want:   true,
+	},
+	{
+		err:    ErrInvalid(errors.New("kaboom!")),
+		tester: IsPermissionDenied,
+		want:   false,
+	},
+	{
+		err:    errors.Wrap(ErrUnauthenticated(errors.New("kaboom!")), "wrapped!"),
+		tester: IsUnauthenticated,
+		want:   true,
+	},
+	{
+		err:    ErrInvalid(errors.New("kaboom!")),
+		tester: IsUnauthenticated,
+		want:   false,
+	},
+	{
+		err:    fmt.Errorf("wrapped: %w", ErrNotFound(errors.New("kaboom!"))),
+		tester: IsNotFound,
+		want:   true,
+	},
+	{
+		err:    errors.Wrap(WithResource(ErrNotFound(errors.New("kaboom!")), "queue", "apollo"), "wrapped!"),
+		tester: IsNotFound,
+		want:   true,
+	},
 }
 
 func TestErr(t *testing.T) {
@@ -87,3 +178,133 @@ func TestErr(t *testing.T) {
 		}
 	}
 }
+
+func TestUnwrap(t *testing.T) {
+	cause := stderrors.New("kaboom!")
+	err := errors.Wrap(WithQuota(ErrFull(cause), "queue", "limit of 1 messages"), "full!")
+	if !stderrors.Is(err, cause) {
+		t.Errorf("errors.Is(%v, %v): want true", err, cause)
+	}
+	var full interface {
+		Full()
+	}
+	if !stderrors.As(err, &full) {
+		t.Errorf("errors.As(%v): want an error implementing Full()", err)
+	}
+}
+
+var grpcTests = []struct {
+	err     error
+	code    codes.Code
+	details []pb.Message
+}{
+	{
+		err:  nil,
+		code: codes.OK,
+	},
+	{
+		err:  errors.New("kaboom!"),
+		code: codes.Unknown,
+	},
+	{
+		err:  errors.Wrap(ErrPermissionDenied(errors.New("kaboom!")), "denied!"),
+		code: codes.PermissionDenied,
+	},
+	{
+		err:     errors.Wrap(WithResource(ErrNotFound(errors.New("kaboom!")), "queue", "apollo"), "not found!"),
+		code:    codes.NotFound,
+		details: []pb.Message{&errdetails.ResourceInfo{ResourceType: "queue", ResourceName: "apollo"}},
+	},
+	{
+		err:  WithQuota(ErrFull(errors.New("kaboom!")), "queue apollo", "limit of 1 messages"),
+		code: codes.ResourceExhausted,
+		details: []pb.Message{&errdetails.QuotaFailure{Violations: []*errdetails.QuotaFailure_Violation{
+			{Subject: "queue apollo", Description: "limit of 1 messages"},
+		}}},
+	},
+}
+
+func TestGRPC(t *testing.T) {
+	for _, tt := range grpcTests {
+		err := GRPC(tt.err)
+		s, _ := status.FromError(err)
+		if s.Code() != tt.code {
+			t.Errorf("GRPC(%v): want code %v, got %v", tt.err, tt.code, s.Code())
+		}
+		details := []pb.Message{}
+		for _, a := range s.Proto().GetDetails() {
+			var d ptypes.DynamicAny
+			if err := ptypes.UnmarshalAny(a, &d); err != nil {
+				t.Errorf("ptypes.UnmarshalAny(%v): %v", a, err)
+				continue
+			}
+			details = append(details, d.Message)
+		}
+		if len(details) != len(tt.details) {
+			t.Errorf("GRPC(%v): want %d details, got %d", tt.err, len(tt.details), len(details))
+			continue
+		}
+		for i := range details {
+			if !pb.Equal(details[i], tt.details[i]) {
+				t.Errorf("GRPC(%v): want detail %v, got %v", tt.err, tt.details[i], details[i])
+			}
+		}
+	}
+}
+
+var fromGRPCTests = []struct {
+	err    error
+	tester func(error) bool
+	reason Reason
+}{
+	{
+		err:    WithReason(ErrNotFound(errors.New("kaboom!")), QUEUE_EMPTY),
+		tester: IsNotFound,
+		reason: QUEUE_EMPTY,
+	},
+	{
+		err:    errors.Wrap(WithReason(WithResource(ErrNotFound(errors.New("kaboom!")), "queue", "apollo"), QUEUE_NOT_FOUND), "missing!"),
+		tester: IsNotFound,
+		reason: QUEUE_NOT_FOUND,
+	},
+	{
+		err:    WithReason(WithQuota(ErrFull(errors.New("kaboom!")), "queue apollo", "limit of 1 messages"), QUEUE_FULL),
+		tester: IsFull,
+		reason: QUEUE_FULL,
+	},
+	{
+		err:    ErrUnauthenticated(errors.New("kaboom!")),
+		tester: IsUnauthenticated,
+		reason: UNKNOWN,
+	},
+}
+
+func TestFromGRPC(t *testing.T) {
+	for _, tt := range fromGRPCTests {
+		err := FromGRPC(GRPC(tt.err))
+		if !tt.tester(err) {
+			t.Errorf("FromGRPC(GRPC(%v)): error does not satisfy the expected predicate", tt.err)
+		}
+		if err.Error() != tt.err.Error() {
+			t.Errorf("FromGRPC(GRPC(%v)): want message %q, got %q", tt.err, tt.err.Error(), err.Error())
+		}
+		if got := ReasonOf(err); got != tt.reason {
+			t.Errorf("ReasonOf(FromGRPC(GRPC(%v))): want %v, got %v", tt.err, tt.reason, got)
+		}
+		want, got := Details(tt.err), Details(err)
+		if len(got) != len(want) {
+			t.Errorf("Details(FromGRPC(GRPC(%v))): want %d details, got %d", tt.err, len(want), len(got))
+			continue
+		}
+		for i := range got {
+			if !pb.Equal(got[i], want[i]) {
+				t.Errorf("Details(FromGRPC(GRPC(%v))): want detail %v, got %v", tt.err, want[i], got[i])
+			}
+		}
+	}
+
+	plain := errors.New("kaboom!")
+	if err := FromGRPC(plain); err != plain {
+		t.Errorf("FromGRPC(%v): want unchanged error, got %v", plain, err)
+	}
+}
@@ -0,0 +1,3 @@
+//go:generate ./generate.sh
+
+package e
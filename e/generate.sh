@@ -0,0 +1,10 @@
+#!/usr/bin/env sh
+
+# Allow importing proto files using the same pattern as Go imports.
+INCLUDE=".:${GOPATH}/src"
+
+protoc -I ${INCLUDE} --gogoslick_out=. reason.proto
+
+# The generator emits a package doc comment that conflicts with the one in
+# err.go, so remove it.
+sed -i.bak '/^\/\*$/,/^\*\/$/d' reason.pb.go && rm reason.pb.go.bak
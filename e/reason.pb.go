@@ -0,0 +1,169 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: reason.proto
+
+package e
+
+import proto "github.com/gogo/protobuf/proto"
+import golang_proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import _ "github.com/gogo/protobuf/gogoproto"
+
+import strconv "strconv"
+
+import strings "strings"
+import reflect "reflect"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = golang_proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+// A Reason is a machine-readable explanation of an error. Reasons distinguish
+// errors that map to the same gRPC status code, for example a queue that does
+// not exist and a queue that holds no messages.
+type Reason int32
+
+const (
+	UNKNOWN                Reason = 0
+	QUEUE_NOT_FOUND        Reason = 1
+	QUEUE_EMPTY            Reason = 2
+	QUEUE_FULL             Reason = 3
+	QUEUE_NOT_EMPTY        Reason = 4
+	MESSAGE_NOT_FOUND      Reason = 5
+	MESSAGE_TOO_LARGE      Reason = 6
+	TOPIC_NOT_FOUND        Reason = 7
+	SUBSCRIPTION_NOT_FOUND Reason = 8
+	GROUP_NOT_FOUND        Reason = 9
+	BUDGET_EXHAUSTED       Reason = 10
+)
+
+var Reason_name = map[int32]string{
+	0:  "UNKNOWN",
+	1:  "QUEUE_NOT_FOUND",
+	2:  "QUEUE_EMPTY",
+	3:  "QUEUE_FULL",
+	4:  "QUEUE_NOT_EMPTY",
+	5:  "MESSAGE_NOT_FOUND",
+	6:  "MESSAGE_TOO_LARGE",
+	7:  "TOPIC_NOT_FOUND",
+	8:  "SUBSCRIPTION_NOT_FOUND",
+	9:  "GROUP_NOT_FOUND",
+	10: "BUDGET_EXHAUSTED",
+}
+var Reason_value = map[string]int32{
+	"UNKNOWN":                0,
+	"QUEUE_NOT_FOUND":        1,
+	"QUEUE_EMPTY":            2,
+	"QUEUE_FULL":             3,
+	"QUEUE_NOT_EMPTY":        4,
+	"MESSAGE_NOT_FOUND":      5,
+	"MESSAGE_TOO_LARGE":      6,
+	"TOPIC_NOT_FOUND":        7,
+	"SUBSCRIPTION_NOT_FOUND": 8,
+	"GROUP_NOT_FOUND":        9,
+	"BUDGET_EXHAUSTED":       10,
+}
+
+func (Reason) EnumDescriptor() ([]byte, []int) { return fileDescriptorReason, []int{0} }
+
+// An ErrorReason is attached as a detail to the gRPC status of an error.
+type ErrorReason struct {
+	Reason Reason `protobuf:"varint,1,opt,name=reason,proto3,enum=negz.q.e.Reason" json:"reason,omitempty"`
+}
+
+func (m *ErrorReason) Reset()                    { *m = ErrorReason{} }
+func (*ErrorReason) ProtoMessage()               {}
+func (*ErrorReason) Descriptor() ([]byte, []int) { return fileDescriptorReason, []int{0} }
+
+func (m *ErrorReason) GetReason() Reason {
+	if m != nil {
+		return m.Reason
+	}
+	return UNKNOWN
+}
+
+func init() {
+	proto.RegisterType((*ErrorReason)(nil), "negz.q.e.ErrorReason")
+	golang_proto.RegisterType((*ErrorReason)(nil), "negz.q.e.ErrorReason")
+	proto.RegisterEnum("negz.q.e.Reason", Reason_name, Reason_value)
+	golang_proto.RegisterEnum("negz.q.e.Reason", Reason_name, Reason_value)
+}
+func (x Reason) String() string {
+	s, ok := Reason_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (this *ErrorReason) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&e.ErrorReason{")
+	s = append(s, "Reason: "+fmt.Sprintf("%#v", this.Reason)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringReason(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+func (this *ErrorReason) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ErrorReason{`,
+		`Reason:` + fmt.Sprintf("%v", this.Reason) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringReason(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+
+func init() { proto.RegisterFile("reason.proto", fileDescriptorReason) }
+func init() { golang_proto.RegisterFile("reason.proto", fileDescriptorReason) }
+
+var fileDescriptorReason = []byte{
+	// 329 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x90, 0xbb, 0x4e, 0xf3, 0x30,
+	0x18, 0x86, 0xed, 0xff, 0x87, 0xb4, 0x7c, 0x45, 0xad, 0x31, 0x07, 0xa1, 0x0e, 0x16, 0x62, 0xaa,
+	0x90, 0x48, 0x25, 0x3a, 0x30, 0xf7, 0xe0, 0x86, 0x8a, 0x36, 0x0e, 0x49, 0x2c, 0x0e, 0x4b, 0x44,
+	0x51, 0x08, 0x0c, 0xd4, 0x10, 0xda, 0x85, 0x89, 0x4b, 0xe0, 0x32, 0xb8, 0x0c, 0x46, 0xc6, 0x8e,
+	0x8c, 0xd4, 0x5d, 0x10, 0x53, 0x2f, 0x01, 0x35, 0x29, 0x52, 0xd8, 0xfc, 0xbd, 0xef, 0xfb, 0x3c,
+	0x83, 0x61, 0x35, 0x0e, 0x2f, 0x1f, 0xd5, 0xc0, 0xbc, 0x8f, 0xd5, 0x50, 0xd1, 0xfc, 0x20, 0x8c,
+	0x9e, 0xcc, 0x07, 0x33, 0x2c, 0xef, 0x47, 0xb7, 0xc3, 0x9b, 0x51, 0xdf, 0xbc, 0x52, 0x77, 0xd5,
+	0x48, 0x45, 0xaa, 0x9a, 0x0c, 0xfa, 0xa3, 0xeb, 0xe4, 0x4a, 0x8e, 0xe4, 0x95, 0x82, 0xbb, 0x87,
+	0x50, 0xe0, 0x71, 0xac, 0x62, 0x37, 0xb1, 0xd1, 0x0a, 0x18, 0xa9, 0x77, 0x1b, 0xef, 0xe0, 0x4a,
+	0xf1, 0x80, 0x98, 0xbf, 0x62, 0x33, 0x5d, 0xb8, 0x8b, 0x7e, 0xef, 0x1b, 0x83, 0xb1, 0x80, 0x0a,
+	0x90, 0x93, 0xf6, 0xb1, 0x2d, 0x4e, 0x6d, 0x82, 0xe8, 0x3a, 0x94, 0x4e, 0x24, 0x97, 0x3c, 0xb0,
+	0x85, 0x1f, 0xb4, 0x85, 0xb4, 0x5b, 0x04, 0xd3, 0x12, 0x14, 0xd2, 0x90, 0xf7, 0x1c, 0xff, 0x9c,
+	0xfc, 0xa3, 0x45, 0x80, 0x34, 0x68, 0xcb, 0x6e, 0x97, 0xfc, 0xff, 0x4b, 0xa5, 0xa3, 0x25, 0xba,
+	0x09, 0x6b, 0x3d, 0xee, 0x79, 0x75, 0x2b, 0x2b, 0x5b, 0xce, 0xc6, 0xbe, 0x10, 0x41, 0xb7, 0xee,
+	0x5a, 0x9c, 0x18, 0x73, 0x85, 0x2f, 0x9c, 0x4e, 0x33, 0xb3, 0xcd, 0xd1, 0x32, 0x6c, 0x79, 0xb2,
+	0xe1, 0x35, 0xdd, 0x8e, 0xe3, 0x77, 0x84, 0x9d, 0xe9, 0xf2, 0x73, 0xc0, 0x72, 0x85, 0x74, 0x32,
+	0xe1, 0x0a, 0xdd, 0x00, 0xd2, 0x90, 0x2d, 0x8b, 0xfb, 0x01, 0x3f, 0x3b, 0xaa, 0x4b, 0xcf, 0xe7,
+	0x2d, 0x02, 0x8d, 0xda, 0x78, 0xc2, 0xd0, 0xc7, 0x84, 0xa1, 0xd9, 0x84, 0xe1, 0x67, 0xcd, 0xf0,
+	0xab, 0x66, 0xe8, 0x5d, 0x33, 0x34, 0xd6, 0x0c, 0x7d, 0x6a, 0x86, 0xbe, 0x34, 0x43, 0x33, 0xcd,
+	0xf0, 0xcb, 0x94, 0xa1, 0xb7, 0x29, 0xc3, 0x17, 0x38, 0xec, 0x1b, 0xc9, 0x0f, 0xd7, 0x7e, 0x06,
+	0x00, 0x24, 0x9c, 0x4a, 0xf5, 0xaa, 0x01, 0x00, 0x00,
+}
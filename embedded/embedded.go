@@ -0,0 +1,108 @@
+// Package embedded runs the q queue service in-process, without a network.
+package embedded
+
+import (
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/negz/q"
+	"github.com/negz/q/manager"
+	"github.com/negz/q/metrics"
+	"github.com/negz/q/proto"
+	"github.com/negz/q/rpc"
+)
+
+// DefaultBufferSize is the default size in bytes of the in-memory buffer that
+// carries each direction of the connection between client and server.
+const DefaultBufferSize = 1 << 20
+
+type embedded struct {
+	buffer int
+	mx     q.Metrics
+	log    *zap.Logger
+	o      []rpc.Option
+}
+
+// An Option represents an optional argument to an embedded server.
+type Option func(*embedded)
+
+// WithBufferSize specifies the size in bytes of the in-memory buffer that
+// carries each direction of the connection between client and server. Defaults
+// to DefaultBufferSize.
+func WithBufferSize(n int) Option {
+	return func(e *embedded) {
+		e.buffer = n
+	}
+}
+
+// WithMetrics instruments the embedded server's queue manager with Metrics.
+func WithMetrics(mx q.Metrics) Option {
+	return func(e *embedded) {
+		e.mx = mx
+	}
+}
+
+// WithLogger instruments the embedded server's queue manager with a zap
+// Logger.
+func WithLogger(l *zap.Logger) Option {
+	return func(e *embedded) {
+		e.log = l
+	}
+}
+
+// WithServerOptions specifies options used to create the embedded server.
+func WithServerOptions(o ...rpc.Option) Option {
+	return func(e *embedded) {
+		e.o = append(e.o, o...)
+	}
+}
+
+// Start serves the q queue service in-process using an instrumented, expiring
+// in-memory queue manager and the default queue factory. It returns a client
+// connected to the service and a function that closes the client and stops the
+// service.
+func Start(o ...Option) (proto.QClient, func() error, error) {
+	e := &embedded{buffer: DefaultBufferSize, mx: metrics.NewNop(), log: zap.NewNop()}
+	for _, opt := range o {
+		opt(e)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m := manager.Expiring(ctx,
+		manager.Instrumented(manager.New(), manager.WithMetrics(e.mx), manager.WithLogger(e.log)),
+		manager.WithExpiryMetrics(e.mx),
+		manager.WithExpiryLogger(e.log))
+	l := bufconn.Listen(e.buffer)
+	s := rpc.NewServer(l, m, e.o...)
+	served := make(chan error, 1)
+	go func() {
+		served <- s.Serve()
+	}()
+
+	conn, err := grpc.Dial("bufconn",
+		grpc.WithInsecure(),
+		grpc.WithBlock(),
+		grpc.WithDialer(func(string, time.Duration) (net.Conn, error) { return l.Dial() }))
+	if err != nil {
+		cancel()
+		s.Stop()
+		return nil, nil, errors.Wrap(err, "cannot connect to embedded server")
+	}
+
+	shutdown := func() error {
+		defer cancel()
+		cerr := conn.Close()
+		s.Stop()
+		if err := <-served; err != nil {
+			return err
+		}
+		return errors.Wrap(cerr, "cannot close connection to embedded server")
+	}
+	return proto.NewQClient(conn), shutdown, nil
+}
@@ -10,15 +10,32 @@ import (
 )
 
 // Default is the default queue factory. It can currently only produce in-memory
-// FIFO queues.
+// FIFO queues and logs.
 var Default = &defaultFactory{}
 
 type defaultFactory struct{}
 
-func (f *defaultFactory) New(s q.Store, limit int, t ...q.Tag) (q.Queue, error) {
+func (f *defaultFactory) New(s q.Store, c q.Config, t ...q.Tag) (q.Queue, error) {
+	if err := c.Validate(); err != nil {
+		return nil, e.ErrInvalid(errors.Wrap(err, "invalid queue config"))
+	}
+	o := []memory.Option{
+		memory.Limit(c.Limit),
+		memory.MaxBytes(c.MaxBytes),
+		memory.MaxMessageBytes(c.MaxMessageBytes),
+		memory.Overflow(c.Overflow),
+		memory.Retention(c.Retention),
+		memory.ExpireAfterIdle(c.ExpireAfterIdle),
+		memory.Tagged(t...),
+	}
 	switch s {
 	case q.Memory:
-		return memory.New(memory.Limit(limit), memory.Tagged(t...)), nil
+		if c.Retention != 0 {
+			return nil, e.ErrInvalid(errors.New("in-memory queues cannot retain consumed messages"))
+		}
+		return memory.New(o...), nil
+	case q.MemoryLog:
+		return memory.NewLog(o...), nil
 	default:
 		return nil, e.ErrNotFound(errors.New("unknown store type"))
 	}
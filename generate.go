@@ -1,3 +1,3 @@
-//go:generate stringer -type=Store,Error -output=queue_strings.go
+//go:generate stringer -type=Store,Error,Overflow -output=queue_strings.go
 
 package q
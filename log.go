@@ -0,0 +1,59 @@
+package q
+
+import "time"
+
+// A Group is a named consumer of a log. Each group reads every message in the
+// log independently of any other group.
+type Group struct {
+	Name   string // Name uniquely identifies the group within its log.
+	Offset uint64 // Offset is the committed offset of the next message the group will read.
+	Lag    uint64 // Lag is the number of offsets between the group's committed offset and the end of the log.
+}
+
+// A Log is a queue whose messages are shared by consumer groups rather than
+// popped by a single consumer. Each group commits its own offset. A log with no
+// retention retains each message until every group has committed an offset
+// beyond it. A log with a retention retains each message until it is older than
+// the retention, whether or not it has been consumed, so that groups may seek
+// back to replay it. Messages the log discards are passed to the functions
+// registered via OnEvict.
+type Log interface {
+	Queue
+
+	// AddGroup adds a new consumer group that will read from the oldest
+	// message retained by the log.
+	AddGroup(name string) (Group, error)
+
+	// GetGroup returns the consumer group with the supplied name.
+	GetGroup(name string) (Group, error)
+
+	// DeleteGroup deletes the consumer group with the supplied name.
+	DeleteGroup(name string) error
+
+	// Groups returns the log's consumer groups, ordered by name.
+	Groups() []Group
+
+	// Read returns up to max messages, which may be Unbounded, starting at the
+	// supplied group's committed offset. Reading does not commit the group's
+	// offset; Read also returns the offset the group should commit once it has
+	// processed the returned messages.
+	Read(group string, max int) ([]*Message, uint64, error)
+
+	// Commit sets the supplied group's committed offset. Offsets may not move
+	// backward, or beyond the end of the log.
+	Commit(group string, offset uint64) (Group, error)
+
+	// Seek sets the supplied group's committed offset, which unlike Commit
+	// may move backward. Offsets before the oldest retained message seek to
+	// that message.
+	Seek(group string, offset uint64) (Group, error)
+
+	// SeekTime sets the supplied group's committed offset to that of the
+	// oldest retained message created at or after the supplied time, or to
+	// the end of the log if there is no such message.
+	SeekTime(group string, t time.Time) (Group, error)
+
+	// OnLag registers a function to be called with a group's lag whenever it
+	// may have changed. fn must not call the log's methods.
+	OnLag(fn func(group string, lag uint64))
+}
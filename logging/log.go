@@ -0,0 +1,99 @@
+package logging
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/negz/q"
+)
+
+// A logQueue is a logging queue that wraps a log.
+type logQueue struct {
+	*queue
+	wl q.Log
+}
+
+func (l *logQueue) AddGroup(name string) (q.Group, error) {
+	log := l.log.With(zap.String("group", name))
+	g, err := l.wl.AddGroup(name)
+	if err != nil {
+		log.Error("add group", zap.Error(err))
+		return g, err
+	}
+	log.Debug("add group", zap.Uint64("offset", g.Offset))
+	return g, nil
+}
+
+func (l *logQueue) GetGroup(name string) (q.Group, error) {
+	log := l.log.With(zap.String("group", name))
+	g, err := l.wl.GetGroup(name)
+	if err != nil {
+		log.Error("get group", zap.Error(err))
+		return g, err
+	}
+	log.Debug("get group")
+	return g, nil
+}
+
+func (l *logQueue) DeleteGroup(name string) error {
+	log := l.log.With(zap.String("group", name))
+	if err := l.wl.DeleteGroup(name); err != nil {
+		log.Error("delete group", zap.Error(err))
+		return err
+	}
+	log.Debug("delete group")
+	return nil
+}
+
+func (l *logQueue) Groups() []q.Group {
+	return l.wl.Groups()
+}
+
+func (l *logQueue) Read(group string, max int) ([]*q.Message, uint64, error) {
+	log := l.log.With(zap.String("group", group), zap.Int("max", max))
+	msgs, next, err := l.wl.Read(group, max)
+	if err != nil {
+		log.Error("read", zap.Error(err))
+		return nil, 0, err
+	}
+	log.Debug("read", zap.Int("read", len(msgs)), zap.Uint64("next", next))
+	return msgs, next, nil
+}
+
+func (l *logQueue) Commit(group string, offset uint64) (q.Group, error) {
+	log := l.log.With(zap.String("group", group), zap.Uint64("offset", offset))
+	g, err := l.wl.Commit(group, offset)
+	if err != nil {
+		log.Error("commit", zap.Error(err))
+		return g, err
+	}
+	log.Debug("commit")
+	return g, nil
+}
+
+func (l *logQueue) Seek(group string, offset uint64) (q.Group, error) {
+	log := l.log.With(zap.String("group", group), zap.Uint64("offset", offset))
+	g, err := l.wl.Seek(group, offset)
+	if err != nil {
+		log.Error("seek group", zap.Error(err))
+		return g, err
+	}
+	log.Debug("seek group", zap.Uint64("committed", g.Offset))
+	return g, nil
+}
+
+func (l *logQueue) SeekTime(group string, t time.Time) (q.Group, error) {
+	log := l.log.With(zap.String("group", group), zap.Time("time", t))
+	g, err := l.wl.SeekTime(group, t)
+	if err != nil {
+		log.Error("seek group", zap.Error(err))
+		return g, err
+	}
+	log.Debug("seek group", zap.Uint64("committed", g.Offset))
+	return g, nil
+}
+
+func (l *logQueue) OnLag(fn func(group string, lag uint64)) {
+	l.wl.OnLag(fn)
+}
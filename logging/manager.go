@@ -47,12 +47,12 @@ func (l *manager) Get(id uuid.UUID) (q.Queue, error) {
 	return queue, nil
 }
 
-func (l *manager) Delete(id uuid.UUID) error {
-	if err := l.w.Delete(id); err != nil {
-		l.log.Error("delete queue", idField(id), zap.Error(err))
+func (l *manager) Delete(id uuid.UUID, force bool) error {
+	if err := l.w.Delete(id, force); err != nil {
+		l.log.Error("delete queue", idField(id), zap.Bool("force", force), zap.Error(err))
 		return err
 	}
-	l.log.Debug("delete queue", idField(id))
+	l.log.Debug("delete queue", idField(id), zap.Bool("force", force))
 	return nil
 }
 
@@ -66,6 +66,54 @@ func (l *manager) List() ([]q.Queue, error) {
 	return q, nil
 }
 
+func (l *manager) AddTopic(t q.Topic) error {
+	if err := l.w.AddTopic(t); err != nil {
+		l.log.Error("add topic", idField(t.ID()), zap.Error(err))
+		return err
+	}
+	log := l.log
+	for _, tag := range t.Tags().Get() {
+		log = log.With(zap.String("tag", fmt.Sprint(tag)))
+	}
+	log.Debug("add topic",
+		idField(t.ID()),
+		zap.Time("created", t.Created()))
+	return nil
+}
+
+func (l *manager) GetTopic(id uuid.UUID) (q.Topic, error) {
+	t, err := l.w.GetTopic(id)
+	if err != nil {
+		l.log.Error("get topic", idField(id), zap.Error(err))
+		return nil, err
+	}
+	l.log.Debug("get topic", idField(id))
+	return t, nil
+}
+
+func (l *manager) DeleteTopic(id uuid.UUID) error {
+	if err := l.w.DeleteTopic(id); err != nil {
+		l.log.Error("delete topic", idField(id), zap.Error(err))
+		return err
+	}
+	l.log.Debug("delete topic", idField(id))
+	return nil
+}
+
+func (l *manager) ListTopics() ([]q.Topic, error) {
+	t, err := l.w.ListTopics()
+	if err != nil {
+		l.log.Error("list topics", zap.Error(err))
+		return nil, err
+	}
+	l.log.Debug("list topics")
+	return t, nil
+}
+
 func idField(id uuid.UUID) zapcore.Field {
 	return zap.String("id", fmt.Sprint(id))
 }
+
+func tagsField(t []q.Tag) zapcore.Field {
+	return zap.String("tags", fmt.Sprint(t))
+}
@@ -68,7 +68,7 @@ func TestManager(t *testing.T) {
 	t.Run("Delete", func(t *testing.T) {
 		queue := fixtures.NewPredictableQueue(nil, nil)
 		m := Manager(fixtures.NewPredictableManager(queue, nil), zap.NewNop())
-		if err := m.Delete(queue.ID()); err != nil {
+		if err := m.Delete(queue.ID(), false); err != nil {
 			t.Errorf("m.Delete(%v): %v", queue.ID(), err)
 		}
 	})
@@ -77,7 +77,7 @@ func TestManager(t *testing.T) {
 		want := errors.New("boom!")
 		m := Manager(fixtures.NewPredictableManager(nil, want), zap.NewNop())
 		id := uuid.New()
-		if err := m.Delete(id); err != want {
+		if err := m.Delete(id, false); err != want {
 			t.Errorf("m.Delete(%v): %v", id, err)
 		}
 	})
@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"golang.org/x/net/context"
 
 	"github.com/negz/q"
 )
@@ -14,11 +15,20 @@ type queue struct {
 	log *zap.Logger
 }
 
-// Queue wraps a queue with the supplied logger.
+// Queue wraps a queue with the supplied logger. The returned queue is a q.Log or
+// a q.Seeker if the wrapped queue is.
 func Queue(wrap q.Queue, l *zap.Logger) q.Queue {
 	log := l.With(idField(wrap.ID()))
 	log.Debug("queue logging enabled")
-	return &queue{w: wrap, log: log}
+	wrap.OnEvict(func(m *q.Message) { log.Debug("evict", idField(m.ID)) })
+	lq := &queue{w: wrap, log: log}
+	if wl, ok := wrap.(q.Log); ok {
+		return &logQueue{queue: lq, wl: wl}
+	}
+	if ws, ok := wrap.(q.Seeker); ok {
+		return &seekerQueue{queue: lq, ws: ws}
+	}
+	return lq
 }
 
 func (l *queue) ID() uuid.UUID {
@@ -37,9 +47,35 @@ func (l *queue) Tags() *q.Tags {
 	return l.w.Tags()
 }
 
+func (l *queue) Config() q.Config {
+	return l.w.Config()
+}
+
+func (l *queue) Configure(c q.Config) error {
+	log := l.log.With(
+		zap.Int("limit", c.Limit),
+		zap.Int("max_bytes", c.MaxBytes),
+		zap.Int("max_message_bytes", c.MaxMessageBytes),
+		zap.Stringer("overflow", c.Overflow))
+	if err := l.w.Configure(c); err != nil {
+		log.Error("configure", zap.Error(err))
+		return err
+	}
+	log.Debug("configure")
+	return nil
+}
+
+func (l *queue) OnEvict(fn func(*q.Message)) {
+	l.w.OnEvict(fn)
+}
+
 func (l *queue) Add(m *q.Message) error {
+	return l.AddContext(context.Background(), m)
+}
+
+func (l *queue) AddContext(ctx context.Context, m *q.Message) error {
 	log := l.log.With(idField(m.ID))
-	if err := l.w.Add(m); err != nil {
+	if err := l.w.AddContext(ctx, m); err != nil {
 		log.Error("add", zap.Error(err))
 		return err
 	}
@@ -66,3 +102,90 @@ func (l *queue) Peek() (*q.Message, error) {
 	l.log.Debug("peek", idField(m.ID))
 	return m, nil
 }
+
+func (l *queue) PopMatching(t ...q.Tag) (*q.Message, error) {
+	log := l.log.With(tagsField(t))
+	m, err := l.w.PopMatching(t...)
+	if err != nil {
+		log.Error("pop matching", zap.Error(err))
+		return nil, err
+	}
+	log.Debug("pop matching", idField(m.ID))
+	return m, nil
+}
+
+func (l *queue) PeekMatching(t ...q.Tag) (*q.Message, error) {
+	log := l.log.With(tagsField(t))
+	m, err := l.w.PeekMatching(t...)
+	if err != nil {
+		log.Error("peek matching", zap.Error(err))
+		return nil, err
+	}
+	log.Debug("peek matching", idField(m.ID))
+	return m, nil
+}
+
+func (l *queue) Get(id uuid.UUID) (*q.Message, error) {
+	log := l.log.With(idField(id))
+	m, err := l.w.Get(id)
+	if err != nil {
+		log.Error("get", zap.Error(err))
+		return nil, err
+	}
+	log.Debug("get")
+	return m, nil
+}
+
+func (l *queue) Delete(id uuid.UUID) error {
+	log := l.log.With(idField(id))
+	if err := l.w.Delete(id); err != nil {
+		log.Error("delete", zap.Error(err))
+		return err
+	}
+	log.Debug("delete")
+	return nil
+}
+
+func (l *queue) Purge() (int, error) {
+	n, err := l.w.Purge()
+	if err != nil {
+		l.log.Error("purge", zap.Error(err))
+		return 0, err
+	}
+	l.log.Debug("purge", zap.Int("purged", n))
+	return n, nil
+}
+
+func (l *queue) Walk(offset uint64, fn q.WalkFunc) error {
+	log := l.log.With(zap.Uint64("offset", offset))
+	if err := l.w.Walk(offset, fn); err != nil {
+		log.Error("walk", zap.Error(err))
+		return err
+	}
+	log.Debug("walk")
+	return nil
+}
+
+func (l *queue) Added() <-chan struct{} {
+	return q.Added(l.w)
+}
+
+func (l *queue) Unwrap() q.Queue {
+	return l.w
+}
+
+func (l *queue) CanTransfer(dst q.Queue) bool {
+	t, ok := l.w.(q.Transferer)
+	return ok && t.CanTransfer(dst)
+}
+
+func (l *queue) Transfer(ctx context.Context, dst q.Queue, max int, move bool, t ...q.Tag) ([]*q.Message, error) {
+	log := l.log.With(zap.Stringer("destination", dst.ID()), zap.Int("max", max), zap.Bool("move", move), tagsField(t))
+	transferred, err := l.w.(q.Transferer).Transfer(ctx, dst, max, move, t...)
+	if err != nil {
+		log.Error("transfer", zap.Int("transferred", len(transferred)), zap.Error(err))
+		return transferred, err
+	}
+	log.Debug("transfer", zap.Int("transferred", len(transferred)))
+	return transferred, nil
+}
@@ -9,9 +9,17 @@ import (
 
 	"github.com/negz/q"
 	"github.com/negz/q/e"
+	"github.com/negz/q/memory"
+	"github.com/negz/q/queuetest"
 	"github.com/negz/q/test/fixtures"
 )
 
+func TestLoggingSuite(t *testing.T) {
+	queuetest.RunQueueSuite(t, func(limit int) q.Queue {
+		return Queue(memory.New(memory.Limit(limit)), zap.NewNop())
+	})
+}
+
 func TestLogging(t *testing.T) {
 	t.Run("Add", func(t *testing.T) {
 		msg := q.NewMessage([]byte("add"))
@@ -29,6 +37,20 @@ func TestLogging(t *testing.T) {
 		}
 	})
 
+	t.Run("AddEvict", func(t *testing.T) {
+		msg := q.NewMessage([]byte("add"))
+		old := q.NewMessage([]byte("evict"))
+		queue := Queue(fixtures.NewPredictableQueue(old, nil), zap.NewNop())
+		var evicted *q.Message
+		queue.OnEvict(func(m *q.Message) { evicted = m })
+		if err := queue.Add(msg); err != nil {
+			t.Errorf("queue.Add(%v): %v", msg, err)
+		}
+		if evicted != old {
+			t.Errorf("queue.OnEvict(): want %v evicted, got %v", old, evicted)
+		}
+	})
+
 	t.Run("Peek", func(t *testing.T) {
 		msg := q.NewMessage([]byte("peek"))
 		queue := Queue(fixtures.NewPredictableQueue(msg, nil), zap.NewNop())
@@ -66,4 +88,57 @@ func TestLogging(t *testing.T) {
 			t.Errorf("queue.Pop(): want error satisfying e.IsNotFound(), got %v", err)
 		}
 	})
+
+	t.Run("PopMatching", func(t *testing.T) {
+		tag := q.Tag{Key: "region", Value: "eu"}
+		msg := q.NewMessage([]byte("pop"), q.Tagged(tag))
+		queue := Queue(fixtures.NewPredictableQueue(msg, nil), zap.NewNop())
+		m, err := queue.PopMatching(tag)
+		if err != nil {
+			t.Errorf("queue.PopMatching(%v): %v", tag, err)
+		}
+		if !reflect.DeepEqual(msg, m) {
+			t.Errorf("queue.PopMatching(%v): want %v, got %v", tag, msg, m)
+		}
+	})
+
+	t.Run("PeekMatchingNotFound", func(t *testing.T) {
+		tag := q.Tag{Key: "region", Value: "eu"}
+		queue := Queue(fixtures.NewPredictableQueue(nil, e.ErrNotFound(errors.New("empty!"))), zap.NewNop())
+		if _, err := queue.PeekMatching(tag); !e.IsNotFound(err) {
+			t.Errorf("queue.PeekMatching(%v): want error satisfying e.IsNotFound(), got %v", tag, err)
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		msg := q.NewMessage([]byte("get"))
+		queue := Queue(fixtures.NewPredictableQueue(msg, nil), zap.NewNop())
+		m, err := queue.Get(msg.ID)
+		if err != nil {
+			t.Errorf("queue.Get(%v): %v", msg.ID, err)
+		}
+		if !reflect.DeepEqual(msg, m) {
+			t.Errorf("queue.Get(%v): want %v, got %v", msg.ID, msg, m)
+		}
+	})
+
+	t.Run("DeleteNotFound", func(t *testing.T) {
+		id := q.NewMessage([]byte("delete")).ID
+		queue := Queue(fixtures.NewPredictableQueue(nil, e.ErrNotFound(errors.New("missing!"))), zap.NewNop())
+		if err := queue.Delete(id); !e.IsNotFound(err) {
+			t.Errorf("queue.Delete(%v): want error satisfying e.IsNotFound(), got %v", id, err)
+		}
+	})
+
+	t.Run("Purge", func(t *testing.T) {
+		msg := q.NewMessage([]byte("purge"))
+		queue := Queue(fixtures.NewPredictableQueue(msg, nil), zap.NewNop())
+		n, err := queue.Purge()
+		if err != nil {
+			t.Errorf("queue.Purge(): %v", err)
+		}
+		if n != 1 {
+			t.Errorf("queue.Purge(): want 1 purged, got %v", n)
+		}
+	})
 }
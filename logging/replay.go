@@ -0,0 +1,37 @@
+package logging
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/negz/q"
+)
+
+// A seekerQueue is a logging queue that wraps a seeker.
+type seekerQueue struct {
+	*queue
+	ws q.Seeker
+}
+
+func (l *seekerQueue) Seek(offset uint64) ([]*q.Message, error) {
+	log := l.log.With(zap.Uint64("offset", offset))
+	replayed, err := l.ws.Seek(offset)
+	if err != nil {
+		log.Error("seek", zap.Error(err))
+		return nil, err
+	}
+	log.Debug("seek", zap.Int("replayed", len(replayed)))
+	return replayed, nil
+}
+
+func (l *seekerQueue) SeekTime(t time.Time) ([]*q.Message, error) {
+	log := l.log.With(zap.Time("time", t))
+	replayed, err := l.ws.SeekTime(t)
+	if err != nil {
+		log.Error("seek", zap.Error(err))
+		return nil, err
+	}
+	log.Debug("seek", zap.Int("replayed", len(replayed)))
+	return replayed, nil
+}
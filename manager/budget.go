@@ -0,0 +1,492 @@
+package manager
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+	"github.com/negz/q/metrics"
+)
+
+// A Budget limits the resources consumed by a set of queues. Each limit may be
+// q.Unbounded.
+type Budget struct {
+	Queues   int // Queues is the maximum number of queues.
+	Messages int // Messages is the maximum number of messages across all queues.
+	Bytes    int // Bytes is the maximum total size of message payloads across all queues.
+}
+
+// Unlimited is a budget that does not limit resource consumption.
+var Unlimited = Budget{Queues: q.Unbounded, Messages: q.Unbounded, Bytes: q.Unbounded}
+
+// usage tracks the resources consumed by a set of queues.
+type usage struct {
+	queues   int
+	messages int
+	bytes    int
+}
+
+// check returns an error if consuming the supplied additional resources would
+// exceed the budget. The budget is described by name in any error.
+func (b Budget) check(name string, u, more usage) error {
+	if more.queues > 0 && b.Queues != q.Unbounded && u.queues+more.queues > b.Queues {
+		err := e.ErrFull(errors.Errorf("%s budget of %d queues exhausted", name, b.Queues))
+		return e.WithReason(e.WithQuota(err, name, fmt.Sprintf("budget of %d queues", b.Queues)), e.BUDGET_EXHAUSTED)
+	}
+	if more.messages > 0 && b.Messages != q.Unbounded && u.messages+more.messages > b.Messages {
+		err := e.ErrFull(errors.Errorf("%s budget of %d messages exhausted", name, b.Messages))
+		return e.WithReason(e.WithQuota(err, name, fmt.Sprintf("budget of %d messages", b.Messages)), e.BUDGET_EXHAUSTED)
+	}
+	if more.bytes > 0 && b.Bytes != q.Unbounded && u.bytes+more.bytes > b.Bytes {
+		err := e.ErrFull(errors.Errorf("%s budget of %d bytes exhausted", name, b.Bytes))
+		return e.WithReason(e.WithQuota(err, name, fmt.Sprintf("budget of %d bytes", b.Bytes)), e.BUDGET_EXHAUSTED)
+	}
+	return nil
+}
+
+type budgeted struct {
+	m       q.Manager
+	mx      q.Metrics
+	global  Budget
+	tenant  Budget
+	tag     string
+	total   usage
+	tenants map[string]*usage
+	queues  map[uuid.UUID]*budgetedQueue
+	mu      *sync.Mutex // Guards total, tenants, queues, and queue usage.
+}
+
+// A BudgetOption represents an optional argument to a budgeted manager.
+type BudgetOption func(*budgeted)
+
+// WithGlobalBudget limits the resources consumed by all queues.
+func WithGlobalBudget(b Budget) BudgetOption {
+	return func(m *budgeted) {
+		m.global = b
+	}
+}
+
+// WithTenantBudget limits the resources consumed by the queues of each tenant.
+// Tenants are identified by the value of the queue tag with the supplied key.
+// Queues without this tag are subject only to the global budget.
+func WithTenantBudget(key string, b Budget) BudgetOption {
+	return func(m *budgeted) {
+		m.tag = key
+		m.tenant = b
+	}
+}
+
+// WithBudgetMetrics reports the resources consumed by each tenant, and by all
+// tenants, via the supplied Metrics.
+func WithBudgetMetrics(mx q.Metrics) BudgetOption {
+	return func(m *budgeted) {
+		m.mx = mx
+	}
+}
+
+// Budgeted returns a queue manager that limits the queues, messages, and bytes
+// consumed by the queues it manages. New queues and messages that would exceed
+// the global budget or their tenant's budget are rejected with an error
+// satisfying e.IsFull. A queue's tenant is determined when it is added to the
+// manager.
+//
+// Budgeted wraps the queues added to it, so it should wrap any instrumented
+// manager in order for rejected messages to be logged and counted.
+func Budgeted(m q.Manager, o ...BudgetOption) q.Manager {
+	b := &budgeted{
+		m:       m,
+		mx:      metrics.NewNop(),
+		global:  Unlimited,
+		tenant:  Unlimited,
+		tenants: make(map[string]*usage),
+		queues:  make(map[uuid.UUID]*budgetedQueue),
+		mu:      &sync.Mutex{},
+	}
+	for _, opt := range o {
+		opt(b)
+	}
+	return b
+}
+
+// Add accounts for any messages already in the supplied queue.
+func (b *budgeted) Add(queue q.Queue) error {
+	existing := usage{queues: 1}
+	if err := queue.Walk(0, func(_ uint64, m *q.Message) bool {
+		existing.messages++
+		existing.bytes += len(m.Payload)
+		return true
+	}); err != nil {
+		return errors.Wrap(err, "cannot determine usage of queue")
+	}
+
+	bq := &budgetedQueue{w: queue, b: b}
+	if b.tag != "" {
+		bq.tenant = tenantOf(queue, b.tag)
+	}
+	if err := b.reserve(bq, existing); err != nil {
+		return errors.Wrapf(err, "cannot add queue %s", queue.ID())
+	}
+	var wrapped q.Queue = bq
+	if wl, ok := queue.(q.Log); ok {
+		wrapped = &budgetedLog{budgetedQueue: bq, wl: wl}
+	}
+	if ws, ok := queue.(q.Seeker); ok {
+		wrapped = &budgetedSeeker{budgetedQueue: bq, ws: ws}
+	}
+	if err := b.m.Add(wrapped); err != nil {
+		b.release(bq, existing)
+		return err
+	}
+	queue.OnEvict(func(m *q.Message) { b.release(bq, usage{messages: 1, bytes: len(m.Payload)}) })
+	b.mu.Lock()
+	b.queues[queue.ID()] = bq
+	b.mu.Unlock()
+	return nil
+}
+
+func (b *budgeted) Get(id uuid.UUID) (q.Queue, error) {
+	return b.m.Get(id)
+}
+
+func (b *budgeted) Delete(id uuid.UUID, force bool) error {
+	if err := b.m.Delete(id, force); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if bq, ok := b.queues[id]; ok {
+		b.sub(bq, usage{queues: 1, messages: bq.used.messages, bytes: bq.used.bytes})
+		delete(b.queues, id)
+	}
+	return nil
+}
+
+func (b *budgeted) List() ([]q.Queue, error) {
+	return b.m.List()
+}
+
+func (b *budgeted) AddTopic(t q.Topic) error {
+	return b.m.AddTopic(t)
+}
+
+func (b *budgeted) GetTopic(id uuid.UUID) (q.Topic, error) {
+	return b.m.GetTopic(id)
+}
+
+func (b *budgeted) DeleteTopic(id uuid.UUID) error {
+	return b.m.DeleteTopic(id)
+}
+
+func (b *budgeted) ListTopics() ([]q.Topic, error) {
+	return b.m.ListTopics()
+}
+
+// tenantOf returns the value of the supplied queue's tag with the supplied
+// key, or an empty string if the queue has no such tag.
+func tenantOf(queue q.Queue, key string) string {
+	for _, t := range queue.Tags().Get() {
+		if t.Key == key {
+			return t.Value
+		}
+	}
+	return ""
+}
+
+// reserve records that the supplied queue will consume the supplied resources,
+// or returns an error if doing so would exceed a budget.
+func (b *budgeted) reserve(bq *budgetedQueue, more usage) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.global.check("global", b.total, more); err != nil {
+		return err
+	}
+	t := b.tenants[bq.tenant]
+	if t == nil {
+		t = &usage{}
+		b.tenants[bq.tenant] = t
+	}
+	if bq.tenant != "" {
+		if err := b.tenant.check("tenant "+bq.tenant, *t, more); err != nil {
+			return err
+		}
+	}
+	b.add(bq, more)
+	return nil
+}
+
+// claim records that the supplied queue consumes the supplied resources, even
+// if doing so exceeds a budget.
+func (b *budgeted) claim(bq *budgetedQueue, more usage) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.add(bq, more)
+}
+
+// add must be called with the lock held.
+func (b *budgeted) add(bq *budgetedQueue, more usage) {
+	t := b.tenants[bq.tenant]
+	if t == nil {
+		t = &usage{}
+		b.tenants[bq.tenant] = t
+	}
+	b.total = b.total.add(more)
+	*t = t.add(more)
+	bq.used = bq.used.add(usage{messages: more.messages, bytes: more.bytes})
+	b.mx.Usage(bq.tenant, t.queues, t.messages, t.bytes)
+	b.mx.GlobalUsage(b.total.queues, b.total.messages, b.total.bytes)
+}
+
+// release records that the supplied queue no longer consumes the supplied
+// resources.
+func (b *budgeted) release(bq *budgetedQueue, less usage) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sub(bq, less)
+}
+
+// releaseMessages records that the supplied queue no longer consumes any
+// resources for messages.
+func (b *budgeted) releaseMessages(bq *budgetedQueue) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sub(bq, bq.used)
+}
+
+// sub must be called with the lock held. A queue never releases more messages
+// or bytes than it has reserved; this could otherwise happen when messages are
+// added concurrently with a purge.
+func (b *budgeted) sub(bq *budgetedQueue, less usage) {
+	if less.messages > bq.used.messages {
+		less.messages = bq.used.messages
+	}
+	if less.bytes > bq.used.bytes {
+		less.bytes = bq.used.bytes
+	}
+	t := b.tenants[bq.tenant]
+	b.total = b.total.sub(less)
+	*t = t.sub(less)
+	bq.used = bq.used.sub(usage{messages: less.messages, bytes: less.bytes})
+	b.mx.Usage(bq.tenant, t.queues, t.messages, t.bytes)
+	b.mx.GlobalUsage(b.total.queues, b.total.messages, b.total.bytes)
+}
+
+func (u usage) add(o usage) usage {
+	return usage{queues: u.queues + o.queues, messages: u.messages + o.messages, bytes: u.bytes + o.bytes}
+}
+
+func (u usage) sub(o usage) usage {
+	return usage{queues: u.queues - o.queues, messages: u.messages - o.messages, bytes: u.bytes - o.bytes}
+}
+
+type budgetedQueue struct {
+	w      q.Queue
+	b      *budgeted
+	tenant string
+	used   usage // Guarded by b.mu.
+}
+
+func (bq *budgetedQueue) ID() uuid.UUID {
+	return bq.w.ID()
+}
+
+func (bq *budgetedQueue) Store() q.Store {
+	return bq.w.Store()
+}
+
+func (bq *budgetedQueue) Created() time.Time {
+	return bq.w.Created()
+}
+
+func (bq *budgetedQueue) Tags() *q.Tags {
+	return bq.w.Tags()
+}
+
+func (bq *budgetedQueue) Config() q.Config {
+	return bq.w.Config()
+}
+
+func (bq *budgetedQueue) Configure(c q.Config) error {
+	return bq.w.Configure(c)
+}
+
+func (bq *budgetedQueue) OnEvict(fn func(*q.Message)) {
+	bq.w.OnEvict(fn)
+}
+
+func (bq *budgetedQueue) Add(m *q.Message) error {
+	return bq.AddContext(context.Background(), m)
+}
+
+// AddContext reserves budget for the supplied message before adding it, and
+// releases it if the message cannot be added. Producers blocked waiting for
+// room in a full queue hold their reservation while they wait.
+func (bq *budgetedQueue) AddContext(ctx context.Context, m *q.Message) error {
+	u := usage{messages: 1, bytes: len(m.Payload)}
+	if err := bq.b.reserve(bq, u); err != nil {
+		return errors.Wrapf(err, "cannot add message to queue %s", bq.ID())
+	}
+	if err := bq.w.AddContext(ctx, m); err != nil {
+		bq.b.release(bq, u)
+		return err
+	}
+	return nil
+}
+
+func (bq *budgetedQueue) Pop() (*q.Message, error) {
+	m, err := bq.w.Pop()
+	if err != nil {
+		return nil, err
+	}
+	bq.b.release(bq, usage{messages: 1, bytes: len(m.Payload)})
+	return m, nil
+}
+
+func (bq *budgetedQueue) Peek() (*q.Message, error) {
+	return bq.w.Peek()
+}
+
+func (bq *budgetedQueue) PopMatching(t ...q.Tag) (*q.Message, error) {
+	m, err := bq.w.PopMatching(t...)
+	if err != nil {
+		return nil, err
+	}
+	bq.b.release(bq, usage{messages: 1, bytes: len(m.Payload)})
+	return m, nil
+}
+
+func (bq *budgetedQueue) PeekMatching(t ...q.Tag) (*q.Message, error) {
+	return bq.w.PeekMatching(t...)
+}
+
+func (bq *budgetedQueue) Get(id uuid.UUID) (*q.Message, error) {
+	return bq.w.Get(id)
+}
+
+// Delete gets the message before deleting it in order to determine its size.
+func (bq *budgetedQueue) Delete(id uuid.UUID) error {
+	m, err := bq.w.Get(id)
+	if err != nil {
+		return err
+	}
+	if err := bq.w.Delete(id); err != nil {
+		return err
+	}
+	bq.b.release(bq, usage{messages: 1, bytes: len(m.Payload)})
+	return nil
+}
+
+func (bq *budgetedQueue) Purge() (int, error) {
+	n, err := bq.w.Purge()
+	if err != nil {
+		return 0, err
+	}
+	bq.b.releaseMessages(bq)
+	return n, nil
+}
+
+func (bq *budgetedQueue) Walk(offset uint64, fn q.WalkFunc) error {
+	return bq.w.Walk(offset, fn)
+}
+
+func (bq *budgetedQueue) Added() <-chan struct{} {
+	return q.Added(bq.w)
+}
+
+func (bq *budgetedQueue) Unwrap() q.Queue {
+	return bq.w
+}
+
+func (bq *budgetedQueue) CanTransfer(dst q.Queue) bool {
+	t, ok := bq.w.(q.Transferer)
+	return ok && t.CanTransfer(dst)
+}
+
+// Transfer releases the budget of moved messages. The destination queue
+// reserves budget for them.
+func (bq *budgetedQueue) Transfer(ctx context.Context, dst q.Queue, max int, move bool, t ...q.Tag) ([]*q.Message, error) {
+	transferred, err := bq.w.(q.Transferer).Transfer(ctx, dst, max, move, t...)
+	if move {
+		for _, m := range transferred {
+			bq.b.release(bq, usage{messages: 1, bytes: len(m.Payload)})
+		}
+	}
+	return transferred, err
+}
+
+// A budgetedLog is a budgeted queue that wraps a log. Messages discarded by the
+// log release their budget via OnEvict.
+type budgetedLog struct {
+	*budgetedQueue
+	wl q.Log
+}
+
+func (bl *budgetedLog) AddGroup(name string) (q.Group, error) {
+	return bl.wl.AddGroup(name)
+}
+
+func (bl *budgetedLog) GetGroup(name string) (q.Group, error) {
+	return bl.wl.GetGroup(name)
+}
+
+func (bl *budgetedLog) DeleteGroup(name string) error {
+	return bl.wl.DeleteGroup(name)
+}
+
+func (bl *budgetedLog) Groups() []q.Group {
+	return bl.wl.Groups()
+}
+
+func (bl *budgetedLog) Read(group string, max int) ([]*q.Message, uint64, error) {
+	return bl.wl.Read(group, max)
+}
+
+func (bl *budgetedLog) Commit(group string, offset uint64) (q.Group, error) {
+	return bl.wl.Commit(group, offset)
+}
+
+func (bl *budgetedLog) Seek(group string, offset uint64) (q.Group, error) {
+	return bl.wl.Seek(group, offset)
+}
+
+func (bl *budgetedLog) SeekTime(group string, t time.Time) (q.Group, error) {
+	return bl.wl.SeekTime(group, t)
+}
+
+func (bl *budgetedLog) OnLag(fn func(group string, lag uint64)) {
+	bl.wl.OnLag(fn)
+}
+
+// A budgetedSeeker is a budgeted queue that wraps a seeker. Seeking ignores
+// budgets, just as it ignores queue limits, but replayed messages count toward
+// them. New messages may be rejected until enough have been consumed.
+type budgetedSeeker struct {
+	*budgetedQueue
+	ws q.Seeker
+}
+
+func (bs *budgetedSeeker) Seek(offset uint64) ([]*q.Message, error) {
+	return bs.replayed(bs.ws.Seek(offset))
+}
+
+func (bs *budgetedSeeker) SeekTime(t time.Time) ([]*q.Message, error) {
+	return bs.replayed(bs.ws.SeekTime(t))
+}
+
+func (bs *budgetedSeeker) replayed(replayed []*q.Message, err error) ([]*q.Message, error) {
+	if err != nil {
+		return nil, err
+	}
+	u := usage{}
+	for _, m := range replayed {
+		u.messages++
+		u.bytes += len(m.Payload)
+	}
+	bs.b.claim(bs.budgetedQueue, u)
+	return replayed, nil
+}
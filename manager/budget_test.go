@@ -0,0 +1,217 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+	"github.com/negz/q/memory"
+	"github.com/negz/q/metrics"
+	"github.com/negz/q/queuetest"
+)
+
+func TestBudgetedQueues(t *testing.T) {
+	m := Budgeted(New(), WithGlobalBudget(Budget{Queues: 2, Messages: q.Unbounded, Bytes: q.Unbounded}))
+	queues := []q.Queue{memory.New(), memory.New()}
+	for _, queue := range queues {
+		if err := m.Add(queue); err != nil {
+			t.Fatalf("m.Add(%v): %v", queue.ID(), err)
+		}
+	}
+	extra := memory.New()
+	if err := m.Add(extra); !e.IsFull(err) {
+		t.Errorf("m.Add(%v): want error satisfying e.IsFull(), got %v", extra.ID(), err)
+	}
+
+	// Deleting a queue should free its place in the budget.
+	if err := m.Delete(queues[0].ID(), false); err != nil {
+		t.Fatalf("m.Delete(%v): %v", queues[0].ID(), err)
+	}
+	if err := m.Add(extra); err != nil {
+		t.Errorf("m.Add(%v): %v", extra.ID(), err)
+	}
+}
+
+func TestBudgetedMessages(t *testing.T) {
+	m := Budgeted(New(), WithGlobalBudget(Budget{Queues: q.Unbounded, Messages: 3, Bytes: 16}))
+
+	// Messages already in a queue count toward the budget.
+	full := memory.New()
+	existing := q.NewMessage([]byte("ranger"))
+	if err := full.Add(existing); err != nil {
+		t.Fatalf("full.Add(%v): %v", existing, err)
+	}
+	if err := m.Add(full); err != nil {
+		t.Fatalf("m.Add(%v): %v", full.ID(), err)
+	}
+
+	other := memory.New(memory.Limit(1), memory.Overflow(q.DropOldest))
+	if err := m.Add(other); err != nil {
+		t.Fatalf("m.Add(%v): %v", other.ID(), err)
+	}
+	queue, err := m.Get(other.ID())
+	if err != nil {
+		t.Fatalf("m.Get(%v): %v", other.ID(), err)
+	}
+
+	// Evicted messages should free their place in the budget.
+	for _, p := range []string{"luna", "venera", "mars"} {
+		msg := q.NewMessage([]byte(p))
+		if err := queue.Add(msg); err != nil {
+			t.Fatalf("queue.Add(%v): %v", msg, err)
+		}
+	}
+
+	big := q.NewMessage([]byte("vanguard"))
+	if err := queue.Add(big); !e.IsFull(err) {
+		t.Errorf("queue.Add(%v): want error satisfying e.IsFull(), got %v", big, err)
+	}
+
+	// Consumed messages should free their place in the budget.
+	if _, err := queue.Pop(); err != nil {
+		t.Fatalf("queue.Pop(): %v", err)
+	}
+	if err := queue.Add(big); err != nil {
+		t.Errorf("queue.Add(%v): %v", big, err)
+	}
+}
+
+func TestBudgetedTenants(t *testing.T) {
+	m := Budgeted(New(), WithTenantBudget("team", Budget{Queues: q.Unbounded, Messages: 1, Bytes: q.Unbounded}))
+
+	apollo := memory.New(memory.Tagged(q.Tag{Key: "team", Value: "apollo"}))
+	gemini := memory.New(memory.Tagged(q.Tag{Key: "team", Value: "gemini"}))
+	untagged := memory.New()
+	for _, queue := range []q.Queue{apollo, gemini, untagged} {
+		if err := m.Add(queue); err != nil {
+			t.Fatalf("m.Add(%v): %v", queue.ID(), err)
+		}
+	}
+	get := func(queue q.Queue) q.Queue {
+		got, err := m.Get(queue.ID())
+		if err != nil {
+			t.Fatalf("m.Get(%v): %v", queue.ID(), err)
+		}
+		return got
+	}
+
+	msg := q.NewMessage([]byte("eagle"))
+	if err := get(apollo).Add(msg); err != nil {
+		t.Fatalf("apollo.Add(%v): %v", msg, err)
+	}
+	if err := get(apollo).Add(msg); !e.IsFull(err) {
+		t.Errorf("apollo.Add(%v): want error satisfying e.IsFull(), got %v", msg, err)
+	}
+
+	// Other tenants have their own budget, and queues without a tenant are
+	// subject only to the global budget.
+	if err := get(gemini).Add(msg); err != nil {
+		t.Errorf("gemini.Add(%v): %v", msg, err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := get(untagged).Add(msg); err != nil {
+			t.Errorf("untagged.Add(%v): %v", msg, err)
+		}
+	}
+
+	// Purging a queue should free its messages' place in the budget.
+	if _, err := get(apollo).Purge(); err != nil {
+		t.Fatalf("apollo.Purge(): %v", err)
+	}
+	if err := get(apollo).Add(msg); err != nil {
+		t.Errorf("apollo.Add(%v): %v", msg, err)
+	}
+}
+
+// A replayer is a seeker that replays its messages into the queue it wraps.
+type replayer struct {
+	q.Queue
+	replay []*q.Message
+}
+
+func (r *replayer) Seek(_ uint64) ([]*q.Message, error) {
+	for _, m := range r.replay {
+		if err := r.Queue.Add(m); err != nil {
+			return nil, err
+		}
+	}
+	return r.replay, nil
+}
+
+func (r *replayer) SeekTime(_ time.Time) ([]*q.Message, error) {
+	return r.Seek(0)
+}
+
+func TestBudgetedSeek(t *testing.T) {
+	m := Budgeted(New(), WithGlobalBudget(Budget{Queues: q.Unbounded, Messages: 2, Bytes: q.Unbounded}))
+	r := &replayer{Queue: memory.New(), replay: []*q.Message{q.NewMessage([]byte("voyager")), q.NewMessage([]byte("galileo"))}}
+	if err := m.Add(r); err != nil {
+		t.Fatalf("m.Add(%v): %v", r.ID(), err)
+	}
+	queue, err := m.Get(r.ID())
+	if err != nil {
+		t.Fatalf("m.Get(%v): %v", r.ID(), err)
+	}
+	s, ok := queue.(q.Seeker)
+	if !ok {
+		t.Fatalf("m.Get(%v): want q.Seeker, got %T", r.ID(), queue)
+	}
+	if _, err := s.Seek(0); err != nil {
+		t.Fatalf("s.Seek(0): %v", err)
+	}
+
+	// Replayed messages count toward the budget.
+	msg := q.NewMessage([]byte("cassini"))
+	if err := queue.Add(msg); !e.IsFull(err) {
+		t.Errorf("queue.Add(%v): want error satisfying e.IsFull(), got %v", msg, err)
+	}
+	if _, err := queue.Pop(); err != nil {
+		t.Fatalf("queue.Pop(): %v", err)
+	}
+	if err := queue.Add(msg); err != nil {
+		t.Errorf("queue.Add(%v): %v", msg, err)
+	}
+}
+
+type usageRecorder struct {
+	q.Metrics
+	global usage
+}
+
+func (r *usageRecorder) GlobalUsage(queues, messages, bytes int) {
+	r.global = usage{queues: queues, messages: messages, bytes: bytes}
+}
+
+func TestBudgetedGlobalUsage(t *testing.T) {
+	r := &usageRecorder{Metrics: metrics.NewNop()}
+	m := Budgeted(New(), WithTenantBudget("team", Unlimited), WithBudgetMetrics(r))
+
+	apollo := memory.New(memory.Tagged(q.Tag{Key: "team", Value: "apollo"}))
+	untagged := memory.New()
+	for _, queue := range []q.Queue{apollo, untagged} {
+		if err := m.Add(queue); err != nil {
+			t.Fatalf("m.Add(%v): %v", queue.ID(), err)
+		}
+		got, err := m.Get(queue.ID())
+		if err != nil {
+			t.Fatalf("m.Get(%v): %v", queue.ID(), err)
+		}
+		msg := q.NewMessage([]byte("eagle"))
+		if err := got.Add(msg); err != nil {
+			t.Fatalf("got.Add(%v): %v", msg, err)
+		}
+	}
+
+	want := usage{queues: 2, messages: 2, bytes: 10}
+	if r.global != want {
+		t.Errorf("r.global: want %+v, got %+v", want, r.global)
+	}
+}
+
+func TestBudgetedSuite(t *testing.T) {
+	newManager := func() q.Manager { return Budgeted(New()) }
+	newQueue := func(limit int) q.Queue { return memory.New(memory.Limit(limit)) }
+	t.Run("Manager", func(t *testing.T) { queuetest.RunManagerSuite(t, newManager, newQueue) })
+	t.Run("Queue", func(t *testing.T) { queuetest.RunQueueSuite(t, managed(newManager())) })
+}
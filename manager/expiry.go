@@ -0,0 +1,360 @@
+package manager
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+	"github.com/negz/q/metrics"
+)
+
+// DefaultExpiryInterval is how often an expiring manager checks for idle queues
+// by default.
+const DefaultExpiryInterval = time.Minute
+
+type expiring struct {
+	m        q.Manager
+	mx       q.Metrics
+	log      *zap.Logger
+	interval time.Duration
+	queues   map[uuid.UUID]*expiringQueue
+	mu       *sync.Mutex // Guards queues.
+}
+
+// An ExpiryOption represents an optional argument to an expiring manager.
+type ExpiryOption func(*expiring)
+
+// WithExpiryInterval specifies how often to check for idle queues. An interval
+// less than or equal to zero disables the janitor. Defaults to
+// DefaultExpiryInterval.
+func WithExpiryInterval(d time.Duration) ExpiryOption {
+	return func(m *expiring) {
+		m.interval = d
+	}
+}
+
+// WithExpiryMetrics counts the queues deleted for being idle via the supplied
+// Metrics.
+func WithExpiryMetrics(mx q.Metrics) ExpiryOption {
+	return func(m *expiring) {
+		m.mx = mx
+	}
+}
+
+// WithExpiryLogger logs the queues deleted for being idle via the supplied
+// Logger.
+func WithExpiryLogger(l *zap.Logger) ExpiryOption {
+	return func(m *expiring) {
+		m.log = l
+	}
+}
+
+// Expiring returns a queue manager that deletes empty queues that have gone
+// longer than their configured ExpireAfterIdle without messages being added,
+// popped, peeked, or read by a consumer group. A janitor goroutine checks for
+// idle queues until the supplied context is done. A queue's idle time is
+// measured from when it was added to the manager.
+//
+// Idle queues are deleted via the supplied manager, so Expiring should wrap any
+// instrumented or budgeted manager in order for deletions to be logged and
+// accounted for.
+func Expiring(ctx context.Context, m q.Manager, o ...ExpiryOption) q.Manager {
+	x := &expiring{
+		m:        m,
+		mx:       metrics.NewNop(),
+		log:      zap.NewNop(),
+		interval: DefaultExpiryInterval,
+		queues:   make(map[uuid.UUID]*expiringQueue),
+		mu:       &sync.Mutex{},
+	}
+	for _, opt := range o {
+		opt(x)
+	}
+	if x.interval <= 0 {
+		return x
+	}
+	go x.janitor(ctx)
+	return x
+}
+
+func (x *expiring) janitor(ctx context.Context) {
+	t := time.NewTicker(x.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-t.C:
+			x.expire(now)
+		}
+	}
+}
+
+// expire deletes the queues that were idle for longer than their
+// ExpireAfterIdle at the supplied time.
+func (x *expiring) expire(now time.Time) {
+	x.mu.Lock()
+	idle := []*expiringQueue{}
+	for _, xq := range x.queues {
+		if xq.idle(now) {
+			idle = append(idle, xq)
+		}
+	}
+	x.mu.Unlock()
+	for _, xq := range idle {
+		// The queue may have been used since we found it idle.
+		if !xq.idle(now) {
+			continue
+		}
+		id := xq.ID()
+		log := x.log.With(zap.String("id", id.String()), zap.Duration("idle", now.Sub(xq.accessed())))
+		// Queues that still hold messages are not deleted.
+		if err := x.Delete(id, false); err != nil {
+			if e.IsFailedPrecondition(err) {
+				log.Debug("expire queue", zap.Error(err))
+				continue
+			}
+			log.Error("expire queue", zap.Error(err))
+			continue
+		}
+		log.Info("expire queue")
+		x.mx.Expired(id)
+	}
+}
+
+func (x *expiring) Add(queue q.Queue) error {
+	xq := &expiringQueue{w: queue}
+	xq.access()
+	var wrapped q.Queue = xq
+	if wl, ok := queue.(q.Log); ok {
+		wrapped = &expiringLog{expiringQueue: xq, wl: wl}
+	}
+	if ws, ok := queue.(q.Seeker); ok {
+		wrapped = &expiringSeeker{expiringQueue: xq, ws: ws}
+	}
+	if err := x.m.Add(wrapped); err != nil {
+		return err
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.queues[queue.ID()] = xq
+	return nil
+}
+
+func (x *expiring) Get(id uuid.UUID) (q.Queue, error) {
+	return x.m.Get(id)
+}
+
+func (x *expiring) Delete(id uuid.UUID, force bool) error {
+	if err := x.m.Delete(id, force); err != nil {
+		return err
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	delete(x.queues, id)
+	return nil
+}
+
+func (x *expiring) List() ([]q.Queue, error) {
+	return x.m.List()
+}
+
+func (x *expiring) AddTopic(t q.Topic) error {
+	return x.m.AddTopic(t)
+}
+
+func (x *expiring) GetTopic(id uuid.UUID) (q.Topic, error) {
+	return x.m.GetTopic(id)
+}
+
+func (x *expiring) DeleteTopic(id uuid.UUID) error {
+	return x.m.DeleteTopic(id)
+}
+
+func (x *expiring) ListTopics() ([]q.Topic, error) {
+	return x.m.ListTopics()
+}
+
+// An expiringQueue records when messages were last added to, popped from, or
+// peeked into the queue it wraps.
+type expiringQueue struct {
+	w    q.Queue
+	last int64 // Unix nanoseconds. Accessed atomically.
+}
+
+func (xq *expiringQueue) access() {
+	atomic.StoreInt64(&xq.last, time.Now().UnixNano())
+}
+
+func (xq *expiringQueue) accessed() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&xq.last))
+}
+
+// idle returns true if the queue has gone longer than its ExpireAfterIdle
+// without being used at the supplied time.
+func (xq *expiringQueue) idle(now time.Time) bool {
+	d := xq.Config().ExpireAfterIdle
+	return d > 0 && now.Sub(xq.accessed()) > d
+}
+
+func (xq *expiringQueue) ID() uuid.UUID {
+	return xq.w.ID()
+}
+
+func (xq *expiringQueue) Store() q.Store {
+	return xq.w.Store()
+}
+
+func (xq *expiringQueue) Created() time.Time {
+	return xq.w.Created()
+}
+
+func (xq *expiringQueue) Tags() *q.Tags {
+	return xq.w.Tags()
+}
+
+func (xq *expiringQueue) Config() q.Config {
+	return xq.w.Config()
+}
+
+func (xq *expiringQueue) Configure(c q.Config) error {
+	return xq.w.Configure(c)
+}
+
+func (xq *expiringQueue) OnEvict(fn func(*q.Message)) {
+	xq.w.OnEvict(fn)
+}
+
+func (xq *expiringQueue) Add(m *q.Message) error {
+	return xq.AddContext(context.Background(), m)
+}
+
+func (xq *expiringQueue) AddContext(ctx context.Context, m *q.Message) error {
+	xq.access()
+	return xq.w.AddContext(ctx, m)
+}
+
+func (xq *expiringQueue) Pop() (*q.Message, error) {
+	xq.access()
+	return xq.w.Pop()
+}
+
+func (xq *expiringQueue) Peek() (*q.Message, error) {
+	xq.access()
+	return xq.w.Peek()
+}
+
+func (xq *expiringQueue) PopMatching(t ...q.Tag) (*q.Message, error) {
+	xq.access()
+	return xq.w.PopMatching(t...)
+}
+
+func (xq *expiringQueue) PeekMatching(t ...q.Tag) (*q.Message, error) {
+	xq.access()
+	return xq.w.PeekMatching(t...)
+}
+
+func (xq *expiringQueue) Get(id uuid.UUID) (*q.Message, error) {
+	return xq.w.Get(id)
+}
+
+func (xq *expiringQueue) Delete(id uuid.UUID) error {
+	return xq.w.Delete(id)
+}
+
+func (xq *expiringQueue) Purge() (int, error) {
+	return xq.w.Purge()
+}
+
+func (xq *expiringQueue) Walk(offset uint64, fn q.WalkFunc) error {
+	return xq.w.Walk(offset, fn)
+}
+
+func (xq *expiringQueue) Added() <-chan struct{} {
+	return q.Added(xq.w)
+}
+
+func (xq *expiringQueue) Unwrap() q.Queue {
+	return xq.w
+}
+
+func (xq *expiringQueue) CanTransfer(dst q.Queue) bool {
+	t, ok := xq.w.(q.Transferer)
+	return ok && t.CanTransfer(dst)
+}
+
+func (xq *expiringQueue) Transfer(ctx context.Context, dst q.Queue, max int, move bool, t ...q.Tag) ([]*q.Message, error) {
+	if move {
+		xq.access()
+	}
+	return xq.w.(q.Transferer).Transfer(ctx, dst, max, move, t...)
+}
+
+// An expiringLog records when consumer groups last read from the log it wraps.
+type expiringLog struct {
+	*expiringQueue
+	wl q.Log
+}
+
+func (xl *expiringLog) AddGroup(name string) (q.Group, error) {
+	xl.access()
+	return xl.wl.AddGroup(name)
+}
+
+func (xl *expiringLog) GetGroup(name string) (q.Group, error) {
+	return xl.wl.GetGroup(name)
+}
+
+func (xl *expiringLog) DeleteGroup(name string) error {
+	return xl.wl.DeleteGroup(name)
+}
+
+func (xl *expiringLog) Groups() []q.Group {
+	return xl.wl.Groups()
+}
+
+func (xl *expiringLog) Read(group string, max int) ([]*q.Message, uint64, error) {
+	xl.access()
+	return xl.wl.Read(group, max)
+}
+
+func (xl *expiringLog) Commit(group string, offset uint64) (q.Group, error) {
+	xl.access()
+	return xl.wl.Commit(group, offset)
+}
+
+func (xl *expiringLog) Seek(group string, offset uint64) (q.Group, error) {
+	xl.access()
+	return xl.wl.Seek(group, offset)
+}
+
+func (xl *expiringLog) SeekTime(group string, t time.Time) (q.Group, error) {
+	xl.access()
+	return xl.wl.SeekTime(group, t)
+}
+
+func (xl *expiringLog) OnLag(fn func(group string, lag uint64)) {
+	xl.wl.OnLag(fn)
+}
+
+// An expiringSeeker records when the seeker it wraps last replayed messages.
+type expiringSeeker struct {
+	*expiringQueue
+	ws q.Seeker
+}
+
+func (xs *expiringSeeker) Seek(offset uint64) ([]*q.Message, error) {
+	xs.access()
+	return xs.ws.Seek(offset)
+}
+
+func (xs *expiringSeeker) SeekTime(t time.Time) ([]*q.Message, error) {
+	xs.access()
+	return xs.ws.SeekTime(t)
+}
@@ -0,0 +1,102 @@
+package manager
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/net/context"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+	"github.com/negz/q/memory"
+	"github.com/negz/q/metrics"
+	"github.com/negz/q/queuetest"
+)
+
+type expiryRecorder struct {
+	q.Metrics
+	expired []uuid.UUID
+}
+
+func (r *expiryRecorder) Expired(id uuid.UUID) { r.expired = append(r.expired, id) }
+
+func TestExpiring(t *testing.T) {
+	mx := &expiryRecorder{Metrics: metrics.NewNop()}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := Expiring(ctx, New(), WithExpiryInterval(time.Hour), WithExpiryMetrics(mx))
+
+	idle := memory.New(memory.ExpireAfterIdle(time.Minute))
+	busy := memory.New(memory.ExpireAfterIdle(time.Minute))
+	forever := memory.New()
+	read := memory.NewLog(memory.ExpireAfterIdle(time.Minute))
+	for _, queue := range []q.Queue{idle, busy, forever, read} {
+		if err := m.Add(queue); err != nil {
+			t.Fatalf("m.Add(%v): %v", queue.ID(), err)
+		}
+	}
+
+	start := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	queue, err := m.Get(busy.ID())
+	if err != nil {
+		t.Fatalf("m.Get(%v): %v", busy.ID(), err)
+	}
+	if _, err := queue.Peek(); !e.IsNotFound(err) {
+		t.Fatalf("queue.Peek(): want error satisfying e.IsNotFound(), got %v", err)
+	}
+
+	// Reading a log via its consumer groups counts as use.
+	queue, err = m.Get(read.ID())
+	if err != nil {
+		t.Fatalf("m.Get(%v): %v", read.ID(), err)
+	}
+	l, ok := queue.(q.Log)
+	if !ok {
+		t.Fatalf("m.Get(%v): want q.Log, got %T", read.ID(), queue)
+	}
+	if _, _, err := l.Read("apollo", q.Unbounded); !e.IsNotFound(err) {
+		t.Fatalf("l.Read(apollo, %v): want error satisfying e.IsNotFound(), got %v", q.Unbounded, err)
+	}
+
+	m.(*expiring).expire(start.Add(time.Minute + 5*time.Millisecond))
+
+	if _, err := m.Get(idle.ID()); !e.IsNotFound(err) {
+		t.Errorf("m.Get(%v): want idle queue deleted, got %v", idle.ID(), err)
+	}
+	for _, queue := range []q.Queue{busy, forever, read} {
+		if _, err := m.Get(queue.ID()); err != nil {
+			t.Errorf("m.Get(%v): %v", queue.ID(), err)
+		}
+	}
+	if want := []uuid.UUID{idle.ID()}; !reflect.DeepEqual(want, mx.expired) {
+		t.Errorf("mx.expired: want %v, got %v", want, mx.expired)
+	}
+}
+
+func TestExpiringDisabled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// A janitor with a zero interval would panic; none should be started.
+	m := Expiring(ctx, New(), WithExpiryInterval(0))
+	queue := memory.New(memory.ExpireAfterIdle(time.Nanosecond))
+	if err := m.Add(queue); err != nil {
+		t.Fatalf("m.Add(%v): %v", queue.ID(), err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if _, err := m.Get(queue.ID()); err != nil {
+		t.Errorf("m.Get(%v): %v", queue.ID(), err)
+	}
+}
+
+func TestExpiringSuite(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	newManager := func() q.Manager { return Expiring(ctx, New()) }
+	newQueue := func(limit int) q.Queue { return memory.New(memory.Limit(limit)) }
+	t.Run("Manager", func(t *testing.T) { queuetest.RunManagerSuite(t, newManager, newQueue) })
+	t.Run("Queue", func(t *testing.T) { queuetest.RunQueueSuite(t, managed(newManager())) })
+}
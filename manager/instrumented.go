@@ -53,10 +53,26 @@ func (i *instrumented) Get(id uuid.UUID) (q.Queue, error) {
 	return i.m.Get(id)
 }
 
-func (i *instrumented) Delete(id uuid.UUID) error {
-	return i.m.Delete(id)
+func (i *instrumented) Delete(id uuid.UUID, force bool) error {
+	return i.m.Delete(id, force)
 }
 
 func (i *instrumented) List() ([]q.Queue, error) {
 	return i.m.List()
 }
+
+func (i *instrumented) AddTopic(t q.Topic) error {
+	return i.m.AddTopic(t)
+}
+
+func (i *instrumented) GetTopic(id uuid.UUID) (q.Topic, error) {
+	return i.m.GetTopic(id)
+}
+
+func (i *instrumented) DeleteTopic(id uuid.UUID) error {
+	return i.m.DeleteTopic(id)
+}
+
+func (i *instrumented) ListTopics() ([]q.Topic, error) {
+	return i.m.ListTopics()
+}
@@ -1,9 +1,12 @@
 // Package manager provides implementations of queue managers, which act as an
-// index of various FIFO queues.
+// index of various FIFO queues and the topics that fan out to them.
 package manager
 
 import (
+	"bytes"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
@@ -14,21 +17,23 @@ import (
 
 type manager struct {
 	m  map[uuid.UUID]q.Queue
+	t  map[uuid.UUID]q.Topic
 	mx *sync.RWMutex
 }
 
 // New returns a new in-memory queue manager.
 func New() q.Manager {
-	return &manager{m: make(map[uuid.UUID]q.Queue), mx: &sync.RWMutex{}}
+	return &manager{m: make(map[uuid.UUID]q.Queue), t: make(map[uuid.UUID]q.Topic), mx: &sync.RWMutex{}}
 }
 
 func (m *manager) Add(queue q.Queue) error {
 	m.mx.Lock()
 	defer m.mx.Unlock()
-	m.m[queue.ID()] = queue
-	// An add to a map will always succeed, but our interface supports
-	// returning an error for future compatibility with more complex backing
-	// stores.
+	id := queue.ID()
+	if _, ok := m.m[id]; ok {
+		return e.WithResource(e.ErrAlreadyExists(errors.Errorf("queue with id %s already exists", id)), "queue", id.String())
+	}
+	m.m[id] = queue
 	return nil
 }
 
@@ -37,21 +42,43 @@ func (m *manager) Get(id uuid.UUID) (q.Queue, error) {
 	defer m.mx.RUnlock()
 	queue, ok := m.m[id]
 	if !ok {
-		return nil, e.ErrNotFound(errors.Errorf("cannot find queue with id %s", id))
+		return nil, queueNotFound(id)
 	}
 	return queue, nil
 }
 
-func (m *manager) Delete(id uuid.UUID) error {
+func queueNotFound(id uuid.UUID) error {
+	err := e.WithResource(e.ErrNotFound(errors.Errorf("cannot find queue with id %s", id)), "queue", id.String())
+	return e.WithReason(err, e.QUEUE_NOT_FOUND)
+}
+
+func topicNotFound(id uuid.UUID) error {
+	err := e.WithResource(e.ErrNotFound(errors.Errorf("cannot find topic with id %s", id)), "topic", id.String())
+	return e.WithReason(err, e.TOPIC_NOT_FOUND)
+}
+
+// Delete determines whether a queue is empty by walking it rather than peeking
+// into it, so that it does not count as a use of the queue.
+func (m *manager) Delete(id uuid.UUID, force bool) error {
 	m.mx.Lock()
 	defer m.mx.Unlock()
+	queue, ok := m.m[id]
+	if !ok {
+		return queueNotFound(id)
+	}
+	if err := q.Destroy(queue, force); err != nil {
+		return errors.Wrapf(err, "cannot destroy queue %s", id)
+	}
 	delete(m.m, id)
-	// A delete from a map will always succeed, but our interface supports
-	// returning an error for future compatibility with more complex backing
-	// stores.
+	for _, t := range m.t {
+		// Unsubscribe returns an error only if the queue was not subscribed.
+		t.Unsubscribe(id)
+	}
 	return nil
 }
 
+// List returns queues ordered by creation time, then by ID. Map iteration order
+// is random, so we sort to allow callers to paginate through the results.
 func (m *manager) List() ([]q.Queue, error) {
 	m.mx.RLock()
 	defer m.mx.RUnlock()
@@ -59,8 +86,69 @@ func (m *manager) List() ([]q.Queue, error) {
 	for _, queue := range m.m {
 		l = append(l, queue)
 	}
+	sort.Slice(l, func(i, j int) bool { return less(l[i], l[j]) })
 	// A read from a map will always succeed, but our interface supports
 	// returning an error for future compatibility with more complex backing
 	// stores.
 	return l, nil
 }
+
+func (m *manager) AddTopic(t q.Topic) error {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	id := t.ID()
+	if _, ok := m.t[id]; ok {
+		return e.WithResource(e.ErrAlreadyExists(errors.Errorf("topic with id %s already exists", id)), "topic", id.String())
+	}
+	m.t[id] = t
+	return nil
+}
+
+func (m *manager) GetTopic(id uuid.UUID) (q.Topic, error) {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+	t, ok := m.t[id]
+	if !ok {
+		return nil, topicNotFound(id)
+	}
+	return t, nil
+}
+
+func (m *manager) DeleteTopic(id uuid.UUID) error {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	if _, ok := m.t[id]; !ok {
+		return topicNotFound(id)
+	}
+	delete(m.t, id)
+	return nil
+}
+
+// ListTopics returns topics ordered by creation time, then by ID.
+func (m *manager) ListTopics() ([]q.Topic, error) {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+	l := make([]q.Topic, 0, len(m.t))
+	for _, t := range m.t {
+		l = append(l, t)
+	}
+	sort.Slice(l, func(i, j int) bool { return less(l[i], l[j]) })
+	return l, nil
+}
+
+// A resource is a queue or topic.
+type resource interface {
+	ID() uuid.UUID
+	Created() time.Time
+}
+
+// less reports whether resource a should be listed before resource b. Creation
+// times are compared as Unix nanoseconds, ignoring any monotonic clock reading,
+// so that the order agrees with page tokens, which record only wall time.
+func less(a, b resource) bool {
+	if ca, cb := a.Created().UnixNano(), b.Created().UnixNano(); ca != cb {
+		return ca < cb
+	}
+	ida, idb := a.ID(), b.ID()
+	return bytes.Compare(ida[:], idb[:]) < 0
+}
@@ -1,12 +1,18 @@
 package manager
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/net/context"
 
 	"github.com/negz/q"
 	"github.com/negz/q/e"
 	"github.com/negz/q/memory"
+	"github.com/negz/q/queuetest"
 )
 
 var managerTests = []struct {
@@ -31,6 +37,14 @@ func TestManager(t *testing.T) {
 			}
 		})
 
+		t.Run("AddExisting", func(t *testing.T) {
+			for _, queue := range tt.queues {
+				if err := m.Add(queue); !e.IsAlreadyExists(err) {
+					t.Errorf("m.Add(%v): want error satisfying e.IsAlreadyExists(), got %v", queue.ID(), err)
+				}
+			}
+		})
+
 		t.Run("Get", func(t *testing.T) {
 			for _, queue := range tt.queues {
 				got, err := m.Get(queue.ID())
@@ -66,9 +80,19 @@ func TestManager(t *testing.T) {
 			}
 		})
 
+		t.Run("ListOrdered", func(t *testing.T) {
+			l, _ := m.List()
+			for i := 1; i < len(l); i++ {
+				if less(l[i], l[i-1]) {
+					t.Errorf("m.List(): queue %v created %v listed after queue %v created %v",
+						l[i].ID(), l[i].Created(), l[i-1].ID(), l[i-1].Created())
+				}
+			}
+		})
+
 		t.Run("Delete", func(t *testing.T) {
 			for _, queue := range tt.queues {
-				m.Delete(queue.ID())
+				m.Delete(queue.ID(), false)
 				_, err := m.Get(queue.ID())
 				if !e.IsNotFound(err) {
 					t.Errorf("m.Get(%v): want error satisfying notFound(), got %v", queue.ID(), err)
@@ -77,3 +101,93 @@ func TestManager(t *testing.T) {
 		})
 	}
 }
+
+func TestManagerSuite(t *testing.T) {
+	newQueue := func(limit int) q.Queue { return memory.New(memory.Limit(limit)) }
+	queuetest.RunManagerSuite(t, New, newQueue)
+}
+
+func TestInstrumentedSuite(t *testing.T) {
+	newManager := func() q.Manager { return Instrumented(New()) }
+	newQueue := func(limit int) q.Queue { return memory.New(memory.Limit(limit)) }
+	t.Run("Manager", func(t *testing.T) { queuetest.RunManagerSuite(t, newManager, newQueue) })
+	t.Run("Queue", func(t *testing.T) { queuetest.RunQueueSuite(t, managed(newManager())) })
+}
+
+// managed returns a function that adds a new memory queue to the supplied
+// manager, returning the queue the manager returns when asked for it. The
+// function is called from the subtests of a suite, so it panics rather than
+// failing the test that created it.
+func managed(m q.Manager) func(limit int) q.Queue {
+	return func(limit int) q.Queue {
+		queue := memory.New(memory.Limit(limit))
+		if err := m.Add(queue); err != nil {
+			panic(fmt.Sprintf("m.Add(%v): %v", queue.ID(), err))
+		}
+		got, err := m.Get(queue.ID())
+		if err != nil {
+			panic(fmt.Sprintf("m.Get(%v): %v", queue.ID(), err))
+		}
+		return got
+	}
+}
+
+func TestManagerDelete(t *testing.T) {
+	m := New()
+	queue := memory.New()
+	m.Add(queue)
+	if err := queue.Add(q.NewMessage([]byte("apollo"))); err != nil {
+		t.Fatalf("queue.Add(): %v", err)
+	}
+
+	if err := m.Delete(uuid.New(), true); !e.IsNotFound(err) {
+		t.Errorf("m.Delete(): want error satisfying e.IsNotFound(), got %v", err)
+	}
+	if err := m.Delete(queue.ID(), false); !e.IsFailedPrecondition(err) {
+		t.Errorf("m.Delete(%v, false): want error satisfying e.IsFailedPrecondition(), got %v", queue.ID(), err)
+	}
+	if _, err := m.Get(queue.ID()); err != nil {
+		t.Errorf("m.Get(%v): non-empty queue was deleted: %v", queue.ID(), err)
+	}
+	if err := m.Delete(queue.ID(), true); err != nil {
+		t.Errorf("m.Delete(%v, true): %v", queue.ID(), err)
+	}
+	if _, err := m.Get(queue.ID()); !e.IsNotFound(err) {
+		t.Errorf("m.Get(%v): want error satisfying e.IsNotFound(), got %v", queue.ID(), err)
+	}
+}
+
+func TestManagedLog(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := Expiring(ctx, Budgeted(Instrumented(New())), WithExpiryInterval(time.Hour))
+	l := memory.NewLog()
+	if err := m.Add(l); err != nil {
+		t.Fatalf("m.Add(%v): %v", l.ID(), err)
+	}
+	queue, err := m.Get(l.ID())
+	if err != nil {
+		t.Fatalf("m.Get(%v): %v", l.ID(), err)
+	}
+
+	// Wrapped logs should remain logs, so that they may be read via the
+	// wrappers rather than bypassing them.
+	got, ok := queue.(q.Log)
+	if !ok {
+		t.Fatalf("m.Get(%v): want q.Log, got %T", l.ID(), queue)
+	}
+	if _, err := got.AddGroup("apollo"); err != nil {
+		t.Fatalf("got.AddGroup(apollo): %v", err)
+	}
+	msg := q.NewMessage([]byte("saturn"))
+	if err := got.Add(msg); err != nil {
+		t.Fatalf("got.Add(%v): %v", msg, err)
+	}
+	msgs, _, err := got.Read("apollo", q.Unbounded)
+	if err != nil {
+		t.Fatalf("got.Read(apollo, %v): %v", q.Unbounded, err)
+	}
+	if len(msgs) != 1 || msgs[0].ID != msg.ID {
+		t.Errorf("got.Read(apollo, %v): want [%v], got %v", q.Unbounded, msg.ID, msgs)
+	}
+}
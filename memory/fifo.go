@@ -1,4 +1,5 @@
-// Package memory provides an in-memory FIFO queue backed by a linked list.
+// Package memory provides an in-memory FIFO queue and an in-memory append-only
+// log, both backed by a linked list.
 package memory
 
 import (
@@ -7,16 +8,29 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"golang.org/x/net/context"
 
 	"github.com/negz/q"
 	"github.com/negz/q/e"
 )
 
 type fifo struct {
-	meta  *q.Metadata
-	ll    *linkedList
-	limit int
-	m     *sync.RWMutex
+	meta   *q.Metadata
+	ll     *linkedList
+	config q.Config
+	m      *sync.RWMutex
+
+	// freed is closed when space is freed in the queue, waking any producers
+	// blocked waiting for room. It is nil when no producers are waiting.
+	freed chan struct{}
+
+	// added is closed when a message is added to the queue, waking any
+	// consumers waiting for messages. It is nil when no consumers are waiting.
+	added chan struct{}
+	evict []func(*q.Message)
+
+	// destroyed is true once the queue has been destroyed.
+	destroyed bool
 }
 
 // An Option represents an optional argument to a new in-memory FIFO queue.
@@ -26,7 +40,43 @@ type Option func(*fifo)
 // Unbounded queues will accept messages until they exhaust available resources.
 func Limit(l int) Option {
 	return func(f *fifo) {
-		f.limit = l
+		f.config.Limit = l
+	}
+}
+
+// MaxBytes sets the MaxBytes field of a new queue's q.Config.
+func MaxBytes(b int) Option {
+	return func(f *fifo) {
+		f.config.MaxBytes = b
+	}
+}
+
+// MaxMessageBytes sets the MaxMessageBytes field of a new queue's q.Config.
+func MaxMessageBytes(b int) Option {
+	return func(f *fifo) {
+		f.config.MaxMessageBytes = b
+	}
+}
+
+// Overflow sets the Overflow field of a new queue's q.Config.
+func Overflow(o q.Overflow) Option {
+	return func(f *fifo) {
+		f.config.Overflow = o
+	}
+}
+
+// Retention sets the Retention field of a new log's q.Config. FIFO queues do
+// not retain consumed messages, and refuse to be configured with a retention.
+func Retention(d time.Duration) Option {
+	return func(f *fifo) {
+		f.config.Retention = d
+	}
+}
+
+// ExpireAfterIdle sets the ExpireAfterIdle field of a new queue's q.Config.
+func ExpireAfterIdle(d time.Duration) Option {
+	return func(f *fifo) {
+		f.config.ExpireAfterIdle = d
 	}
 }
 
@@ -41,8 +91,13 @@ func Tagged(t ...q.Tag) Option {
 
 // New returns a new FIFO queue backed by an in-memory linked list.
 func New(o ...Option) q.Queue {
+	return newFIFO(o...)
+}
+
+func newFIFO(o ...Option) *fifo {
 	meta := &q.Metadata{ID: uuid.New(), Created: time.Now(), Tags: &q.Tags{}}
-	f := &fifo{meta: meta, ll: &linkedList{}, limit: q.Unbounded, m: &sync.RWMutex{}}
+	c := q.Config{Limit: q.Unbounded, MaxBytes: q.Unbounded, MaxMessageBytes: q.Unbounded}
+	f := &fifo{meta: meta, ll: &linkedList{}, config: c, m: &sync.RWMutex{}}
 	for _, opt := range o {
 		opt(f)
 	}
@@ -65,14 +120,133 @@ func (f *fifo) Tags() *q.Tags {
 	return f.meta.Tags
 }
 
+func (f *fifo) Config() q.Config {
+	f.m.RLock()
+	defer f.m.RUnlock()
+	return f.config
+}
+
+func (f *fifo) Configure(c q.Config) error {
+	if c.Retention != 0 {
+		return e.ErrInvalid(errors.Errorf("in-memory queue %s cannot retain consumed messages", f.ID()))
+	}
+	return f.configure(c)
+}
+
+func (f *fifo) configure(c q.Config) error {
+	if err := c.Validate(); err != nil {
+		return e.ErrInvalid(err)
+	}
+	f.m.Lock()
+	defer f.m.Unlock()
+	f.config = c
+	f.free()
+	return nil
+}
+
+func (f *fifo) OnEvict(fn func(*q.Message)) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	f.evict = append(f.evict, fn)
+}
+
 func (f *fifo) Add(m *q.Message) error {
+	return f.AddContext(context.Background(), m)
+}
+
+func (f *fifo) AddContext(ctx context.Context, m *q.Message) error {
+	for {
+		f.m.Lock()
+		if f.destroyed {
+			f.m.Unlock()
+			err := e.WithResource(e.ErrNotFound(errors.Errorf("queue %s was destroyed", f.ID())), "queue", f.ID().String())
+			return e.WithReason(err, e.QUEUE_NOT_FOUND)
+		}
+		err := f.config.Admit(f.ID(), f.ll.length, f.ll.bytes, m)
+		if err == nil {
+			f.ll.add(m)
+			f.notify()
+			f.m.Unlock()
+			return nil
+		}
+
+		// Neither evicting nor waiting will help if the message would not
+		// fit in an empty queue.
+		if !e.IsFull(err) || f.config.Admit(f.ID(), 0, 0, m) != nil {
+			f.m.Unlock()
+			return err
+		}
+
+		switch f.config.Overflow {
+		case q.DropOldest:
+			evicted := []*q.Message{}
+			for f.config.Admit(f.ID(), f.ll.length, f.ll.bytes, m) != nil {
+				evicted = append(evicted, f.ll.pop())
+			}
+			f.ll.add(m)
+			f.notify()
+			fns := f.evict
+			f.m.Unlock()
+			for _, ev := range evicted {
+				for _, fn := range fns {
+					fn(ev)
+				}
+			}
+			return nil
+		case q.Block:
+			if f.freed == nil {
+				f.freed = make(chan struct{})
+			}
+			freed := f.freed
+			f.m.Unlock()
+			select {
+			case <-freed:
+			case <-ctx.Done():
+				return e.WithReason(e.ErrFull(errors.Wrapf(ctx.Err(), "queue %s remained full", f.ID())), e.QUEUE_FULL)
+			}
+		default:
+			f.m.Unlock()
+			return err
+		}
+	}
+}
+
+// free wakes any producers waiting for room in the queue. It must be called
+// with the write lock held.
+func (f *fifo) free() {
+	if f.freed == nil {
+		return
+	}
+	close(f.freed)
+	f.freed = nil
+}
+
+// Added returns a channel that is closed when a message is next added to the
+// queue.
+func (f *fifo) Added() <-chan struct{} {
 	f.m.Lock()
 	defer f.m.Unlock()
-	if (f.limit != q.Unbounded) && (f.ll.length >= f.limit) {
-		return e.ErrFull(errors.Errorf("queue %s has reached limit of %d messages", f.ID(), f.limit))
+	if f.added == nil {
+		f.added = make(chan struct{})
 	}
-	f.ll.add(m)
-	return nil
+	return f.added
+}
+
+// notify wakes any consumers waiting for a message to be added to the queue. It
+// must be called with the write lock held.
+func (f *fifo) notify() {
+	if f.added == nil {
+		return
+	}
+	close(f.added)
+	f.added = nil
+}
+
+func (f *fifo) notFound(t []q.Tag) error {
+	if len(t) == 0 {
+		return e.WithReason(e.ErrNotFound(errors.Errorf("queue %s is empty", f.ID())), e.QUEUE_EMPTY)
+	}
+	return e.WithReason(e.ErrNotFound(errors.Errorf("queue %s has no messages tagged %v", f.ID(), t)), e.QUEUE_EMPTY)
 }
 
 func (f *fifo) Pop() (*q.Message, error) {
@@ -80,8 +254,9 @@ func (f *fifo) Pop() (*q.Message, error) {
 	defer f.m.Unlock()
 	m := f.ll.pop()
 	if m == nil {
-		return nil, e.ErrNotFound(errors.Errorf("queue %s is empty", f.ID()))
+		return nil, f.notFound(nil)
 	}
+	f.free()
 	return m, nil
 }
 
@@ -90,7 +265,88 @@ func (f *fifo) Peek() (*q.Message, error) {
 	defer f.m.RUnlock()
 	m := f.ll.peek()
 	if m == nil {
-		return nil, e.ErrNotFound(errors.Errorf("queue %s is empty", f.ID()))
+		return nil, f.notFound(nil)
 	}
 	return m, nil
 }
+
+func (f *fifo) PopMatching(t ...q.Tag) (*q.Message, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	m := f.ll.popMatching(tagged(t))
+	if m == nil {
+		return nil, f.notFound(t)
+	}
+	f.free()
+	return m, nil
+}
+
+func (f *fifo) PeekMatching(t ...q.Tag) (*q.Message, error) {
+	f.m.RLock()
+	defer f.m.RUnlock()
+	m := f.ll.peekMatching(tagged(t))
+	if m == nil {
+		return nil, f.notFound(t)
+	}
+	return m, nil
+}
+
+func (f *fifo) Get(id uuid.UUID) (*q.Message, error) {
+	f.m.RLock()
+	defer f.m.RUnlock()
+	el := f.ll.get(id)
+	if el == nil {
+		return nil, e.WithReason(e.ErrNotFound(errors.Errorf("queue %s has no message %s", f.ID(), id)), e.MESSAGE_NOT_FOUND)
+	}
+	return el.message, nil
+}
+
+func (f *fifo) Delete(id uuid.UUID) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	el := f.ll.get(id)
+	if el == nil {
+		return e.WithReason(e.ErrNotFound(errors.Errorf("queue %s has no message %s", f.ID(), id)), e.MESSAGE_NOT_FOUND)
+	}
+	f.ll.remove(el)
+	f.free()
+	return nil
+}
+
+func (f *fifo) Purge() (int, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	n := f.ll.length
+	f.ll.clear()
+	f.free()
+	return n, nil
+}
+
+// Destroy removes all of the queue's messages. The queue may not be used once
+// destroyed; producers waiting for room are woken and fail to add their
+// messages.
+func (f *fifo) Destroy(force bool) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if !force && f.ll.length > 0 {
+		return q.NotEmpty(f.ID())
+	}
+	f.ll.clear()
+	f.destroyed = true
+	f.free()
+	return nil
+}
+
+func (f *fifo) Walk(offset uint64, fn q.WalkFunc) error {
+	f.m.RLock()
+	defer f.m.RUnlock()
+	f.ll.walk(offset, fn)
+	return nil
+}
+
+// tagged returns a function that matches messages with all of the supplied tags.
+func tagged(t []q.Tag) func(*q.Message) bool {
+	return func(m *q.Message) bool {
+		return m.Tags.ContainsAll(t...)
+	}
+}
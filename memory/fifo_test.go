@@ -3,84 +3,344 @@ package memory
 import (
 	"reflect"
 	"testing"
+	"time"
+
+	"golang.org/x/net/context"
 
 	"github.com/negz/q"
 	"github.com/negz/q/e"
+	"github.com/negz/q/queuetest"
 )
 
-var fifoTests = []struct {
-	messages []*q.Message
-	limit    int
-}{
-	{
-		messages: []*q.Message{
-			q.NewMessage([]byte("salyut"), q.Tagged(q.Tag{"country", "USSR"})),
-			q.NewMessage([]byte("DOS")),
-			q.NewMessage([]byte("kosmos")),
-			q.NewMessage([]byte("skylab")),
-			q.NewMessage([]byte("mir")),
-			q.NewMessage([]byte("iss")),
-			q.NewMessage([]byte("tiangong")),
-		},
-		limit: q.Unbounded,
-	},
-	{
-		messages: []*q.Message{
-			q.NewMessage([]byte("salyut")),
-			q.NewMessage([]byte("DOS")),
-			q.NewMessage([]byte("kosmos")),
-		},
-		limit: 2,
-	},
-	{
-		messages: []*q.Message{},
-		limit:    q.Unbounded,
-	},
+func TestFIFOSuite(t *testing.T) {
+	queuetest.RunQueueSuite(t, func(limit int) q.Queue { return New(Limit(limit)) })
 }
 
-func TestFIFO(t *testing.T) {
-	for _, tt := range fifoTests {
-		queue := New(Limit(tt.limit), Tagged(q.Tag{"function", "space station"}))
-
-		t.Run("Add", func(t *testing.T) {
-			for _, m := range tt.messages {
-				if err := queue.Add(m); err != nil {
-					if len(tt.messages) > tt.limit && e.IsFull(err) {
-						continue
-					}
-					t.Errorf("queue.Add(%v): %v", m, err)
-				}
-			}
-		})
+func TestFIFOWalk(t *testing.T) {
+	messages := []*q.Message{
+		q.NewMessage([]byte("vostok")),
+		q.NewMessage([]byte("voskhod")),
+		q.NewMessage([]byte("soyuz")),
+	}
+
+	queue := New()
+	for _, m := range messages {
+		if err := queue.Add(m); err != nil {
+			t.Fatalf("queue.Add(%v): %v", m, err)
+		}
+	}
+	if _, err := queue.Pop(); err != nil {
+		t.Fatalf("queue.Pop(): %v", err)
+	}
 
-		t.Run("Peek", func(t *testing.T) {
-			m, err := queue.Peek()
+	var offsets []uint64
+	var got []*q.Message
+	walk := func(o uint64, m *q.Message) bool {
+		offsets = append(offsets, o)
+		got = append(got, m)
+		return true
+	}
+	if err := queue.Walk(0, walk); err != nil {
+		t.Fatalf("queue.Walk(0, walk): %v", err)
+	}
+	if !reflect.DeepEqual(messages[1:], got) {
+		t.Errorf("queue.Walk(0, walk): want %v, got %v", messages[1:], got)
+	}
+	if len(offsets) != 2 || offsets[0] >= offsets[1] {
+		t.Fatalf("queue.Walk(0, walk): want two increasing offsets, got %v", offsets)
+	}
+
+	from := offsets[1]
+	got = nil
+	if err := queue.Walk(from, walk); err != nil {
+		t.Fatalf("queue.Walk(%v, walk): %v", from, err)
+	}
+	if !reflect.DeepEqual(messages[2:], got) {
+		t.Errorf("queue.Walk(%v, walk): want %v, got %v", from, messages[2:], got)
+	}
+}
+
+func TestFIFOGetDelete(t *testing.T) {
+	messages := []*q.Message{
+		q.NewMessage([]byte("gemini")),
+		q.NewMessage([]byte("apollo")),
+		q.NewMessage([]byte("skylab")),
+		q.NewMessage([]byte("shuttle")),
+	}
+
+	queue := New(Limit(len(messages)))
+	for _, m := range messages {
+		if err := queue.Add(m); err != nil {
+			t.Fatalf("queue.Add(%v): %v", m, err)
+		}
+	}
+
+	t.Run("Get", func(t *testing.T) {
+		for _, want := range messages {
+			m, err := queue.Get(want.ID)
 			if err != nil {
-				if len(tt.messages) < 1 && e.IsNotFound(err) {
-					return
-				}
-				t.Errorf("queue.Peek(): %v", err)
-				return
+				t.Errorf("queue.Get(%v): %v", want.ID, err)
+				continue
+			}
+			if !reflect.DeepEqual(want, m) {
+				t.Errorf("queue.Get(%v): want %v, got %v", want.ID, want, m)
 			}
-			if !reflect.DeepEqual(tt.messages[0], m) {
-				t.Errorf("queue.Peek(): want %v, got %v", tt.messages[0], m)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		// Delete from the middle, tail, and head of the queue.
+		for _, m := range []*q.Message{messages[1], messages[3], messages[0]} {
+			if err := queue.Delete(m.ID); err != nil {
+				t.Errorf("queue.Delete(%v): %v", m.ID, err)
 			}
-		})
-
-		t.Run("Pop", func(t *testing.T) {
-			for i := range tt.messages {
-				m, err := queue.Pop()
-				if err != nil {
-					if i == len(tt.messages)-1 && e.IsNotFound(err) {
-						continue
-					}
-					t.Errorf("queue.Pop(): %v", err)
-					continue
-				}
-				if !reflect.DeepEqual(tt.messages[i], m) {
-					t.Errorf("queue.Pop(): want %v, got %v", tt.messages[i], m)
-				}
+			if _, err := queue.Get(m.ID); !e.IsNotFound(err) {
+				t.Errorf("queue.Get(%v): want error satisfying e.IsNotFound(), got %v", m.ID, err)
 			}
-		})
+			if err := queue.Delete(m.ID); !e.IsNotFound(err) {
+				t.Errorf("queue.Delete(%v): want error satisfying e.IsNotFound(), got %v", m.ID, err)
+			}
+		}
+	})
+
+	t.Run("AddAfterDelete", func(t *testing.T) {
+		m := q.NewMessage([]byte("orion"))
+		if err := queue.Add(m); err != nil {
+			t.Errorf("queue.Add(%v): %v", m, err)
+		}
+	})
+
+	t.Run("Pop", func(t *testing.T) {
+		first, err := queue.Pop()
+		if err != nil {
+			t.Fatalf("queue.Pop(): %v", err)
+		}
+		if !reflect.DeepEqual(messages[2], first) {
+			t.Errorf("queue.Pop(): want %v, got %v", messages[2], first)
+		}
+		if _, err := queue.Get(first.ID); !e.IsNotFound(err) {
+			t.Errorf("queue.Get(%v): want error satisfying e.IsNotFound(), got %v", first.ID, err)
+		}
+	})
+}
+
+func TestFIFOPurge(t *testing.T) {
+	messages := []*q.Message{
+		q.NewMessage([]byte("sputnik")),
+		q.NewMessage([]byte("explorer")),
+	}
+
+	queue := New(Limit(len(messages)))
+	for _, m := range messages {
+		if err := queue.Add(m); err != nil {
+			t.Fatalf("queue.Add(%v): %v", m, err)
+		}
+	}
+	var last uint64
+	queue.Walk(0, func(o uint64, _ *q.Message) bool {
+		last = o
+		return true
+	})
+
+	n, err := queue.Purge()
+	if err != nil {
+		t.Fatalf("queue.Purge(): %v", err)
+	}
+	if n != len(messages) {
+		t.Errorf("queue.Purge(): want %v purged, got %v", len(messages), n)
+	}
+	if _, err := queue.Peek(); !e.IsNotFound(err) {
+		t.Errorf("queue.Peek(): want error satisfying e.IsNotFound(), got %v", err)
+	}
+	if _, err := queue.Get(messages[0].ID); !e.IsNotFound(err) {
+		t.Errorf("queue.Get(%v): want error satisfying e.IsNotFound(), got %v", messages[0].ID, err)
+	}
+
+	// A purged queue should accept messages up to its limit, and never reuse
+	// offsets.
+	for _, m := range messages {
+		if err := queue.Add(m); err != nil {
+			t.Errorf("queue.Add(%v): %v", m, err)
+		}
+	}
+	queue.Walk(0, func(o uint64, _ *q.Message) bool {
+		if o <= last {
+			t.Errorf("queue.Walk(): offset %v reused after purge", o)
+		}
+		return true
+	})
+}
+
+func TestFIFOConfigure(t *testing.T) {
+	messages := []*q.Message{
+		q.NewMessage([]byte("mercury")),
+		q.NewMessage([]byte("gemini")),
+		q.NewMessage([]byte("apollo")),
+	}
+
+	queue := New(Limit(len(messages)))
+	for _, m := range messages {
+		if err := queue.Add(m); err != nil {
+			t.Fatalf("queue.Add(%v): %v", m, err)
+		}
+	}
+
+	c := queue.Config()
+	c.Limit = 1
+	if err := queue.Configure(c); err != nil {
+		t.Fatalf("queue.Configure(%v): %v", c, err)
+	}
+	if got := queue.Config(); !reflect.DeepEqual(c, got) {
+		t.Errorf("queue.Config(): want %v, got %v", c, got)
+	}
+
+	// Shrinking the limit below the current depth should reject new messages
+	// without dropping existing ones.
+	m := q.NewMessage([]byte("shuttle"))
+	if err := queue.Add(m); !e.IsFull(err) {
+		t.Errorf("queue.Add(%v): want error satisfying e.IsFull(), got %v", m, err)
+	}
+	for _, want := range messages {
+		got, err := queue.Pop()
+		if err != nil {
+			t.Errorf("queue.Pop(): %v", err)
+			continue
+		}
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("queue.Pop(): want %v, got %v", want, got)
+		}
+	}
+	if err := queue.Add(m); err != nil {
+		t.Errorf("queue.Add(%v): %v", m, err)
+	}
+
+	c.Limit = -2
+	if err := queue.Configure(c); !e.IsInvalid(err) {
+		t.Errorf("queue.Configure(%v): want error satisfying e.IsInvalid(), got %v", c, err)
+	}
+
+	// FIFO queues cannot retain consumed messages for replay.
+	c = queue.Config()
+	c.Retention = time.Hour
+	if err := queue.Configure(c); !e.IsInvalid(err) {
+		t.Errorf("queue.Configure(%v): want error satisfying e.IsInvalid(), got %v", c, err)
+	}
+}
+
+func TestFIFOMaxBytes(t *testing.T) {
+	queue := New(MaxBytes(10), MaxMessageBytes(6))
+
+	big := q.NewMessage([]byte("vanguard"))
+	if err := queue.Add(big); !e.IsInvalid(err) {
+		t.Errorf("queue.Add(%v): want error satisfying e.IsInvalid(), got %v", big, err)
+	}
+
+	first := q.NewMessage([]byte("luna"))
+	second := q.NewMessage([]byte("venera"))
+	for _, m := range []*q.Message{first, second} {
+		if err := queue.Add(m); err != nil {
+			t.Fatalf("queue.Add(%v): %v", m, err)
+		}
+	}
+	third := q.NewMessage([]byte("mars"))
+	if err := queue.Add(third); !e.IsFull(err) {
+		t.Errorf("queue.Add(%v): want error satisfying e.IsFull(), got %v", third, err)
+	}
+
+	// Consuming a message should free its bytes.
+	if _, err := queue.Pop(); err != nil {
+		t.Fatalf("queue.Pop(): %v", err)
+	}
+	if err := queue.Add(third); err != nil {
+		t.Errorf("queue.Add(%v): %v", third, err)
+	}
+}
+
+func TestFIFODropOldest(t *testing.T) {
+	queue := New(Limit(2), MaxMessageBytes(6), Overflow(q.DropOldest))
+	evicted := []*q.Message{}
+	queue.OnEvict(func(m *q.Message) { evicted = append(evicted, m) })
+
+	messages := []*q.Message{
+		q.NewMessage([]byte("luna")),
+		q.NewMessage([]byte("venera")),
+		q.NewMessage([]byte("mars")),
+	}
+	for _, m := range messages {
+		if err := queue.Add(m); err != nil {
+			t.Fatalf("queue.Add(%v): %v", m, err)
+		}
+	}
+	if want := messages[:1]; !reflect.DeepEqual(want, evicted) {
+		t.Errorf("queue.OnEvict(): want %v evicted, got %v", want, evicted)
+	}
+	m, err := queue.Peek()
+	if err != nil {
+		t.Fatalf("queue.Peek(): %v", err)
+	}
+	if m != messages[1] {
+		t.Errorf("queue.Peek(): want %v, got %v", messages[1], m)
+	}
+
+	// Messages that could never fit should not evict anything.
+	big := q.NewMessage([]byte("vanguard"))
+	if err := queue.Add(big); !e.IsInvalid(err) {
+		t.Errorf("queue.Add(%v): want error satisfying e.IsInvalid(), got %v", big, err)
+	}
+	if len(evicted) != 1 {
+		t.Errorf("queue.Add(%v): want 1 message evicted, got %v", big, len(evicted))
+	}
+}
+
+func TestFIFOBlock(t *testing.T) {
+	queue := New(Limit(1), Overflow(q.Block))
+	first := q.NewMessage([]byte("luna"))
+	if err := queue.Add(first); err != nil {
+		t.Fatalf("queue.Add(%v): %v", first, err)
+	}
+
+	second := q.NewMessage([]byte("venera"))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := queue.AddContext(ctx, second); !e.IsFull(err) {
+		t.Errorf("queue.AddContext(%v): want error satisfying e.IsFull(), got %v", second, err)
+	}
+
+	added := make(chan error)
+	go func() { added <- queue.AddContext(context.Background(), second) }()
+	if _, err := queue.Pop(); err != nil {
+		t.Fatalf("queue.Pop(): %v", err)
+	}
+	if err := <-added; err != nil {
+		t.Fatalf("queue.AddContext(%v): %v", second, err)
+	}
+	m, err := queue.Peek()
+	if err != nil {
+		t.Fatalf("queue.Peek(): %v", err)
+	}
+	if m != second {
+		t.Errorf("queue.Peek(): want %v, got %v", second, m)
+	}
+}
+
+func TestFIFODestroy(t *testing.T) {
+	queue := New(Limit(1), Overflow(q.Block))
+	first := q.NewMessage([]byte("luna"))
+	if err := queue.Add(first); err != nil {
+		t.Fatalf("queue.Add(%v): %v", first, err)
+	}
+
+	if err := q.Destroy(queue, false); !e.IsFailedPrecondition(err) {
+		t.Errorf("q.Destroy(queue, false): want error satisfying e.IsFailedPrecondition(), got %v", err)
+	}
+
+	second := q.NewMessage([]byte("venera"))
+	added := make(chan error)
+	go func() { added <- queue.AddContext(context.Background(), second) }()
+	if err := q.Destroy(queue, true); err != nil {
+		t.Fatalf("q.Destroy(queue, true): %v", err)
+	}
+	if err := <-added; !e.IsNotFound(err) {
+		t.Errorf("queue.AddContext(%v): want error satisfying e.IsNotFound(), got %v", second, err)
 	}
 }
@@ -1,32 +1,51 @@
 package memory
 
-import "github.com/negz/q"
+import (
+	"github.com/google/uuid"
+
+	"github.com/negz/q"
+)
 
 type element struct {
 	message *q.Message
+	offset  uint64
+	prev    *element
 	next    *element
 }
 
 /* linkedList exists mostly to demonstrate that I can implement a linked list.
    In practice I might use https://golang.org/src/container/list/list.go to
-   avoid reinventing wheels. That said, a FIFO q does not require a doubly
-   linked list, and this implementation has the advantage of storing a concrete
-   type (*q.Message) rather than the empty interface.
+   avoid reinventing wheels. That said, this implementation has the advantage of
+   storing a concrete type (*q.Message) rather than the empty interface, and of
+   indexing its elements by message ID so that any message may be found and
+   removed in constant time.
 */
 type linkedList struct {
 	head   *element
 	tail   *element
 	length int
+	bytes  int    // The total size of the payloads of all elements.
+	offset uint64 // The offset of the most recently added element.
+	index  map[uuid.UUID]*element
 }
 
 func (l *linkedList) add(m *q.Message) {
-	e := &element{message: m}
+	l.offset++
+	l.bytes += len(m.Payload)
+	e := &element{message: m, offset: l.offset}
+	if m.Metadata != nil { // Messages without metadata cannot be indexed.
+		if l.index == nil {
+			l.index = make(map[uuid.UUID]*element)
+		}
+		l.index[m.ID] = e
+	}
 	if l.head == nil { // This list is empty.
 		l.head = e
 		l.tail = e
 		l.length = 1
 		return
 	}
+	e.prev = l.tail
 	l.tail.next = e
 	l.tail = e
 	l.length++
@@ -37,15 +56,9 @@ func (l *linkedList) pop() *q.Message {
 	if l.head == nil { // This list is empty.
 		return nil
 	}
-	m := l.head.message
-	l.length--
-	if l.head.next == nil { // This list has a single element.
-		l.head = nil
-		l.tail = nil
-		return m
-	}
-	l.head = l.head.next
-	return m
+	e := l.head
+	l.remove(e)
+	return e.message
 }
 
 func (l *linkedList) peek() *q.Message {
@@ -54,3 +67,79 @@ func (l *linkedList) peek() *q.Message {
 	}
 	return l.head.message
 }
+
+// find returns the first element whose message satisfies match, or nil if no
+// message matches.
+func (l *linkedList) find(match func(*q.Message) bool) *element {
+	for e := l.head; e != nil; e = e.next {
+		if match(e.message) {
+			return e
+		}
+	}
+	return nil
+}
+
+// get returns the element whose message has the supplied ID, or nil if no
+// such message exists.
+func (l *linkedList) get(id uuid.UUID) *element {
+	return l.index[id]
+}
+
+// remove unlinks e from the list.
+func (l *linkedList) remove(e *element) {
+	l.length--
+	l.bytes -= len(e.message.Payload)
+	if e.message.Metadata != nil && l.index[e.message.ID] == e {
+		delete(l.index, e.message.ID)
+	}
+	if e.prev == nil { // We're removing the head of the list.
+		l.head = e.next
+	} else {
+		e.prev.next = e.next
+	}
+	if e.next == nil { // We're removing the tail of the list.
+		l.tail = e.prev
+	} else {
+		e.next.prev = e.prev
+	}
+	e.prev, e.next = nil, nil
+}
+
+func (l *linkedList) popMatching(match func(*q.Message) bool) *q.Message {
+	e := l.find(match)
+	if e == nil {
+		return nil
+	}
+	l.remove(e)
+	return e.message
+}
+
+func (l *linkedList) peekMatching(match func(*q.Message) bool) *q.Message {
+	e := l.find(match)
+	if e == nil {
+		return nil
+	}
+	return e.message
+}
+
+// clear removes all elements from the list. Offsets are not reset.
+func (l *linkedList) clear() {
+	l.head = nil
+	l.tail = nil
+	l.length = 0
+	l.bytes = 0
+	l.index = nil
+}
+
+// walk calls fn for each element with an offset of at least the supplied
+// offset, until fn returns false.
+func (l *linkedList) walk(offset uint64, fn func(uint64, *q.Message) bool) {
+	for e := l.head; e != nil; e = e.next {
+		if e.offset < offset {
+			continue
+		}
+		if !fn(e.offset, e.message) {
+			return
+		}
+	}
+}
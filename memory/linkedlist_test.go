@@ -90,6 +90,49 @@ func TestLinkedList(t *testing.T) {
 	})
 }
 
+func payload(b []byte) func(*q.Message) bool {
+	return func(m *q.Message) bool { return reflect.DeepEqual(m.Payload, b) }
+}
+
+// TestPopMatching removes elements from the tail, middle, and head of a list.
+func TestPopMatching(t *testing.T) {
+	for _, tt := range linkedListTests {
+		ll := fromSlice(tt.list)
+		remaining := tt.list
+		for _, i := range []int{len(tt.list) - 1, len(tt.list) / 2, 0} {
+			if i < 0 || i >= len(remaining) {
+				continue
+			}
+			b := remaining[i]
+			if got := ll.peekMatching(payload(b)); got == nil || !reflect.DeepEqual(got.Payload, b) {
+				t.Errorf("ll.peekMatching(%v): want %v, got %v", b, b, got)
+			}
+			if got := ll.popMatching(payload(b)); got == nil || !reflect.DeepEqual(got.Payload, b) {
+				t.Errorf("ll.popMatching(%v): want %v, got %v", b, b, got)
+			}
+			remaining = append(append([][]byte{}, remaining[:i]...), remaining[i+1:]...)
+			if got := toSlice(ll); !reflect.DeepEqual(remaining, got) {
+				t.Errorf("ll.popMatching(%v): want remaining %v, got %v", b, remaining, got)
+			}
+			if ll.length != len(remaining) {
+				t.Errorf("ll.length: want %v, got %v", len(remaining), ll.length)
+			}
+		}
+
+		// Ensure the tail is still valid after removing elements.
+		b := []byte("sputnik")
+		ll.add(q.NewMessage(b))
+		remaining = append(remaining, b)
+		if got := toSlice(ll); !reflect.DeepEqual(remaining, got) {
+			t.Errorf("ll.add(%v): want %v, got %v", b, remaining, got)
+		}
+
+		if got := ll.popMatching(payload([]byte("vostok"))); got != nil {
+			t.Errorf("ll.popMatching(%v): want nil, got %v", []byte("vostok"), got)
+		}
+	}
+}
+
 // TestAddPopAddPop tests for a bug where new messages added to a linked list
 // cannot be consumed if the queue has previously been populated and consumed.
 func TestAddPopAddPop(t *testing.T) {
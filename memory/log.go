@@ -0,0 +1,323 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+)
+
+type log struct {
+	*fifo
+	groups map[string]uint64 // Committed offsets, keyed by group name.
+	lag    []func(string, uint64)
+}
+
+// NewLog returns a new append-only log backed by an in-memory linked list.
+// Messages may not be popped from a log; they are read by consumer groups.
+func NewLog(o ...Option) q.Log {
+	return &log{fifo: newFIFO(o...), groups: make(map[string]uint64)}
+}
+
+func (l *log) Store() q.Store {
+	return q.MemoryLog
+}
+
+func (l *log) Configure(c q.Config) error {
+	if err := l.fifo.configure(c); err != nil {
+		return err
+	}
+	l.m.Lock()
+	evicted := l.discard(time.Now())
+	groups := l.list()
+	l.m.Unlock()
+	l.notify(evicted, groups)
+	return nil
+}
+
+func (l *log) OnLag(fn func(group string, lag uint64)) {
+	l.m.Lock()
+	defer l.m.Unlock()
+	l.lag = append(l.lag, fn)
+}
+
+func (l *log) Add(m *q.Message) error {
+	return l.AddContext(context.Background(), m)
+}
+
+// AddContext discards any messages that have been consumed or have expired
+// before adding the supplied message, in order to make room for it.
+func (l *log) AddContext(ctx context.Context, m *q.Message) error {
+	l.m.Lock()
+	evicted := l.discard(time.Now())
+	l.m.Unlock()
+	l.notify(evicted, nil)
+
+	if err := l.fifo.AddContext(ctx, m); err != nil {
+		return err
+	}
+	l.changed()
+	return nil
+}
+
+func (l *log) Pop() (*q.Message, error) {
+	return nil, e.ErrInvalid(errors.Errorf("cannot pop from log %s; read it via a consumer group", l.ID()))
+}
+
+func (l *log) PopMatching(t ...q.Tag) (*q.Message, error) {
+	return nil, e.ErrInvalid(errors.Errorf("cannot pop from log %s; read it via a consumer group", l.ID()))
+}
+
+func (l *log) Delete(id uuid.UUID) error {
+	if err := l.fifo.Delete(id); err != nil {
+		return err
+	}
+	l.changed()
+	return nil
+}
+
+func (l *log) Purge() (int, error) {
+	n, err := l.fifo.Purge()
+	if err != nil {
+		return n, err
+	}
+	l.changed()
+	return n, nil
+}
+
+func (l *log) AddGroup(name string) (q.Group, error) {
+	if name == "" {
+		return q.Group{}, e.ErrInvalid(errors.New("consumer groups must have a name"))
+	}
+	l.m.Lock()
+	if _, ok := l.groups[name]; ok {
+		l.m.Unlock()
+		return q.Group{}, e.ErrAlreadyExists(errors.Errorf("log %s already has consumer group %s", l.ID(), name))
+	}
+	l.groups[name] = l.first()
+	g := l.group(name)
+	l.m.Unlock()
+	l.notify(nil, []q.Group{g})
+	return g, nil
+}
+
+func (l *log) GetGroup(name string) (q.Group, error) {
+	l.m.RLock()
+	defer l.m.RUnlock()
+	if _, ok := l.groups[name]; !ok {
+		return q.Group{}, l.notFound(name)
+	}
+	return l.group(name), nil
+}
+
+// DeleteGroup discards any messages that were retained only because the
+// deleted group had not yet consumed them.
+func (l *log) DeleteGroup(name string) error {
+	l.m.Lock()
+	if _, ok := l.groups[name]; !ok {
+		l.m.Unlock()
+		return l.notFound(name)
+	}
+	delete(l.groups, name)
+	evicted := l.discard(time.Now())
+	groups := l.list()
+	l.m.Unlock()
+	l.notify(evicted, groups)
+	return nil
+}
+
+func (l *log) Groups() []q.Group {
+	l.m.RLock()
+	defer l.m.RUnlock()
+	return l.list()
+}
+
+func (l *log) Read(group string, max int) ([]*q.Message, uint64, error) {
+	l.m.Lock()
+	evicted := l.discard(time.Now())
+	offset, ok := l.groups[group]
+	if !ok {
+		l.m.Unlock()
+		l.notify(evicted, nil)
+		return nil, 0, l.notFound(group)
+	}
+	msgs := []*q.Message{}
+	next := offset
+	l.ll.walk(offset, func(o uint64, m *q.Message) bool {
+		if max != q.Unbounded && len(msgs) >= max {
+			return false
+		}
+		msgs = append(msgs, m)
+		next = o + 1
+		return true
+	})
+	l.m.Unlock()
+	l.notify(evicted, nil)
+	return msgs, next, nil
+}
+
+func (l *log) Commit(group string, offset uint64) (q.Group, error) {
+	l.m.Lock()
+	current, ok := l.groups[group]
+	if !ok {
+		l.m.Unlock()
+		return q.Group{}, l.notFound(group)
+	}
+	if offset < current {
+		l.m.Unlock()
+		return q.Group{}, e.ErrInvalid(errors.Errorf("cannot move consumer group %s back from offset %d to %d", group, current, offset))
+	}
+	if end := l.end(); offset > end {
+		l.m.Unlock()
+		return q.Group{}, e.ErrInvalid(errors.Errorf("cannot commit offset %d beyond the end of log %s at offset %d", offset, l.ID(), end))
+	}
+	l.groups[group] = offset
+	evicted := l.discard(time.Now())
+	g := l.group(group)
+	groups := l.list()
+	l.m.Unlock()
+	l.notify(evicted, groups)
+	return g, nil
+}
+
+func (l *log) Seek(group string, offset uint64) (q.Group, error) {
+	l.m.Lock()
+	if _, ok := l.groups[group]; !ok {
+		l.m.Unlock()
+		return q.Group{}, l.notFound(group)
+	}
+	if end := l.end(); offset > end {
+		l.m.Unlock()
+		return q.Group{}, e.ErrInvalid(errors.Errorf("cannot seek beyond the end of log %s at offset %d", l.ID(), end))
+	}
+	if first := l.first(); offset < first {
+		offset = first
+	}
+	l.groups[group] = offset
+	g := l.group(group)
+	l.m.Unlock()
+	l.notify(nil, []q.Group{g})
+	return g, nil
+}
+
+func (l *log) SeekTime(group string, t time.Time) (q.Group, error) {
+	l.m.RLock()
+	offset := l.end()
+	l.ll.walk(0, func(o uint64, m *q.Message) bool {
+		if m.Created.Before(t) {
+			return true
+		}
+		offset = o
+		return false
+	})
+	l.m.RUnlock()
+	return l.Seek(group, offset)
+}
+
+// changed notifies the log's lag functions of the lag of every group after
+// messages have been added or removed.
+func (l *log) changed() {
+	l.m.RLock()
+	groups := l.list()
+	l.m.RUnlock()
+	l.notify(nil, groups)
+}
+
+// notify calls the log's eviction functions with each of the supplied evicted
+// messages, then its lag functions with the lag of each of the supplied groups.
+// It must be called without the lock held.
+func (l *log) notify(evicted []*q.Message, groups []q.Group) {
+	if len(evicted) == 0 && len(groups) == 0 {
+		return
+	}
+	l.m.RLock()
+	evict, lag := l.evict, l.lag
+	l.m.RUnlock()
+	for _, m := range evicted {
+		for _, fn := range evict {
+			fn(m)
+		}
+	}
+	for _, g := range groups {
+		for _, fn := range lag {
+			fn(g.Name, g.Lag)
+		}
+	}
+}
+
+// discard removes messages from the head of the log that are older than the
+// log's retention or, if the log has no retention, that every group has
+// consumed. It returns the removed messages. Consumed messages are retained if
+// the log has no groups. It must be called with the write lock held.
+func (l *log) discard(now time.Time) []*q.Message {
+	var min uint64
+	consumed := false
+	for _, offset := range l.groups {
+		if !consumed || offset < min {
+			min, consumed = offset, true
+		}
+	}
+	retention := l.config.Retention
+	evicted := []*q.Message{}
+	for el := l.ll.head; el != nil; el = l.ll.head {
+		expired := retention > 0 && now.Sub(el.message.Created) > retention
+		if !expired && !(retention == 0 && consumed && el.offset < min) {
+			break
+		}
+		evicted = append(evicted, l.ll.pop())
+	}
+	if len(evicted) > 0 {
+		l.free()
+	}
+	return evicted
+}
+
+// end returns the offset the next message added to the log will have. It must
+// be called with the lock held.
+func (l *log) end() uint64 {
+	return l.ll.offset + 1
+}
+
+// first returns the offset of the oldest message retained by the log, or the
+// end of the log if it is empty. It must be called with the lock held.
+func (l *log) first() uint64 {
+	if l.ll.head == nil {
+		return l.end()
+	}
+	return l.ll.head.offset
+}
+
+// group returns the named group, which must exist. It must be called with the
+// lock held.
+func (l *log) group(name string) q.Group {
+	offset := l.groups[name]
+	from := offset
+	if first := l.first(); from < first {
+		from = first
+	}
+	return q.Group{Name: name, Offset: offset, Lag: l.end() - from}
+}
+
+// list returns all groups, ordered by name. It must be called with the lock
+// held.
+func (l *log) list() []q.Group {
+	names := make([]string, 0, len(l.groups))
+	for name := range l.groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	groups := make([]q.Group, 0, len(names))
+	for _, name := range names {
+		groups = append(groups, l.group(name))
+	}
+	return groups
+}
+
+func (l *log) notFound(group string) error {
+	return e.WithReason(e.ErrNotFound(errors.Errorf("log %s has no consumer group %s", l.ID(), group)), e.GROUP_NOT_FOUND)
+}
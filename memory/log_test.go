@@ -0,0 +1,188 @@
+package memory
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+)
+
+func payloads(msgs []*q.Message) []string {
+	p := make([]string, 0, len(msgs))
+	for _, m := range msgs {
+		p = append(p, string(m.Payload))
+	}
+	return p
+}
+
+func TestLogGroups(t *testing.T) {
+	l := NewLog()
+	evicted := []string{}
+	l.OnEvict(func(m *q.Message) { evicted = append(evicted, string(m.Payload)) })
+	lags := map[string]uint64{}
+	l.OnLag(func(group string, lag uint64) { lags[group] = lag })
+
+	for _, p := range []string{"voyager 1", "voyager 2", "pioneer 10"} {
+		if err := l.Add(q.NewMessage([]byte(p))); err != nil {
+			t.Fatalf("l.Add(%v): %v", p, err)
+		}
+	}
+	for _, name := range []string{"nasa", "jpl"} {
+		if _, err := l.AddGroup(name); err != nil {
+			t.Fatalf("l.AddGroup(%v): %v", name, err)
+		}
+	}
+	if _, err := l.AddGroup("nasa"); !e.IsAlreadyExists(err) {
+		t.Errorf("l.AddGroup(nasa): want error satisfying e.IsAlreadyExists(), got %v", err)
+	}
+	if _, err := l.Pop(); !e.IsInvalid(err) {
+		t.Errorf("l.Pop(): want error satisfying e.IsInvalid(), got %v", err)
+	}
+
+	msgs, next, err := l.Read("nasa", 2)
+	if err != nil {
+		t.Fatalf("l.Read(nasa, 2): %v", err)
+	}
+	if want, got := []string{"voyager 1", "voyager 2"}, payloads(msgs); !reflect.DeepEqual(want, got) {
+		t.Errorf("l.Read(nasa, 2): want %v, got %v", want, got)
+	}
+	g, err := l.Commit("nasa", next)
+	if err != nil {
+		t.Fatalf("l.Commit(nasa, %v): %v", next, err)
+	}
+	if g.Lag != 1 || lags["nasa"] != 1 {
+		t.Errorf("l.Commit(nasa, %v): want lag 1, got %v (reported %v)", next, g.Lag, lags["nasa"])
+	}
+	if len(evicted) != 0 {
+		t.Errorf("l.Commit(nasa, %v): want no messages discarded while jpl has not read them, got %v", next, evicted)
+	}
+	if _, err := l.Commit("nasa", next-1); !e.IsInvalid(err) {
+		t.Errorf("l.Commit(nasa, %v): want error satisfying e.IsInvalid(), got %v", next-1, err)
+	}
+
+	msgs, next, err = l.Read("jpl", q.Unbounded)
+	if err != nil {
+		t.Fatalf("l.Read(jpl): %v", err)
+	}
+	if want, got := []string{"voyager 1", "voyager 2", "pioneer 10"}, payloads(msgs); !reflect.DeepEqual(want, got) {
+		t.Errorf("l.Read(jpl): want %v, got %v", want, got)
+	}
+	if _, err := l.Commit("jpl", next+1); !e.IsInvalid(err) {
+		t.Errorf("l.Commit(jpl, %v): want error satisfying e.IsInvalid(), got %v", next+1, err)
+	}
+	if _, err := l.Commit("jpl", next); err != nil {
+		t.Fatalf("l.Commit(jpl, %v): %v", next, err)
+	}
+	if want := []string{"voyager 1", "voyager 2"}; !reflect.DeepEqual(want, evicted) {
+		t.Errorf("l.Commit(jpl, %v): want %v discarded, got %v", next, want, evicted)
+	}
+
+	if err := l.DeleteGroup("nasa"); err != nil {
+		t.Fatalf("l.DeleteGroup(nasa): %v", err)
+	}
+	if want := []string{"voyager 1", "voyager 2", "pioneer 10"}; !reflect.DeepEqual(want, evicted) {
+		t.Errorf("l.DeleteGroup(nasa): want %v discarded, got %v", want, evicted)
+	}
+	if _, err := l.GetGroup("nasa"); !e.IsNotFound(err) {
+		t.Errorf("l.GetGroup(nasa): want error satisfying e.IsNotFound(), got %v", err)
+	}
+	if want, got := []q.Group{{Name: "jpl", Offset: next, Lag: 0}}, l.Groups(); !reflect.DeepEqual(want, got) {
+		t.Errorf("l.Groups(): want %v, got %v", want, got)
+	}
+}
+
+func TestLogRetention(t *testing.T) {
+	l := NewLog(Retention(time.Hour))
+	old := q.NewMessage([]byte("sputnik"))
+	old.Created = time.Now().Add(-2 * time.Hour)
+	for _, m := range []*q.Message{old, q.NewMessage([]byte("explorer"))} {
+		if err := l.Add(m); err != nil {
+			t.Fatalf("l.Add(%s): %v", m.Payload, err)
+		}
+	}
+	if _, err := l.AddGroup("nasa"); err != nil {
+		t.Fatalf("l.AddGroup(nasa): %v", err)
+	}
+	msgs, _, err := l.Read("nasa", q.Unbounded)
+	if err != nil {
+		t.Fatalf("l.Read(nasa): %v", err)
+	}
+	if want, got := []string{"explorer"}, payloads(msgs); !reflect.DeepEqual(want, got) {
+		t.Errorf("l.Read(nasa): want %v, got %v", want, got)
+	}
+}
+
+func TestLogBlock(t *testing.T) {
+	l := NewLog(Limit(1), Overflow(q.Block))
+	if err := l.Add(q.NewMessage([]byte("gemini 3"))); err != nil {
+		t.Fatalf("l.Add(): %v", err)
+	}
+	if _, err := l.AddGroup("nasa"); err != nil {
+		t.Fatalf("l.AddGroup(nasa): %v", err)
+	}
+	added := make(chan error)
+	go func() { added <- l.Add(q.NewMessage([]byte("gemini 4"))) }()
+
+	_, next, err := l.Read("nasa", 1)
+	if err != nil {
+		t.Fatalf("l.Read(nasa, 1): %v", err)
+	}
+	if _, err := l.Commit("nasa", next); err != nil {
+		t.Fatalf("l.Commit(nasa, %v): %v", next, err)
+	}
+	select {
+	case err := <-added:
+		if err != nil {
+			t.Errorf("l.Add(): %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("l.Add(): still blocked after consumer group committed its offset")
+	}
+}
+
+func TestLogSeek(t *testing.T) {
+	l := NewLog(Retention(time.Hour))
+	old := q.NewMessage([]byte("luna 1"))
+	old.Created = time.Now().Add(-30 * time.Minute)
+	for _, m := range []*q.Message{old, q.NewMessage([]byte("luna 2")), q.NewMessage([]byte("luna 3"))} {
+		if err := l.Add(m); err != nil {
+			t.Fatalf("l.Add(%s): %v", m.Payload, err)
+		}
+	}
+	if _, err := l.AddGroup("okb-1"); err != nil {
+		t.Fatalf("l.AddGroup(okb-1): %v", err)
+	}
+	_, end, err := l.Read("okb-1", q.Unbounded)
+	if err != nil {
+		t.Fatalf("l.Read(okb-1): %v", err)
+	}
+	if _, err := l.Commit("okb-1", end); err != nil {
+		t.Fatalf("l.Commit(okb-1, %v): %v", end, err)
+	}
+
+	g, err := l.Seek("okb-1", 0)
+	if err != nil {
+		t.Fatalf("l.Seek(okb-1, 0): %v", err)
+	}
+	if g.Lag != 3 {
+		t.Errorf("l.Seek(okb-1, 0): want lag 3 after seeking to retained consumed messages, got %v", g.Lag)
+	}
+
+	since := time.Now().Add(-10 * time.Minute)
+	if _, err := l.SeekTime("okb-1", since); err != nil {
+		t.Fatalf("l.SeekTime(okb-1, %v): %v", since, err)
+	}
+	msgs, _, err := l.Read("okb-1", q.Unbounded)
+	if err != nil {
+		t.Fatalf("l.Read(okb-1): %v", err)
+	}
+	if want, got := []string{"luna 2", "luna 3"}, payloads(msgs); !reflect.DeepEqual(want, got) {
+		t.Errorf("l.Read(okb-1): want %v, got %v", want, got)
+	}
+
+	if _, err := l.Seek("okb-1", end+1); !e.IsInvalid(err) {
+		t.Errorf("l.Seek(okb-1, %v): want error satisfying e.IsInvalid(), got %v", end+1, err)
+	}
+}
@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+)
+
+// A logQueue is a metrics queue that wraps a log.
+type logQueue struct {
+	*queue
+	wl q.Log
+}
+
+// failed counts the supplied error.
+func (l *logQueue) failed(err error) {
+	t := q.UnknownError
+	if e.IsNotFound(err) {
+		t = q.NotFound
+	}
+	l.m.Error(l.ID(), t)
+}
+
+func (l *logQueue) AddGroup(name string) (q.Group, error) {
+	g, err := l.wl.AddGroup(name)
+	if err != nil {
+		l.failed(err)
+	}
+	return g, err
+}
+
+func (l *logQueue) GetGroup(name string) (q.Group, error) {
+	g, err := l.wl.GetGroup(name)
+	if err != nil {
+		l.failed(err)
+	}
+	return g, err
+}
+
+func (l *logQueue) DeleteGroup(name string) error {
+	err := l.wl.DeleteGroup(name)
+	if err != nil {
+		l.failed(err)
+	}
+	return err
+}
+
+func (l *logQueue) Groups() []q.Group {
+	return l.wl.Groups()
+}
+
+// Read counts each message read by a consumer group as consumed.
+func (l *logQueue) Read(group string, max int) ([]*q.Message, uint64, error) {
+	msgs, next, err := l.wl.Read(group, max)
+	if err != nil {
+		l.failed(err)
+		return nil, 0, err
+	}
+	for range msgs {
+		l.m.Consumed(l.ID())
+	}
+	return msgs, next, nil
+}
+
+func (l *logQueue) Commit(group string, offset uint64) (q.Group, error) {
+	g, err := l.wl.Commit(group, offset)
+	if err != nil {
+		l.failed(err)
+	}
+	return g, err
+}
+
+func (l *logQueue) Seek(group string, offset uint64) (q.Group, error) {
+	g, err := l.wl.Seek(group, offset)
+	if err != nil {
+		l.failed(err)
+	}
+	return g, err
+}
+
+func (l *logQueue) SeekTime(group string, t time.Time) (q.Group, error) {
+	g, err := l.wl.SeekTime(group, t)
+	if err != nil {
+		l.failed(err)
+	}
+	return g, err
+}
+
+func (l *logQueue) OnLag(fn func(group string, lag uint64)) {
+	l.wl.OnLag(fn)
+}
@@ -11,6 +11,12 @@ type nopMetrics struct{}
 // NewNop returns a metrics implementation that does nothing.
 func NewNop() q.Metrics { return &nopMetrics{} }
 
-func (m *nopMetrics) Enqueued(id uuid.UUID)         {}
-func (m *nopMetrics) Consumed(id uuid.UUID)         {}
-func (m *nopMetrics) Error(id uuid.UUID, t q.Error) {}
+func (m *nopMetrics) Enqueued(id uuid.UUID)                            {}
+func (m *nopMetrics) Consumed(id uuid.UUID)                            {}
+func (m *nopMetrics) Purged(id uuid.UUID, n int)                       {}
+func (m *nopMetrics) Evicted(id uuid.UUID)                             {}
+func (m *nopMetrics) Expired(id uuid.UUID)                             {}
+func (m *nopMetrics) Error(id uuid.UUID, t q.Error)                    {}
+func (m *nopMetrics) Usage(tenant string, queues, messages, bytes int) {}
+func (m *nopMetrics) GlobalUsage(queues, messages, bytes int)          {}
+func (m *nopMetrics) Lag(id uuid.UUID, group string, lag uint64)       {}
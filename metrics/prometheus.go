@@ -13,7 +13,13 @@ import (
 type prom struct {
 	enqueued *prometheus.CounterVec
 	consumed *prometheus.CounterVec
+	purged   *prometheus.CounterVec
+	evicted  *prometheus.CounterVec
+	expired  prometheus.Counter
 	errors   *prometheus.CounterVec
+	usage    *prometheus.GaugeVec
+	global   *prometheus.GaugeVec
+	lag      *prometheus.GaugeVec
 }
 
 // NewPrometheus returns a new implementation of Metrics that exposes metrics to
@@ -35,6 +41,27 @@ func NewPrometheus() (q.Metrics, prometheus.Gatherer) {
 		},
 		[]string{"queue"},
 	)
+	purged := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "queue_messages_purged_total",
+			Help: "Number of messages removed by purging queues.",
+		},
+		[]string{"queue"},
+	)
+	evicted := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "queue_messages_evicted_total",
+			Help: "Number of messages evicted to make room for new messages.",
+		},
+		[]string{"queue"},
+	)
+	// Expired queues are not labelled by ID; each queue expires only once.
+	expired := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "queues_expired_total",
+			Help: "Number of queues deleted for being idle.",
+		},
+	)
 	errors := prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "queue_errors_total",
@@ -43,13 +70,41 @@ func NewPrometheus() (q.Metrics, prometheus.Gatherer) {
 		[]string{"queue", "type"},
 	)
 
+	usage := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "queue_budget_usage",
+			Help: "Number of queues, messages, and bytes used by each tenant.",
+		},
+		[]string{"tenant", "resource"},
+	)
+	global := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "queue_budget_global_usage",
+			Help: "Number of queues, messages, and bytes used by all tenants.",
+		},
+		[]string{"resource"},
+	)
+	lag := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "queue_group_lag",
+			Help: "Number of offsets between each consumer group's committed offset and the end of its log.",
+		},
+		[]string{"queue", "group"},
+	)
+
 	r.MustRegister(prometheus.NewGoCollector())
 	r.MustRegister(prometheus.NewProcessCollector(os.Getpid(), ""))
 	r.MustRegister(enqueued)
 	r.MustRegister(consumed)
+	r.MustRegister(purged)
+	r.MustRegister(evicted)
+	r.MustRegister(expired)
 	r.MustRegister(errors)
+	r.MustRegister(usage)
+	r.MustRegister(global)
+	r.MustRegister(lag)
 
-	return &prom{enqueued, consumed, errors}, r
+	return &prom{enqueued, consumed, purged, evicted, expired, errors, usage, global, lag}, r
 }
 
 func (m *prom) Enqueued(id uuid.UUID) {
@@ -60,6 +115,18 @@ func (m *prom) Consumed(id uuid.UUID) {
 	m.consumed.With(prometheus.Labels{"queue": fmt.Sprint(id)}).Inc()
 }
 
+func (m *prom) Purged(id uuid.UUID, n int) {
+	m.purged.With(prometheus.Labels{"queue": fmt.Sprint(id)}).Add(float64(n))
+}
+
+func (m *prom) Evicted(id uuid.UUID) {
+	m.evicted.With(prometheus.Labels{"queue": fmt.Sprint(id)}).Inc()
+}
+
+func (m *prom) Expired(id uuid.UUID) {
+	m.expired.Inc()
+}
+
 func (m *prom) Error(id uuid.UUID, t q.Error) {
 	labels := prometheus.Labels{
 		"queue": fmt.Sprint(id),
@@ -67,3 +134,19 @@ func (m *prom) Error(id uuid.UUID, t q.Error) {
 	}
 	m.errors.With(labels).Inc()
 }
+
+func (m *prom) Usage(tenant string, queues, messages, bytes int) {
+	m.usage.With(prometheus.Labels{"tenant": tenant, "resource": "queues"}).Set(float64(queues))
+	m.usage.With(prometheus.Labels{"tenant": tenant, "resource": "messages"}).Set(float64(messages))
+	m.usage.With(prometheus.Labels{"tenant": tenant, "resource": "bytes"}).Set(float64(bytes))
+}
+
+func (m *prom) GlobalUsage(queues, messages, bytes int) {
+	m.global.With(prometheus.Labels{"resource": "queues"}).Set(float64(queues))
+	m.global.With(prometheus.Labels{"resource": "messages"}).Set(float64(messages))
+	m.global.With(prometheus.Labels{"resource": "bytes"}).Set(float64(bytes))
+}
+
+func (m *prom) Lag(id uuid.UUID, group string, lag uint64) {
+	m.lag.With(prometheus.Labels{"queue": fmt.Sprint(id), "group": group}).Set(float64(lag))
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"golang.org/x/net/context"
 
 	"github.com/negz/q"
 	"github.com/negz/q/e"
@@ -16,9 +17,19 @@ type queue struct {
 	m q.Metrics
 }
 
-// Queue wraps a queue with the supplied metrics.
+// Queue wraps a queue with the supplied metrics. The returned queue is a q.Log
+// or a q.Seeker if the wrapped queue is.
 func Queue(wrap q.Queue, m q.Metrics) q.Queue {
-	return &queue{w: wrap, m: m}
+	wrap.OnEvict(func(*q.Message) { m.Evicted(wrap.ID()) })
+	mq := &queue{w: wrap, m: m}
+	if wl, ok := wrap.(q.Log); ok {
+		wl.OnLag(func(group string, lag uint64) { m.Lag(wrap.ID(), group, lag) })
+		return &logQueue{queue: mq, wl: wl}
+	}
+	if ws, ok := wrap.(q.Seeker); ok {
+		return &seekerQueue{queue: mq, ws: ws}
+	}
+	return mq
 }
 
 func (l *queue) ID() uuid.UUID {
@@ -37,8 +48,24 @@ func (l *queue) Tags() *q.Tags {
 	return l.w.Tags()
 }
 
+func (l *queue) Config() q.Config {
+	return l.w.Config()
+}
+
+func (l *queue) Configure(c q.Config) error {
+	return l.w.Configure(c)
+}
+
+func (l *queue) OnEvict(fn func(*q.Message)) {
+	l.w.OnEvict(fn)
+}
+
 func (l *queue) Add(m *q.Message) error {
-	if err := l.w.Add(m); err != nil {
+	return l.AddContext(context.Background(), m)
+}
+
+func (l *queue) AddContext(ctx context.Context, m *q.Message) error {
+	if err := l.w.AddContext(ctx, m); err != nil {
 		t := q.UnknownError
 		if e.IsFull(err) {
 			t = q.Full
@@ -76,3 +103,106 @@ func (l *queue) Peek() (*q.Message, error) {
 	}
 	return m, nil
 }
+
+func (l *queue) PopMatching(tags ...q.Tag) (*q.Message, error) {
+	m, err := l.w.PopMatching(tags...)
+	if err != nil {
+		t := q.UnknownError
+		if e.IsNotFound(err) {
+			t = q.NotFound
+		}
+		l.m.Error(l.ID(), t)
+		return nil, err
+	}
+	l.m.Consumed(l.ID())
+	return m, nil
+}
+
+func (l *queue) PeekMatching(tags ...q.Tag) (*q.Message, error) {
+	m, err := l.w.PeekMatching(tags...)
+	if err != nil {
+		t := q.UnknownError
+		if e.IsNotFound(err) {
+			t = q.NotFound
+		}
+		l.m.Error(l.ID(), t)
+		return nil, err
+	}
+	return m, nil
+}
+
+func (l *queue) Get(id uuid.UUID) (*q.Message, error) {
+	m, err := l.w.Get(id)
+	if err != nil {
+		t := q.UnknownError
+		if e.IsNotFound(err) {
+			t = q.NotFound
+		}
+		l.m.Error(l.ID(), t)
+		return nil, err
+	}
+	return m, nil
+}
+
+func (l *queue) Delete(id uuid.UUID) error {
+	if err := l.w.Delete(id); err != nil {
+		t := q.UnknownError
+		if e.IsNotFound(err) {
+			t = q.NotFound
+		}
+		l.m.Error(l.ID(), t)
+		return err
+	}
+	return nil
+}
+
+func (l *queue) Purge() (int, error) {
+	n, err := l.w.Purge()
+	if err != nil {
+		l.m.Error(l.ID(), q.UnknownError)
+		return 0, err
+	}
+	l.m.Purged(l.ID(), n)
+	return n, nil
+}
+
+func (l *queue) Walk(offset uint64, fn q.WalkFunc) error {
+	if err := l.w.Walk(offset, fn); err != nil {
+		l.m.Error(l.ID(), q.UnknownError)
+		return err
+	}
+	return nil
+}
+
+func (l *queue) Added() <-chan struct{} {
+	return q.Added(l.w)
+}
+
+func (l *queue) Unwrap() q.Queue {
+	return l.w
+}
+
+func (l *queue) CanTransfer(dst q.Queue) bool {
+	t, ok := l.w.(q.Transferer)
+	return ok && t.CanTransfer(dst)
+}
+
+// Transfer counts moved messages as consumed. The destination queue counts them
+// as enqueued.
+func (l *queue) Transfer(ctx context.Context, dst q.Queue, max int, move bool, tags ...q.Tag) ([]*q.Message, error) {
+	transferred, err := l.w.(q.Transferer).Transfer(ctx, dst, max, move, tags...)
+	if move {
+		for range transferred {
+			l.m.Consumed(l.ID())
+		}
+	}
+	if err != nil {
+		t := q.UnknownError
+		if e.IsFull(err) {
+			t = q.Full
+		}
+		l.m.Error(l.ID(), t)
+		return transferred, err
+	}
+	return transferred, nil
+}
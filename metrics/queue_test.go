@@ -4,13 +4,36 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 
 	"github.com/negz/q"
 	"github.com/negz/q/e"
+	"github.com/negz/q/memory"
+	"github.com/negz/q/queuetest"
 	"github.com/negz/q/test/fixtures"
 )
 
+type evictCounter struct {
+	q.Metrics
+	evicted int
+}
+
+func (c *evictCounter) Evicted(id uuid.UUID) { c.evicted++ }
+
+type lagRecorder struct {
+	q.Metrics
+	lag map[string]uint64
+}
+
+func (r *lagRecorder) Lag(id uuid.UUID, group string, lag uint64) { r.lag[group] = lag }
+
+func TestMetricsSuite(t *testing.T) {
+	queuetest.RunQueueSuite(t, func(limit int) q.Queue {
+		return Queue(memory.New(memory.Limit(limit)), NewNop())
+	})
+}
+
 func TestMetrics(t *testing.T) {
 	t.Run("Add", func(t *testing.T) {
 		msg := q.NewMessage([]byte("add"))
@@ -28,6 +51,34 @@ func TestMetrics(t *testing.T) {
 		}
 	})
 
+	t.Run("AddEvict", func(t *testing.T) {
+		msg := q.NewMessage([]byte("add"))
+		mx := &evictCounter{Metrics: NewNop()}
+		queue := Queue(fixtures.NewPredictableQueue(q.NewMessage([]byte("evict")), nil), mx)
+		if err := queue.Add(msg); err != nil {
+			t.Errorf("queue.Add(%v): %v", msg, err)
+		}
+		if mx.evicted != 1 {
+			t.Errorf("queue.Add(%v): want 1 evicted, got %v", msg, mx.evicted)
+		}
+	})
+
+	t.Run("Lag", func(t *testing.T) {
+		mx := &lagRecorder{Metrics: NewNop(), lag: map[string]uint64{}}
+		l := memory.NewLog()
+		queue := Queue(l, mx)
+		if _, err := l.AddGroup("apollo"); err != nil {
+			t.Fatalf("l.AddGroup(apollo): %v", err)
+		}
+		msg := q.NewMessage([]byte("lag"))
+		if err := queue.Add(msg); err != nil {
+			t.Errorf("queue.Add(%v): %v", msg, err)
+		}
+		if mx.lag["apollo"] != 1 {
+			t.Errorf("queue.Add(%v): want lag 1, got %v", msg, mx.lag["apollo"])
+		}
+	})
+
 	t.Run("Peek", func(t *testing.T) {
 		msg := q.NewMessage([]byte("peek"))
 		queue := Queue(fixtures.NewPredictableQueue(msg, nil), NewNop())
@@ -65,4 +116,57 @@ func TestMetrics(t *testing.T) {
 			t.Errorf("queue.Pop(): want error satisfying e.IsNotFound(), got %v", err)
 		}
 	})
+
+	t.Run("PopMatching", func(t *testing.T) {
+		tag := q.Tag{Key: "region", Value: "eu"}
+		msg := q.NewMessage([]byte("pop"), q.Tagged(tag))
+		queue := Queue(fixtures.NewPredictableQueue(msg, nil), NewNop())
+		m, err := queue.PopMatching(tag)
+		if err != nil {
+			t.Errorf("queue.PopMatching(%v): %v", tag, err)
+		}
+		if !reflect.DeepEqual(msg, m) {
+			t.Errorf("queue.PopMatching(%v): want %v, got %v", tag, msg, m)
+		}
+	})
+
+	t.Run("PeekMatchingNotFound", func(t *testing.T) {
+		tag := q.Tag{Key: "region", Value: "eu"}
+		queue := Queue(fixtures.NewPredictableQueue(nil, e.ErrNotFound(errors.New("empty!"))), NewNop())
+		if _, err := queue.PeekMatching(tag); !e.IsNotFound(err) {
+			t.Errorf("queue.PeekMatching(%v): want error satisfying e.IsNotFound(), got %v", tag, err)
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		msg := q.NewMessage([]byte("get"))
+		queue := Queue(fixtures.NewPredictableQueue(msg, nil), NewNop())
+		m, err := queue.Get(msg.ID)
+		if err != nil {
+			t.Errorf("queue.Get(%v): %v", msg.ID, err)
+		}
+		if !reflect.DeepEqual(msg, m) {
+			t.Errorf("queue.Get(%v): want %v, got %v", msg.ID, msg, m)
+		}
+	})
+
+	t.Run("DeleteNotFound", func(t *testing.T) {
+		id := q.NewMessage([]byte("delete")).ID
+		queue := Queue(fixtures.NewPredictableQueue(nil, e.ErrNotFound(errors.New("missing!"))), NewNop())
+		if err := queue.Delete(id); !e.IsNotFound(err) {
+			t.Errorf("queue.Delete(%v): want error satisfying e.IsNotFound(), got %v", id, err)
+		}
+	})
+
+	t.Run("Purge", func(t *testing.T) {
+		msg := q.NewMessage([]byte("purge"))
+		queue := Queue(fixtures.NewPredictableQueue(msg, nil), NewNop())
+		n, err := queue.Purge()
+		if err != nil {
+			t.Errorf("queue.Purge(): %v", err)
+		}
+		if n != 1 {
+			t.Errorf("queue.Purge(): want 1 purged, got %v", n)
+		}
+	})
 }
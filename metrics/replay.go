@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/negz/q"
+)
+
+// A seekerQueue is a metrics queue that wraps a seeker. Replayed messages are
+// counted as enqueued.
+type seekerQueue struct {
+	*queue
+	ws q.Seeker
+}
+
+func (l *seekerQueue) Seek(offset uint64) ([]*q.Message, error) {
+	return l.replayed(l.ws.Seek(offset))
+}
+
+func (l *seekerQueue) SeekTime(t time.Time) ([]*q.Message, error) {
+	return l.replayed(l.ws.SeekTime(t))
+}
+
+func (l *seekerQueue) replayed(replayed []*q.Message, err error) ([]*q.Message, error) {
+	if err != nil {
+		l.m.Error(l.ID(), q.UnknownError)
+		return nil, err
+	}
+	for range replayed {
+		l.m.Enqueued(l.ID())
+	}
+	return replayed, nil
+}
@@ -0,0 +1,20 @@
+package q
+
+// A Notifier is a queue that can notify consumers when messages are added to
+// it, so that they need not poll it.
+type Notifier interface {
+	// Added returns a channel that is closed when a message is next added to
+	// the queue. It returns nil if the queue cannot notify consumers, for
+	// example because it wraps a queue that is not a Notifier.
+	Added() <-chan struct{}
+}
+
+// Added returns a channel that is closed when a message is next added to the
+// supplied queue, or nil if the queue cannot notify consumers.
+func Added(queue Queue) <-chan struct{} {
+	n, ok := queue.(Notifier)
+	if !ok {
+		return nil
+	}
+	return n.Added()
+}
@@ -14,23 +14,73 @@
 		GetQueueResponse
 		ListQueuesRequest
 		ListQueuesResponse
+		UpdateQueueRequest
+		UpdateQueueResponse
 		DeleteQueueRequest
 		DeleteQueueResponse
+		PurgeQueueRequest
+		PurgeQueueResponse
 		AddQueueTagRequest
 		AddQueueTagResponse
 		DeleteQueueTagRequest
 		DeleteQueueTagResponse
 		AddRequest
 		AddResponse
+		AddBatchRequest
+		AddBatchResponse
+		RequestRequest
+		RequestResponse
 		PopRequest
 		PopResponse
 		PeekRequest
 		PeekResponse
+		ListMessagesRequest
+		ListMessagesResponse
+		GetMessageRequest
+		GetMessageResponse
+		DeleteMessageRequest
+		DeleteMessageResponse
+		MoveMessagesRequest
+		MoveMessagesResponse
+		CopyMessagesRequest
+		CopyMessagesResponse
+		NewTopicRequest
+		NewTopicResponse
+		GetTopicRequest
+		GetTopicResponse
+		ListTopicsRequest
+		ListTopicsResponse
+		DeleteTopicRequest
+		DeleteTopicResponse
+		SubscribeRequest
+		SubscribeResponse
+		UnsubscribeRequest
+		UnsubscribeResponse
+		PublishRequest
+		PublishResponse
+		ListGroupsRequest
+		ListGroupsResponse
+		NewGroupRequest
+		NewGroupResponse
+		GetGroupRequest
+		GetGroupResponse
+		DeleteGroupRequest
+		DeleteGroupResponse
+		ReadRequest
+		ReadResponse
+		CommitRequest
+		CommitResponse
+		SeekRequest
+		SeekResponse
 		Tag
 		Metadata
 		NewMessage
 		Message
 		Queue
+		QueueConfig
+		Subscription
+		Topic
+		Group
 */
 package proto
 
@@ -40,7 +90,9 @@ import fmt "fmt"
 import math "math"
 import _ "github.com/gogo/protobuf/gogoproto"
 import _ "google.golang.org/genproto/googleapis/api/annotations"
-import google_protobuf1 "github.com/golang/protobuf/ptypes/timestamp"
+import google_protobuf1 "github.com/golang/protobuf/ptypes/duration"
+import google_protobuf2 "google.golang.org/genproto/protobuf/field_mask"
+import google_protobuf3 "github.com/golang/protobuf/ptypes/timestamp"
 
 import strconv "strconv"
 
@@ -67,28 +119,62 @@ const _ = proto1.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 type Queue_Store int32
 
 const (
-	UNKNOWN Queue_Store = 0
-	MEMORY  Queue_Store = 1
-	BOLTDB  Queue_Store = 2
+	UNKNOWN    Queue_Store = 0
+	MEMORY     Queue_Store = 1
+	BOLTDB     Queue_Store = 2
+	MEMORY_LOG Queue_Store = 3
 )
 
 var Queue_Store_name = map[int32]string{
 	0: "UNKNOWN",
 	1: "MEMORY",
 	2: "BOLTDB",
+	3: "MEMORY_LOG",
 }
 var Queue_Store_value = map[string]int32{
-	"UNKNOWN": 0,
-	"MEMORY":  1,
-	"BOLTDB":  2,
+	"UNKNOWN":    0,
+	"MEMORY":     1,
+	"BOLTDB":     2,
+	"MEMORY_LOG": 3,
 }
 
-func (Queue_Store) EnumDescriptor() ([]byte, []int) { return fileDescriptorQ, []int{22, 0} }
+func (Queue_Store) EnumDescriptor() ([]byte, []int) { return fileDescriptorQ, []int{68, 0} }
 
+type QueueConfig_Overflow int32
+
+const (
+	REJECT      QueueConfig_Overflow = 0
+	DROP_OLDEST QueueConfig_Overflow = 1
+	BLOCK       QueueConfig_Overflow = 2
+)
+
+var QueueConfig_Overflow_name = map[int32]string{
+	0: "REJECT",
+	1: "DROP_OLDEST",
+	2: "BLOCK",
+}
+var QueueConfig_Overflow_value = map[string]int32{
+	"REJECT":      0,
+	"DROP_OLDEST": 1,
+	"BLOCK":       2,
+}
+
+func (QueueConfig_Overflow) EnumDescriptor() ([]byte, []int) { return fileDescriptorQ, []int{69, 0} }
+
+// A max_bytes or max_message_bytes of zero requests the server's default. Use
+// -1 for no limit. A temporary queue is deleted when the client connection that
+// created it closes, or when it expires after being idle. Temporary queues that
+// do not set expire_after_idle default to the server's idle timeout.
 type NewQueueRequest struct {
-	Store Queue_Store `protobuf:"varint,1,opt,name=store,proto3,enum=proto.Queue_Store" json:"store,omitempty"`
-	Limit int64       `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Tags  []*Tag      `protobuf:"bytes,3,rep,name=tags" json:"tags,omitempty"`
+	Store           Queue_Store                `protobuf:"varint,1,opt,name=store,proto3,enum=proto.Queue_Store" json:"store,omitempty"`
+	Limit           int64                      `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Tags            []*Tag                     `protobuf:"bytes,3,rep,name=tags" json:"tags,omitempty"`
+	MaxBytes        int64                      `protobuf:"varint,4,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	MaxMessageBytes int64                      `protobuf:"varint,5,opt,name=max_message_bytes,json=maxMessageBytes,proto3" json:"max_message_bytes,omitempty"`
+	Overflow        QueueConfig_Overflow       `protobuf:"varint,6,opt,name=overflow,proto3,enum=proto.QueueConfig_Overflow" json:"overflow,omitempty"`
+	Retention       *google_protobuf1.Duration `protobuf:"bytes,7,opt,name=retention" json:"retention,omitempty"`
+	Temporary       bool                       `protobuf:"varint,8,opt,name=temporary,proto3" json:"temporary,omitempty"`
+	ExpireAfterIdle *google_protobuf1.Duration `protobuf:"bytes,9,opt,name=expire_after_idle,json=expireAfterIdle" json:"expire_after_idle,omitempty"`
 }
 
 func (m *NewQueueRequest) Reset()                    { *m = NewQueueRequest{} }
@@ -116,6 +202,48 @@ func (m *NewQueueRequest) GetTags() []*Tag {
 	return nil
 }
 
+func (m *NewQueueRequest) GetMaxBytes() int64 {
+	if m != nil {
+		return m.MaxBytes
+	}
+	return 0
+}
+
+func (m *NewQueueRequest) GetMaxMessageBytes() int64 {
+	if m != nil {
+		return m.MaxMessageBytes
+	}
+	return 0
+}
+
+func (m *NewQueueRequest) GetOverflow() QueueConfig_Overflow {
+	if m != nil {
+		return m.Overflow
+	}
+	return REJECT
+}
+
+func (m *NewQueueRequest) GetRetention() *google_protobuf1.Duration {
+	if m != nil {
+		return m.Retention
+	}
+	return nil
+}
+
+func (m *NewQueueRequest) GetTemporary() bool {
+	if m != nil {
+		return m.Temporary
+	}
+	return false
+}
+
+func (m *NewQueueRequest) GetExpireAfterIdle() *google_protobuf1.Duration {
+	if m != nil {
+		return m.ExpireAfterIdle
+	}
+	return nil
+}
+
 type NewQueueResponse struct {
 	Queue *Queue `protobuf:"bytes,1,opt,name=queue" json:"queue,omitempty"`
 }
@@ -161,15 +289,36 @@ func (m *GetQueueResponse) GetQueue() *Queue {
 	return nil
 }
 
+// Queues are listed in order of creation time, then ID. A page_size of zero
+// requests the server's default page size. The page_token is the
+// next_page_token returned by a previous call to ListQueues.
 type ListQueuesRequest struct {
+	PageSize  int32  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 }
 
 func (m *ListQueuesRequest) Reset()                    { *m = ListQueuesRequest{} }
 func (*ListQueuesRequest) ProtoMessage()               {}
 func (*ListQueuesRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{4} }
 
+func (m *ListQueuesRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListQueuesRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+// The next_page_token is empty when there are no more queues to list.
 type ListQueuesResponse struct {
-	Queues []*Queue `protobuf:"bytes,1,rep,name=queues" json:"queues,omitempty"`
+	Queues        []*Queue `protobuf:"bytes,1,rep,name=queues" json:"queues,omitempty"`
+	NextPageToken string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 }
 
 func (m *ListQueuesResponse) Reset()                    { *m = ListQueuesResponse{} }
@@ -183,13 +332,71 @@ func (m *ListQueuesResponse) GetQueues() []*Queue {
 	return nil
 }
 
+func (m *ListQueuesResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+// Only the fields of config named by update_mask are changed. Supported paths
+// are: limit, max_bytes, max_message_bytes, and overflow.
+type UpdateQueueRequest struct {
+	QueueId    string                      `protobuf:"bytes,1,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	Config     *QueueConfig                `protobuf:"bytes,2,opt,name=config" json:"config,omitempty"`
+	UpdateMask *google_protobuf2.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask" json:"update_mask,omitempty"`
+}
+
+func (m *UpdateQueueRequest) Reset()                    { *m = UpdateQueueRequest{} }
+func (*UpdateQueueRequest) ProtoMessage()               {}
+func (*UpdateQueueRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{6} }
+
+func (m *UpdateQueueRequest) GetQueueId() string {
+	if m != nil {
+		return m.QueueId
+	}
+	return ""
+}
+
+func (m *UpdateQueueRequest) GetConfig() *QueueConfig {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *UpdateQueueRequest) GetUpdateMask() *google_protobuf2.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+type UpdateQueueResponse struct {
+	Queue *Queue `protobuf:"bytes,1,opt,name=queue" json:"queue,omitempty"`
+}
+
+func (m *UpdateQueueResponse) Reset()                    { *m = UpdateQueueResponse{} }
+func (*UpdateQueueResponse) ProtoMessage()               {}
+func (*UpdateQueueResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{7} }
+
+func (m *UpdateQueueResponse) GetQueue() *Queue {
+	if m != nil {
+		return m.Queue
+	}
+	return nil
+}
+
+// DeleteQueue refuses to delete a queue that still holds messages unless force
+// is set.
 type DeleteQueueRequest struct {
 	QueueId string `protobuf:"bytes,1,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	Force   bool   `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
 }
 
 func (m *DeleteQueueRequest) Reset()                    { *m = DeleteQueueRequest{} }
 func (*DeleteQueueRequest) ProtoMessage()               {}
-func (*DeleteQueueRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{6} }
+func (*DeleteQueueRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{8} }
 
 func (m *DeleteQueueRequest) GetQueueId() string {
 	if m != nil {
@@ -198,12 +405,50 @@ func (m *DeleteQueueRequest) GetQueueId() string {
 	return ""
 }
 
+func (m *DeleteQueueRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
 type DeleteQueueResponse struct {
 }
 
 func (m *DeleteQueueResponse) Reset()                    { *m = DeleteQueueResponse{} }
 func (*DeleteQueueResponse) ProtoMessage()               {}
-func (*DeleteQueueResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{7} }
+func (*DeleteQueueResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{9} }
+
+// PurgeQueue removes all messages from a queue without changing its ID.
+type PurgeQueueRequest struct {
+	QueueId string `protobuf:"bytes,1,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+}
+
+func (m *PurgeQueueRequest) Reset()                    { *m = PurgeQueueRequest{} }
+func (*PurgeQueueRequest) ProtoMessage()               {}
+func (*PurgeQueueRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{10} }
+
+func (m *PurgeQueueRequest) GetQueueId() string {
+	if m != nil {
+		return m.QueueId
+	}
+	return ""
+}
+
+type PurgeQueueResponse struct {
+	Purged int64 `protobuf:"varint,1,opt,name=purged,proto3" json:"purged,omitempty"`
+}
+
+func (m *PurgeQueueResponse) Reset()                    { *m = PurgeQueueResponse{} }
+func (*PurgeQueueResponse) ProtoMessage()               {}
+func (*PurgeQueueResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{11} }
+
+func (m *PurgeQueueResponse) GetPurged() int64 {
+	if m != nil {
+		return m.Purged
+	}
+	return 0
+}
 
 type AddQueueTagRequest struct {
 	QueueId string `protobuf:"bytes,1,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
@@ -212,7 +457,7 @@ type AddQueueTagRequest struct {
 
 func (m *AddQueueTagRequest) Reset()                    { *m = AddQueueTagRequest{} }
 func (*AddQueueTagRequest) ProtoMessage()               {}
-func (*AddQueueTagRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{8} }
+func (*AddQueueTagRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{12} }
 
 func (m *AddQueueTagRequest) GetQueueId() string {
 	if m != nil {
@@ -233,7 +478,7 @@ type AddQueueTagResponse struct {
 
 func (m *AddQueueTagResponse) Reset()                    { *m = AddQueueTagResponse{} }
 func (*AddQueueTagResponse) ProtoMessage()               {}
-func (*AddQueueTagResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{9} }
+func (*AddQueueTagResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{13} }
 
 type DeleteQueueTagRequest struct {
 	QueueId string `protobuf:"bytes,1,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
@@ -242,7 +487,7 @@ type DeleteQueueTagRequest struct {
 
 func (m *DeleteQueueTagRequest) Reset()                    { *m = DeleteQueueTagRequest{} }
 func (*DeleteQueueTagRequest) ProtoMessage()               {}
-func (*DeleteQueueTagRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{10} }
+func (*DeleteQueueTagRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{14} }
 
 func (m *DeleteQueueTagRequest) GetQueueId() string {
 	if m != nil {
@@ -263,7 +508,7 @@ type DeleteQueueTagResponse struct {
 
 func (m *DeleteQueueTagResponse) Reset()                    { *m = DeleteQueueTagResponse{} }
 func (*DeleteQueueTagResponse) ProtoMessage()               {}
-func (*DeleteQueueTagResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{11} }
+func (*DeleteQueueTagResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{15} }
 
 type AddRequest struct {
 	QueueId string      `protobuf:"bytes,1,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
@@ -272,7 +517,7 @@ type AddRequest struct {
 
 func (m *AddRequest) Reset()                    { *m = AddRequest{} }
 func (*AddRequest) ProtoMessage()               {}
-func (*AddRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{12} }
+func (*AddRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{16} }
 
 func (m *AddRequest) GetQueueId() string {
 	if m != nil {
@@ -294,7 +539,7 @@ type AddResponse struct {
 
 func (m *AddResponse) Reset()                    { *m = AddResponse{} }
 func (*AddResponse) ProtoMessage()               {}
-func (*AddResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{13} }
+func (*AddResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{17} }
 
 func (m *AddResponse) GetMessage() *Message {
 	if m != nil {
@@ -303,13 +548,104 @@ func (m *AddResponse) GetMessage() *Message {
 	return nil
 }
 
+// AddBatch adds messages to a queue in order, stopping at the first message that
+// cannot be added. It fails only if the first message cannot be added, so a
+// response with fewer messages than were requested means the rest were not
+// added. Resend them to learn why.
+type AddBatchRequest struct {
+	QueueId  string        `protobuf:"bytes,1,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	Messages []*NewMessage `protobuf:"bytes,2,rep,name=messages" json:"messages,omitempty"`
+}
+
+func (m *AddBatchRequest) Reset()                    { *m = AddBatchRequest{} }
+func (*AddBatchRequest) ProtoMessage()               {}
+func (*AddBatchRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{18} }
+
+func (m *AddBatchRequest) GetQueueId() string {
+	if m != nil {
+		return m.QueueId
+	}
+	return ""
+}
+
+func (m *AddBatchRequest) GetMessages() []*NewMessage {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+type AddBatchResponse struct {
+	Messages []*Message `protobuf:"bytes,1,rep,name=messages" json:"messages,omitempty"`
+}
+
+func (m *AddBatchResponse) Reset()                    { *m = AddBatchResponse{} }
+func (*AddBatchResponse) ProtoMessage()               {}
+func (*AddBatchResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{19} }
+
+func (m *AddBatchResponse) GetMessages() []*Message {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+// Request adds a message to a queue and waits until a reply with the same
+// correlation_id is added to its reply_to queue, returning the reply. A
+// temporary reply queue is used if the message has no reply_to, and a
+// correlation_id is generated if the message has none. Set a deadline to bound
+// how long to wait.
+type RequestRequest struct {
+	QueueId string      `protobuf:"bytes,1,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	Message *NewMessage `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
+}
+
+func (m *RequestRequest) Reset()                    { *m = RequestRequest{} }
+func (*RequestRequest) ProtoMessage()               {}
+func (*RequestRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{20} }
+
+func (m *RequestRequest) GetQueueId() string {
+	if m != nil {
+		return m.QueueId
+	}
+	return ""
+}
+
+func (m *RequestRequest) GetMessage() *NewMessage {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+type RequestResponse struct {
+	Reply *Message `protobuf:"bytes,1,opt,name=reply" json:"reply,omitempty"`
+}
+
+func (m *RequestResponse) Reset()                    { *m = RequestResponse{} }
+func (*RequestResponse) ProtoMessage()               {}
+func (*RequestResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{21} }
+
+func (m *RequestResponse) GetReply() *Message {
+	if m != nil {
+		return m.Reply
+	}
+	return nil
+}
+
+// When a filter is supplied only messages tagged with all of its tags are
+// popped. Messages that do not match are left in the queue. When wait is
+// supplied the server waits up to that long for a matching message to be added
+// to an empty queue.
 type PopRequest struct {
-	QueueId string `protobuf:"bytes,1,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	QueueId string                     `protobuf:"bytes,1,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	Filter  []*Tag                     `protobuf:"bytes,2,rep,name=filter" json:"filter,omitempty"`
+	Wait    *google_protobuf1.Duration `protobuf:"bytes,3,opt,name=wait" json:"wait,omitempty"`
 }
 
 func (m *PopRequest) Reset()                    { *m = PopRequest{} }
 func (*PopRequest) ProtoMessage()               {}
-func (*PopRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{14} }
+func (*PopRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{22} }
 
 func (m *PopRequest) GetQueueId() string {
 	if m != nil {
@@ -318,13 +654,27 @@ func (m *PopRequest) GetQueueId() string {
 	return ""
 }
 
+func (m *PopRequest) GetFilter() []*Tag {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *PopRequest) GetWait() *google_protobuf1.Duration {
+	if m != nil {
+		return m.Wait
+	}
+	return nil
+}
+
 type PopResponse struct {
 	Message *Message `protobuf:"bytes,1,opt,name=message" json:"message,omitempty"`
 }
 
 func (m *PopResponse) Reset()                    { *m = PopResponse{} }
 func (*PopResponse) ProtoMessage()               {}
-func (*PopResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{15} }
+func (*PopResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{23} }
 
 func (m *PopResponse) GetMessage() *Message {
 	if m != nil {
@@ -333,13 +683,16 @@ func (m *PopResponse) GetMessage() *Message {
 	return nil
 }
 
+// When a filter is supplied only messages tagged with all of its tags are
+// considered.
 type PeekRequest struct {
 	QueueId string `protobuf:"bytes,1,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	Filter  []*Tag `protobuf:"bytes,2,rep,name=filter" json:"filter,omitempty"`
 }
 
 func (m *PeekRequest) Reset()                    { *m = PeekRequest{} }
 func (*PeekRequest) ProtoMessage()               {}
-func (*PeekRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{16} }
+func (*PeekRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{24} }
 
 func (m *PeekRequest) GetQueueId() string {
 	if m != nil {
@@ -348,13 +701,20 @@ func (m *PeekRequest) GetQueueId() string {
 	return ""
 }
 
+func (m *PeekRequest) GetFilter() []*Tag {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
 type PeekResponse struct {
 	Message *Message `protobuf:"bytes,1,opt,name=message" json:"message,omitempty"`
 }
 
 func (m *PeekResponse) Reset()                    { *m = PeekResponse{} }
 func (*PeekResponse) ProtoMessage()               {}
-func (*PeekResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{17} }
+func (*PeekResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{25} }
 
 func (m *PeekResponse) GetMessage() *Message {
 	if m != nil {
@@ -363,979 +723,4004 @@ func (m *PeekResponse) GetMessage() *Message {
 	return nil
 }
 
-type Tag struct {
-	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+// Messages are listed in queue order without being consumed. A page_size of
+// zero requests the server's default page size. The page_token is the
+// next_page_token returned by a previous call to ListMessages. When a filter is
+// supplied only messages tagged with all of its tags are listed. A non-zero
+// preview_length truncates message payloads to at most that many bytes.
+type ListMessagesRequest struct {
+	QueueId       string `protobuf:"bytes,1,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	PageSize      int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Filter        []*Tag `protobuf:"bytes,4,rep,name=filter" json:"filter,omitempty"`
+	PreviewLength int32  `protobuf:"varint,5,opt,name=preview_length,json=previewLength,proto3" json:"preview_length,omitempty"`
 }
 
-func (m *Tag) Reset()                    { *m = Tag{} }
-func (*Tag) ProtoMessage()               {}
-func (*Tag) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{18} }
+func (m *ListMessagesRequest) Reset()                    { *m = ListMessagesRequest{} }
+func (*ListMessagesRequest) ProtoMessage()               {}
+func (*ListMessagesRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{26} }
 
-func (m *Tag) GetKey() string {
+func (m *ListMessagesRequest) GetQueueId() string {
 	if m != nil {
-		return m.Key
+		return m.QueueId
 	}
 	return ""
 }
 
-func (m *Tag) GetValue() string {
+func (m *ListMessagesRequest) GetPageSize() int32 {
 	if m != nil {
-		return m.Value
+		return m.PageSize
 	}
-	return ""
+	return 0
 }
 
-type Metadata struct {
-	Id      string                      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Created *google_protobuf1.Timestamp `protobuf:"bytes,2,opt,name=created" json:"created,omitempty"`
-	Tags    []*Tag                      `protobuf:"bytes,3,rep,name=tags" json:"tags,omitempty"`
+func (m *ListMessagesRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
 }
 
-func (m *Metadata) Reset()                    { *m = Metadata{} }
-func (*Metadata) ProtoMessage()               {}
-func (*Metadata) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{19} }
+func (m *ListMessagesRequest) GetFilter() []*Tag {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
 
-func (m *Metadata) GetId() string {
+func (m *ListMessagesRequest) GetPreviewLength() int32 {
 	if m != nil {
-		return m.Id
+		return m.PreviewLength
 	}
-	return ""
+	return 0
+}
+
+// The next_page_token is empty when there are no more messages to list.
+type ListMessagesResponse struct {
+	Messages      []*Message `protobuf:"bytes,1,rep,name=messages" json:"messages,omitempty"`
+	NextPageToken string     `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 }
 
-func (m *Metadata) GetCreated() *google_protobuf1.Timestamp {
+func (m *ListMessagesResponse) Reset()                    { *m = ListMessagesResponse{} }
+func (*ListMessagesResponse) ProtoMessage()               {}
+func (*ListMessagesResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{27} }
+
+func (m *ListMessagesResponse) GetMessages() []*Message {
 	if m != nil {
-		return m.Created
+		return m.Messages
 	}
 	return nil
 }
 
-func (m *Metadata) GetTags() []*Tag {
+func (m *ListMessagesResponse) GetNextPageToken() string {
 	if m != nil {
-		return m.Tags
+		return m.NextPageToken
 	}
-	return nil
+	return ""
 }
 
-// A NewMessage is the user-writable subset of a Message. We could use Message
-// for new messages and just ignore any ID or create times the caller sent, but
-// doing so would cause the grpc-gateway swagger spec generator to generate a
-// misleading input.
-type NewMessage struct {
-	Tags    []*Tag `protobuf:"bytes,1,rep,name=tags" json:"tags,omitempty"`
-	Payload []byte `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+// GetMessage returns a message without consuming it.
+type GetMessageRequest struct {
+	QueueId   string `protobuf:"bytes,1,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	MessageId string `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
 }
 
-func (m *NewMessage) Reset()                    { *m = NewMessage{} }
-func (*NewMessage) ProtoMessage()               {}
-func (*NewMessage) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{20} }
+func (m *GetMessageRequest) Reset()                    { *m = GetMessageRequest{} }
+func (*GetMessageRequest) ProtoMessage()               {}
+func (*GetMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{28} }
 
-func (m *NewMessage) GetTags() []*Tag {
+func (m *GetMessageRequest) GetQueueId() string {
 	if m != nil {
-		return m.Tags
+		return m.QueueId
 	}
-	return nil
+	return ""
 }
 
-func (m *NewMessage) GetPayload() []byte {
+func (m *GetMessageRequest) GetMessageId() string {
 	if m != nil {
-		return m.Payload
+		return m.MessageId
 	}
-	return nil
+	return ""
 }
 
-type Message struct {
-	Meta    *Metadata `protobuf:"bytes,1,opt,name=meta" json:"meta,omitempty"`
-	Payload []byte    `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+type GetMessageResponse struct {
+	Message *Message `protobuf:"bytes,1,opt,name=message" json:"message,omitempty"`
 }
 
-func (m *Message) Reset()                    { *m = Message{} }
-func (*Message) ProtoMessage()               {}
-func (*Message) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{21} }
+func (m *GetMessageResponse) Reset()                    { *m = GetMessageResponse{} }
+func (*GetMessageResponse) ProtoMessage()               {}
+func (*GetMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{29} }
 
-func (m *Message) GetMeta() *Metadata {
+func (m *GetMessageResponse) GetMessage() *Message {
 	if m != nil {
-		return m.Meta
+		return m.Message
 	}
 	return nil
 }
 
-func (m *Message) GetPayload() []byte {
+// DeleteMessage removes a message regardless of its position in the queue.
+type DeleteMessageRequest struct {
+	QueueId   string `protobuf:"bytes,1,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	MessageId string `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+}
+
+func (m *DeleteMessageRequest) Reset()                    { *m = DeleteMessageRequest{} }
+func (*DeleteMessageRequest) ProtoMessage()               {}
+func (*DeleteMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{30} }
+
+func (m *DeleteMessageRequest) GetQueueId() string {
 	if m != nil {
-		return m.Payload
+		return m.QueueId
 	}
-	return nil
+	return ""
 }
 
-type Queue struct {
-	Meta  *Metadata   `protobuf:"bytes,1,opt,name=meta" json:"meta,omitempty"`
-	Store Queue_Store `protobuf:"varint,2,opt,name=store,proto3,enum=proto.Queue_Store" json:"store,omitempty"`
+func (m *DeleteMessageRequest) GetMessageId() string {
+	if m != nil {
+		return m.MessageId
+	}
+	return ""
 }
 
-func (m *Queue) Reset()                    { *m = Queue{} }
-func (*Queue) ProtoMessage()               {}
-func (*Queue) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{22} }
+type DeleteMessageResponse struct {
+}
 
-func (m *Queue) GetMeta() *Metadata {
+func (m *DeleteMessageResponse) Reset()                    { *m = DeleteMessageResponse{} }
+func (*DeleteMessageResponse) ProtoMessage()               {}
+func (*DeleteMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{31} }
+
+// MoveMessages moves up to max_count messages from the source queue to the
+// destination queue in queue order. A max_count of zero moves all messages.
+// When a filter is supplied only messages tagged with all of its tags are
+// moved. Each message is moved atomically when both queues are stored in the
+// same BoltDB database.
+type MoveMessagesRequest struct {
+	SourceQueueId      string `protobuf:"bytes,1,opt,name=source_queue_id,json=sourceQueueId,proto3" json:"source_queue_id,omitempty"`
+	DestinationQueueId string `protobuf:"bytes,2,opt,name=destination_queue_id,json=destinationQueueId,proto3" json:"destination_queue_id,omitempty"`
+	MaxCount           int64  `protobuf:"varint,3,opt,name=max_count,json=maxCount,proto3" json:"max_count,omitempty"`
+	Filter             []*Tag `protobuf:"bytes,4,rep,name=filter" json:"filter,omitempty"`
+}
+
+func (m *MoveMessagesRequest) Reset()                    { *m = MoveMessagesRequest{} }
+func (*MoveMessagesRequest) ProtoMessage()               {}
+func (*MoveMessagesRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{32} }
+
+func (m *MoveMessagesRequest) GetSourceQueueId() string {
 	if m != nil {
-		return m.Meta
+		return m.SourceQueueId
 	}
-	return nil
+	return ""
 }
 
-func (m *Queue) GetStore() Queue_Store {
+func (m *MoveMessagesRequest) GetDestinationQueueId() string {
 	if m != nil {
-		return m.Store
+		return m.DestinationQueueId
 	}
-	return UNKNOWN
+	return ""
 }
 
-func init() {
-	proto1.RegisterType((*NewQueueRequest)(nil), "proto.NewQueueRequest")
-	golang_proto.RegisterType((*NewQueueRequest)(nil), "proto.NewQueueRequest")
-	proto1.RegisterType((*NewQueueResponse)(nil), "proto.NewQueueResponse")
-	golang_proto.RegisterType((*NewQueueResponse)(nil), "proto.NewQueueResponse")
-	proto1.RegisterType((*GetQueueRequest)(nil), "proto.GetQueueRequest")
-	golang_proto.RegisterType((*GetQueueRequest)(nil), "proto.GetQueueRequest")
-	proto1.RegisterType((*GetQueueResponse)(nil), "proto.GetQueueResponse")
-	golang_proto.RegisterType((*GetQueueResponse)(nil), "proto.GetQueueResponse")
-	proto1.RegisterType((*ListQueuesRequest)(nil), "proto.ListQueuesRequest")
-	golang_proto.RegisterType((*ListQueuesRequest)(nil), "proto.ListQueuesRequest")
-	proto1.RegisterType((*ListQueuesResponse)(nil), "proto.ListQueuesResponse")
-	golang_proto.RegisterType((*ListQueuesResponse)(nil), "proto.ListQueuesResponse")
-	proto1.RegisterType((*DeleteQueueRequest)(nil), "proto.DeleteQueueRequest")
-	golang_proto.RegisterType((*DeleteQueueRequest)(nil), "proto.DeleteQueueRequest")
-	proto1.RegisterType((*DeleteQueueResponse)(nil), "proto.DeleteQueueResponse")
-	golang_proto.RegisterType((*DeleteQueueResponse)(nil), "proto.DeleteQueueResponse")
-	proto1.RegisterType((*AddQueueTagRequest)(nil), "proto.AddQueueTagRequest")
-	golang_proto.RegisterType((*AddQueueTagRequest)(nil), "proto.AddQueueTagRequest")
-	proto1.RegisterType((*AddQueueTagResponse)(nil), "proto.AddQueueTagResponse")
-	golang_proto.RegisterType((*AddQueueTagResponse)(nil), "proto.AddQueueTagResponse")
-	proto1.RegisterType((*DeleteQueueTagRequest)(nil), "proto.DeleteQueueTagRequest")
-	golang_proto.RegisterType((*DeleteQueueTagRequest)(nil), "proto.DeleteQueueTagRequest")
-	proto1.RegisterType((*DeleteQueueTagResponse)(nil), "proto.DeleteQueueTagResponse")
-	golang_proto.RegisterType((*DeleteQueueTagResponse)(nil), "proto.DeleteQueueTagResponse")
-	proto1.RegisterType((*AddRequest)(nil), "proto.AddRequest")
-	golang_proto.RegisterType((*AddRequest)(nil), "proto.AddRequest")
-	proto1.RegisterType((*AddResponse)(nil), "proto.AddResponse")
-	golang_proto.RegisterType((*AddResponse)(nil), "proto.AddResponse")
-	proto1.RegisterType((*PopRequest)(nil), "proto.PopRequest")
-	golang_proto.RegisterType((*PopRequest)(nil), "proto.PopRequest")
-	proto1.RegisterType((*PopResponse)(nil), "proto.PopResponse")
-	golang_proto.RegisterType((*PopResponse)(nil), "proto.PopResponse")
-	proto1.RegisterType((*PeekRequest)(nil), "proto.PeekRequest")
-	golang_proto.RegisterType((*PeekRequest)(nil), "proto.PeekRequest")
-	proto1.RegisterType((*PeekResponse)(nil), "proto.PeekResponse")
-	golang_proto.RegisterType((*PeekResponse)(nil), "proto.PeekResponse")
-	proto1.RegisterType((*Tag)(nil), "proto.Tag")
-	golang_proto.RegisterType((*Tag)(nil), "proto.Tag")
-	proto1.RegisterType((*Metadata)(nil), "proto.Metadata")
-	golang_proto.RegisterType((*Metadata)(nil), "proto.Metadata")
-	proto1.RegisterType((*NewMessage)(nil), "proto.NewMessage")
-	golang_proto.RegisterType((*NewMessage)(nil), "proto.NewMessage")
-	proto1.RegisterType((*Message)(nil), "proto.Message")
-	golang_proto.RegisterType((*Message)(nil), "proto.Message")
-	proto1.RegisterType((*Queue)(nil), "proto.Queue")
-	golang_proto.RegisterType((*Queue)(nil), "proto.Queue")
-	proto1.RegisterEnum("proto.Queue_Store", Queue_Store_name, Queue_Store_value)
-	golang_proto.RegisterEnum("proto.Queue_Store", Queue_Store_name, Queue_Store_value)
-}
-func (x Queue_Store) String() string {
-	s, ok := Queue_Store_name[int32(x)]
-	if ok {
-		return s
+func (m *MoveMessagesRequest) GetMaxCount() int64 {
+	if m != nil {
+		return m.MaxCount
 	}
-	return strconv.Itoa(int(x))
+	return 0
 }
-func (this *NewQueueRequest) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 7)
-	s = append(s, "&proto.NewQueueRequest{")
-	s = append(s, "Store: "+fmt.Sprintf("%#v", this.Store)+",\n")
-	s = append(s, "Limit: "+fmt.Sprintf("%#v", this.Limit)+",\n")
-	if this.Tags != nil {
-		s = append(s, "Tags: "+fmt.Sprintf("%#v", this.Tags)+",\n")
+
+func (m *MoveMessagesRequest) GetFilter() []*Tag {
+	if m != nil {
+		return m.Filter
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return nil
 }
-func (this *NewQueueResponse) GoString() string {
-	if this == nil {
-		return "nil"
+
+type MoveMessagesResponse struct {
+	Moved int64 `protobuf:"varint,1,opt,name=moved,proto3" json:"moved,omitempty"`
+}
+
+func (m *MoveMessagesResponse) Reset()                    { *m = MoveMessagesResponse{} }
+func (*MoveMessagesResponse) ProtoMessage()               {}
+func (*MoveMessagesResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{33} }
+
+func (m *MoveMessagesResponse) GetMoved() int64 {
+	if m != nil {
+		return m.Moved
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&proto.NewQueueResponse{")
-	if this.Queue != nil {
-		s = append(s, "Queue: "+fmt.Sprintf("%#v", this.Queue)+",\n")
+	return 0
+}
+
+// CopyMessages copies up to max_count messages from the source queue to the
+// destination queue in queue order, leaving the source queue unchanged. A
+// max_count of zero copies all messages. When a filter is supplied only
+// messages tagged with all of its tags are copied. Each copy is given a new ID.
+type CopyMessagesRequest struct {
+	SourceQueueId      string `protobuf:"bytes,1,opt,name=source_queue_id,json=sourceQueueId,proto3" json:"source_queue_id,omitempty"`
+	DestinationQueueId string `protobuf:"bytes,2,opt,name=destination_queue_id,json=destinationQueueId,proto3" json:"destination_queue_id,omitempty"`
+	MaxCount           int64  `protobuf:"varint,3,opt,name=max_count,json=maxCount,proto3" json:"max_count,omitempty"`
+	Filter             []*Tag `protobuf:"bytes,4,rep,name=filter" json:"filter,omitempty"`
+}
+
+func (m *CopyMessagesRequest) Reset()                    { *m = CopyMessagesRequest{} }
+func (*CopyMessagesRequest) ProtoMessage()               {}
+func (*CopyMessagesRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{34} }
+
+func (m *CopyMessagesRequest) GetSourceQueueId() string {
+	if m != nil {
+		return m.SourceQueueId
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return ""
 }
-func (this *GetQueueRequest) GoString() string {
-	if this == nil {
-		return "nil"
+
+func (m *CopyMessagesRequest) GetDestinationQueueId() string {
+	if m != nil {
+		return m.DestinationQueueId
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&proto.GetQueueRequest{")
-	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return ""
 }
-func (this *GetQueueResponse) GoString() string {
-	if this == nil {
-		return "nil"
+
+func (m *CopyMessagesRequest) GetMaxCount() int64 {
+	if m != nil {
+		return m.MaxCount
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&proto.GetQueueResponse{")
-	if this.Queue != nil {
-		s = append(s, "Queue: "+fmt.Sprintf("%#v", this.Queue)+",\n")
+	return 0
+}
+
+func (m *CopyMessagesRequest) GetFilter() []*Tag {
+	if m != nil {
+		return m.Filter
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return nil
 }
-func (this *ListQueuesRequest) GoString() string {
-	if this == nil {
-		return "nil"
+
+type CopyMessagesResponse struct {
+	Copied int64 `protobuf:"varint,1,opt,name=copied,proto3" json:"copied,omitempty"`
+}
+
+func (m *CopyMessagesResponse) Reset()                    { *m = CopyMessagesResponse{} }
+func (*CopyMessagesResponse) ProtoMessage()               {}
+func (*CopyMessagesResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{35} }
+
+func (m *CopyMessagesResponse) GetCopied() int64 {
+	if m != nil {
+		return m.Copied
 	}
-	s := make([]string, 0, 4)
-	s = append(s, "&proto.ListQueuesRequest{")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return 0
 }
-func (this *ListQueuesResponse) GoString() string {
-	if this == nil {
-		return "nil"
+
+type NewTopicRequest struct {
+	Tags []*Tag `protobuf:"bytes,1,rep,name=tags" json:"tags,omitempty"`
+}
+
+func (m *NewTopicRequest) Reset()                    { *m = NewTopicRequest{} }
+func (*NewTopicRequest) ProtoMessage()               {}
+func (*NewTopicRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{36} }
+
+func (m *NewTopicRequest) GetTags() []*Tag {
+	if m != nil {
+		return m.Tags
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&proto.ListQueuesResponse{")
-	if this.Queues != nil {
-		s = append(s, "Queues: "+fmt.Sprintf("%#v", this.Queues)+",\n")
+	return nil
+}
+
+type NewTopicResponse struct {
+	Topic *Topic `protobuf:"bytes,1,opt,name=topic" json:"topic,omitempty"`
+}
+
+func (m *NewTopicResponse) Reset()                    { *m = NewTopicResponse{} }
+func (*NewTopicResponse) ProtoMessage()               {}
+func (*NewTopicResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{37} }
+
+func (m *NewTopicResponse) GetTopic() *Topic {
+	if m != nil {
+		return m.Topic
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return nil
 }
-func (this *DeleteQueueRequest) GoString() string {
-	if this == nil {
-		return "nil"
+
+type GetTopicRequest struct {
+	TopicId string `protobuf:"bytes,1,opt,name=topic_id,json=topicId,proto3" json:"topic_id,omitempty"`
+}
+
+func (m *GetTopicRequest) Reset()                    { *m = GetTopicRequest{} }
+func (*GetTopicRequest) ProtoMessage()               {}
+func (*GetTopicRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{38} }
+
+func (m *GetTopicRequest) GetTopicId() string {
+	if m != nil {
+		return m.TopicId
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&proto.DeleteQueueRequest{")
-	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return ""
 }
-func (this *DeleteQueueResponse) GoString() string {
-	if this == nil {
-		return "nil"
+
+type GetTopicResponse struct {
+	Topic *Topic `protobuf:"bytes,1,opt,name=topic" json:"topic,omitempty"`
+}
+
+func (m *GetTopicResponse) Reset()                    { *m = GetTopicResponse{} }
+func (*GetTopicResponse) ProtoMessage()               {}
+func (*GetTopicResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{39} }
+
+func (m *GetTopicResponse) GetTopic() *Topic {
+	if m != nil {
+		return m.Topic
 	}
-	s := make([]string, 0, 4)
-	s = append(s, "&proto.DeleteQueueResponse{")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return nil
 }
-func (this *AddQueueTagRequest) GoString() string {
-	if this == nil {
-		return "nil"
+
+// Topics are listed in order of creation time, then ID. A page_size of zero
+// requests the server's default page size. The page_token is the
+// next_page_token returned by a previous call to ListTopics.
+type ListTopicsRequest struct {
+	PageSize  int32  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (m *ListTopicsRequest) Reset()                    { *m = ListTopicsRequest{} }
+func (*ListTopicsRequest) ProtoMessage()               {}
+func (*ListTopicsRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{40} }
+
+func (m *ListTopicsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&proto.AddQueueTagRequest{")
-	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
-	if this.Tag != nil {
-		s = append(s, "Tag: "+fmt.Sprintf("%#v", this.Tag)+",\n")
+	return 0
+}
+
+func (m *ListTopicsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return ""
 }
-func (this *AddQueueTagResponse) GoString() string {
-	if this == nil {
-		return "nil"
+
+// The next_page_token is empty when there are no more topics to list.
+type ListTopicsResponse struct {
+	Topics        []*Topic `protobuf:"bytes,1,rep,name=topics" json:"topics,omitempty"`
+	NextPageToken string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (m *ListTopicsResponse) Reset()                    { *m = ListTopicsResponse{} }
+func (*ListTopicsResponse) ProtoMessage()               {}
+func (*ListTopicsResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{41} }
+
+func (m *ListTopicsResponse) GetTopics() []*Topic {
+	if m != nil {
+		return m.Topics
 	}
-	s := make([]string, 0, 4)
-	s = append(s, "&proto.AddQueueTagResponse{")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return nil
 }
-func (this *DeleteQueueTagRequest) GoString() string {
-	if this == nil {
-		return "nil"
+
+func (m *ListTopicsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&proto.DeleteQueueTagRequest{")
-	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
-	if this.Tag != nil {
-		s = append(s, "Tag: "+fmt.Sprintf("%#v", this.Tag)+",\n")
+	return ""
+}
+
+type DeleteTopicRequest struct {
+	TopicId string `protobuf:"bytes,1,opt,name=topic_id,json=topicId,proto3" json:"topic_id,omitempty"`
+}
+
+func (m *DeleteTopicRequest) Reset()                    { *m = DeleteTopicRequest{} }
+func (*DeleteTopicRequest) ProtoMessage()               {}
+func (*DeleteTopicRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{42} }
+
+func (m *DeleteTopicRequest) GetTopicId() string {
+	if m != nil {
+		return m.TopicId
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return ""
 }
-func (this *DeleteQueueTagResponse) GoString() string {
-	if this == nil {
-		return "nil"
+
+type DeleteTopicResponse struct {
+}
+
+func (m *DeleteTopicResponse) Reset()                    { *m = DeleteTopicResponse{} }
+func (*DeleteTopicResponse) ProtoMessage()               {}
+func (*DeleteTopicResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{43} }
+
+// Subscribing a queue that is already subscribed to the topic replaces its
+// subscription.
+type SubscribeRequest struct {
+	TopicId      string        `protobuf:"bytes,1,opt,name=topic_id,json=topicId,proto3" json:"topic_id,omitempty"`
+	Subscription *Subscription `protobuf:"bytes,2,opt,name=subscription" json:"subscription,omitempty"`
+}
+
+func (m *SubscribeRequest) Reset()                    { *m = SubscribeRequest{} }
+func (*SubscribeRequest) ProtoMessage()               {}
+func (*SubscribeRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{44} }
+
+func (m *SubscribeRequest) GetTopicId() string {
+	if m != nil {
+		return m.TopicId
 	}
-	s := make([]string, 0, 4)
-	s = append(s, "&proto.DeleteQueueTagResponse{")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return ""
 }
-func (this *AddRequest) GoString() string {
-	if this == nil {
-		return "nil"
+
+func (m *SubscribeRequest) GetSubscription() *Subscription {
+	if m != nil {
+		return m.Subscription
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&proto.AddRequest{")
-	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
-	if this.Message != nil {
-		s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
-	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return nil
 }
-func (this *AddResponse) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 5)
-	s = append(s, "&proto.AddResponse{")
-	if this.Message != nil {
-		s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
-	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+
+type SubscribeResponse struct {
+	Topic *Topic `protobuf:"bytes,1,opt,name=topic" json:"topic,omitempty"`
 }
-func (this *PopRequest) GoString() string {
-	if this == nil {
-		return "nil"
+
+func (m *SubscribeResponse) Reset()                    { *m = SubscribeResponse{} }
+func (*SubscribeResponse) ProtoMessage()               {}
+func (*SubscribeResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{45} }
+
+func (m *SubscribeResponse) GetTopic() *Topic {
+	if m != nil {
+		return m.Topic
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&proto.PopRequest{")
-	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return nil
 }
-func (this *PopResponse) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 5)
-	s = append(s, "&proto.PopResponse{")
-	if this.Message != nil {
-		s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
-	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+
+type UnsubscribeRequest struct {
+	TopicId string `protobuf:"bytes,1,opt,name=topic_id,json=topicId,proto3" json:"topic_id,omitempty"`
+	QueueId string `protobuf:"bytes,2,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
 }
-func (this *PeekRequest) GoString() string {
-	if this == nil {
-		return "nil"
+
+func (m *UnsubscribeRequest) Reset()                    { *m = UnsubscribeRequest{} }
+func (*UnsubscribeRequest) ProtoMessage()               {}
+func (*UnsubscribeRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{46} }
+
+func (m *UnsubscribeRequest) GetTopicId() string {
+	if m != nil {
+		return m.TopicId
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&proto.PeekRequest{")
-	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return ""
 }
-func (this *PeekResponse) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 5)
-	s = append(s, "&proto.PeekResponse{")
-	if this.Message != nil {
-		s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+
+func (m *UnsubscribeRequest) GetQueueId() string {
+	if m != nil {
+		return m.QueueId
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return ""
 }
-func (this *Tag) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 6)
-	s = append(s, "&proto.Tag{")
-	s = append(s, "Key: "+fmt.Sprintf("%#v", this.Key)+",\n")
-	s = append(s, "Value: "+fmt.Sprintf("%#v", this.Value)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+
+type UnsubscribeResponse struct {
 }
-func (this *Metadata) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 7)
-	s = append(s, "&proto.Metadata{")
-	s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
-	if this.Created != nil {
-		s = append(s, "Created: "+fmt.Sprintf("%#v", this.Created)+",\n")
-	}
-	if this.Tags != nil {
-		s = append(s, "Tags: "+fmt.Sprintf("%#v", this.Tags)+",\n")
-	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+
+func (m *UnsubscribeResponse) Reset()                    { *m = UnsubscribeResponse{} }
+func (*UnsubscribeResponse) ProtoMessage()               {}
+func (*UnsubscribeResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{47} }
+
+// Publish adds a copy of a message to every queue subscribed to a topic whose
+// filter the message matches. delivered is the number of queues the message was
+// added to. Each queue's copy has its own ID; the ID of the message returned in
+// the response identifies the published message, not a message in any queue,
+// and so cannot be used to get or delete a copy.
+type PublishRequest struct {
+	TopicId string      `protobuf:"bytes,1,opt,name=topic_id,json=topicId,proto3" json:"topic_id,omitempty"`
+	Message *NewMessage `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
 }
-func (this *NewMessage) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 6)
-	s = append(s, "&proto.NewMessage{")
-	if this.Tags != nil {
-		s = append(s, "Tags: "+fmt.Sprintf("%#v", this.Tags)+",\n")
+
+func (m *PublishRequest) Reset()                    { *m = PublishRequest{} }
+func (*PublishRequest) ProtoMessage()               {}
+func (*PublishRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{48} }
+
+func (m *PublishRequest) GetTopicId() string {
+	if m != nil {
+		return m.TopicId
 	}
-	s = append(s, "Payload: "+fmt.Sprintf("%#v", this.Payload)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return ""
 }
-func (this *Message) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 6)
-	s = append(s, "&proto.Message{")
-	if this.Meta != nil {
-		s = append(s, "Meta: "+fmt.Sprintf("%#v", this.Meta)+",\n")
+
+func (m *PublishRequest) GetMessage() *NewMessage {
+	if m != nil {
+		return m.Message
 	}
-	s = append(s, "Payload: "+fmt.Sprintf("%#v", this.Payload)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return nil
 }
-func (this *Queue) GoString() string {
-	if this == nil {
-		return "nil"
-	}
-	s := make([]string, 0, 6)
-	s = append(s, "&proto.Queue{")
-	if this.Meta != nil {
-		s = append(s, "Meta: "+fmt.Sprintf("%#v", this.Meta)+",\n")
+
+type PublishResponse struct {
+	Message   *Message `protobuf:"bytes,1,opt,name=message" json:"message,omitempty"`
+	Delivered int64    `protobuf:"varint,2,opt,name=delivered,proto3" json:"delivered,omitempty"`
+}
+
+func (m *PublishResponse) Reset()                    { *m = PublishResponse{} }
+func (*PublishResponse) ProtoMessage()               {}
+func (*PublishResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{49} }
+
+func (m *PublishResponse) GetMessage() *Message {
+	if m != nil {
+		return m.Message
 	}
-	s = append(s, "Store: "+fmt.Sprintf("%#v", this.Store)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return nil
 }
-func valueToGoStringQ(v interface{}, typ string) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+
+func (m *PublishResponse) GetDelivered() int64 {
+	if m != nil {
+		return m.Delivered
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+	return 0
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+// Consumer groups are only supported by MEMORY_LOG queues.
+type ListGroupsRequest struct {
+	QueueId string `protobuf:"bytes,1,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *ListGroupsRequest) Reset()                    { *m = ListGroupsRequest{} }
+func (*ListGroupsRequest) ProtoMessage()               {}
+func (*ListGroupsRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{50} }
 
-// Client API for Q service
+func (m *ListGroupsRequest) GetQueueId() string {
+	if m != nil {
+		return m.QueueId
+	}
+	return ""
+}
 
-type QClient interface {
-	ListQueues(ctx context.Context, in *ListQueuesRequest, opts ...grpc.CallOption) (*ListQueuesResponse, error)
-	NewQueue(ctx context.Context, in *NewQueueRequest, opts ...grpc.CallOption) (*NewQueueResponse, error)
-	GetQueue(ctx context.Context, in *GetQueueRequest, opts ...grpc.CallOption) (*GetQueueResponse, error)
-	DeleteQueue(ctx context.Context, in *DeleteQueueRequest, opts ...grpc.CallOption) (*DeleteQueueResponse, error)
-	AddQueueTag(ctx context.Context, in *AddQueueTagRequest, opts ...grpc.CallOption) (*AddQueueTagResponse, error)
-	DeleteQueueTag(ctx context.Context, in *DeleteQueueTagRequest, opts ...grpc.CallOption) (*DeleteQueueTagResponse, error)
-	Add(ctx context.Context, in *AddRequest, opts ...grpc.CallOption) (*AddResponse, error)
-	Pop(ctx context.Context, in *PopRequest, opts ...grpc.CallOption) (*PopResponse, error)
-	Peek(ctx context.Context, in *PeekRequest, opts ...grpc.CallOption) (*PeekResponse, error)
+type ListGroupsResponse struct {
+	Groups []*Group `protobuf:"bytes,1,rep,name=groups" json:"groups,omitempty"`
 }
 
-type qClient struct {
-	cc *grpc.ClientConn
+func (m *ListGroupsResponse) Reset()                    { *m = ListGroupsResponse{} }
+func (*ListGroupsResponse) ProtoMessage()               {}
+func (*ListGroupsResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{51} }
+
+func (m *ListGroupsResponse) GetGroups() []*Group {
+	if m != nil {
+		return m.Groups
+	}
+	return nil
 }
 
-func NewQClient(cc *grpc.ClientConn) QClient {
-	return &qClient{cc}
+// A new group reads from the oldest message retained by the log.
+type NewGroupRequest struct {
+	QueueId string `protobuf:"bytes,1,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	Group   string `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
 }
 
-func (c *qClient) ListQueues(ctx context.Context, in *ListQueuesRequest, opts ...grpc.CallOption) (*ListQueuesResponse, error) {
-	out := new(ListQueuesResponse)
-	err := grpc.Invoke(ctx, "/proto.Q/ListQueues", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *NewGroupRequest) Reset()                    { *m = NewGroupRequest{} }
+func (*NewGroupRequest) ProtoMessage()               {}
+func (*NewGroupRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{52} }
+
+func (m *NewGroupRequest) GetQueueId() string {
+	if m != nil {
+		return m.QueueId
 	}
-	return out, nil
+	return ""
 }
 
-func (c *qClient) NewQueue(ctx context.Context, in *NewQueueRequest, opts ...grpc.CallOption) (*NewQueueResponse, error) {
-	out := new(NewQueueResponse)
-	err := grpc.Invoke(ctx, "/proto.Q/NewQueue", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *NewGroupRequest) GetGroup() string {
+	if m != nil {
+		return m.Group
 	}
-	return out, nil
+	return ""
 }
 
-func (c *qClient) GetQueue(ctx context.Context, in *GetQueueRequest, opts ...grpc.CallOption) (*GetQueueResponse, error) {
-	out := new(GetQueueResponse)
-	err := grpc.Invoke(ctx, "/proto.Q/GetQueue", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type NewGroupResponse struct {
+	Group *Group `protobuf:"bytes,1,opt,name=group" json:"group,omitempty"`
 }
 
-func (c *qClient) DeleteQueue(ctx context.Context, in *DeleteQueueRequest, opts ...grpc.CallOption) (*DeleteQueueResponse, error) {
-	out := new(DeleteQueueResponse)
-	err := grpc.Invoke(ctx, "/proto.Q/DeleteQueue", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *NewGroupResponse) Reset()                    { *m = NewGroupResponse{} }
+func (*NewGroupResponse) ProtoMessage()               {}
+func (*NewGroupResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{53} }
+
+func (m *NewGroupResponse) GetGroup() *Group {
+	if m != nil {
+		return m.Group
 	}
-	return out, nil
+	return nil
 }
 
-func (c *qClient) AddQueueTag(ctx context.Context, in *AddQueueTagRequest, opts ...grpc.CallOption) (*AddQueueTagResponse, error) {
-	out := new(AddQueueTagResponse)
-	err := grpc.Invoke(ctx, "/proto.Q/AddQueueTag", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type GetGroupRequest struct {
+	QueueId string `protobuf:"bytes,1,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	Group   string `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
 }
 
-func (c *qClient) DeleteQueueTag(ctx context.Context, in *DeleteQueueTagRequest, opts ...grpc.CallOption) (*DeleteQueueTagResponse, error) {
-	out := new(DeleteQueueTagResponse)
-	err := grpc.Invoke(ctx, "/proto.Q/DeleteQueueTag", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetGroupRequest) Reset()                    { *m = GetGroupRequest{} }
+func (*GetGroupRequest) ProtoMessage()               {}
+func (*GetGroupRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{54} }
+
+func (m *GetGroupRequest) GetQueueId() string {
+	if m != nil {
+		return m.QueueId
 	}
-	return out, nil
+	return ""
 }
 
-func (c *qClient) Add(ctx context.Context, in *AddRequest, opts ...grpc.CallOption) (*AddResponse, error) {
-	out := new(AddResponse)
-	err := grpc.Invoke(ctx, "/proto.Q/Add", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetGroupRequest) GetGroup() string {
+	if m != nil {
+		return m.Group
 	}
-	return out, nil
+	return ""
 }
 
-func (c *qClient) Pop(ctx context.Context, in *PopRequest, opts ...grpc.CallOption) (*PopResponse, error) {
-	out := new(PopResponse)
-	err := grpc.Invoke(ctx, "/proto.Q/Pop", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type GetGroupResponse struct {
+	Group *Group `protobuf:"bytes,1,opt,name=group" json:"group,omitempty"`
 }
 
-func (c *qClient) Peek(ctx context.Context, in *PeekRequest, opts ...grpc.CallOption) (*PeekResponse, error) {
-	out := new(PeekResponse)
-	err := grpc.Invoke(ctx, "/proto.Q/Peek", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *GetGroupResponse) Reset()                    { *m = GetGroupResponse{} }
+func (*GetGroupResponse) ProtoMessage()               {}
+func (*GetGroupResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{55} }
+
+func (m *GetGroupResponse) GetGroup() *Group {
+	if m != nil {
+		return m.Group
 	}
-	return out, nil
+	return nil
 }
 
-// Server API for Q service
+type DeleteGroupRequest struct {
+	QueueId string `protobuf:"bytes,1,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	Group   string `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+}
 
-type QServer interface {
-	ListQueues(context.Context, *ListQueuesRequest) (*ListQueuesResponse, error)
-	NewQueue(context.Context, *NewQueueRequest) (*NewQueueResponse, error)
-	GetQueue(context.Context, *GetQueueRequest) (*GetQueueResponse, error)
-	DeleteQueue(context.Context, *DeleteQueueRequest) (*DeleteQueueResponse, error)
-	AddQueueTag(context.Context, *AddQueueTagRequest) (*AddQueueTagResponse, error)
-	DeleteQueueTag(context.Context, *DeleteQueueTagRequest) (*DeleteQueueTagResponse, error)
-	Add(context.Context, *AddRequest) (*AddResponse, error)
-	Pop(context.Context, *PopRequest) (*PopResponse, error)
-	Peek(context.Context, *PeekRequest) (*PeekResponse, error)
+func (m *DeleteGroupRequest) Reset()                    { *m = DeleteGroupRequest{} }
+func (*DeleteGroupRequest) ProtoMessage()               {}
+func (*DeleteGroupRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{56} }
+
+func (m *DeleteGroupRequest) GetQueueId() string {
+	if m != nil {
+		return m.QueueId
+	}
+	return ""
 }
 
-func RegisterQServer(s *grpc.Server, srv QServer) {
-	s.RegisterService(&_Q_serviceDesc, srv)
+func (m *DeleteGroupRequest) GetGroup() string {
+	if m != nil {
+		return m.Group
+	}
+	return ""
 }
 
-func _Q_ListQueues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListQueuesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+type DeleteGroupResponse struct {
+}
+
+func (m *DeleteGroupResponse) Reset()                    { *m = DeleteGroupResponse{} }
+func (*DeleteGroupResponse) ProtoMessage()               {}
+func (*DeleteGroupResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{57} }
+
+// Read returns up to max_count messages starting at the group's committed
+// offset, without committing it. A max_count of zero reads all messages.
+type ReadRequest struct {
+	QueueId  string `protobuf:"bytes,1,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	Group    string `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	MaxCount int64  `protobuf:"varint,3,opt,name=max_count,json=maxCount,proto3" json:"max_count,omitempty"`
+}
+
+func (m *ReadRequest) Reset()                    { *m = ReadRequest{} }
+func (*ReadRequest) ProtoMessage()               {}
+func (*ReadRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{58} }
+
+func (m *ReadRequest) GetQueueId() string {
+	if m != nil {
+		return m.QueueId
 	}
-	if interceptor == nil {
-		return srv.(QServer).ListQueues(ctx, in)
+	return ""
+}
+
+func (m *ReadRequest) GetGroup() string {
+	if m != nil {
+		return m.Group
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/proto.Q/ListQueues",
+	return ""
+}
+
+func (m *ReadRequest) GetMaxCount() int64 {
+	if m != nil {
+		return m.MaxCount
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(QServer).ListQueues(ctx, req.(*ListQueuesRequest))
+	return 0
+}
+
+// Commit next_offset once the messages have been processed.
+type ReadResponse struct {
+	Messages   []*Message `protobuf:"bytes,1,rep,name=messages" json:"messages,omitempty"`
+	NextOffset uint64     `protobuf:"varint,2,opt,name=next_offset,json=nextOffset,proto3" json:"next_offset,omitempty"`
+}
+
+func (m *ReadResponse) Reset()                    { *m = ReadResponse{} }
+func (*ReadResponse) ProtoMessage()               {}
+func (*ReadResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{59} }
+
+func (m *ReadResponse) GetMessages() []*Message {
+	if m != nil {
+		return m.Messages
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _Q_NewQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NewQueueRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *ReadResponse) GetNextOffset() uint64 {
+	if m != nil {
+		return m.NextOffset
 	}
-	if interceptor == nil {
-		return srv.(QServer).NewQueue(ctx, in)
+	return 0
+}
+
+// Commit sets the group's committed offset. Offsets may not move backward, or
+// beyond the end of the log.
+type CommitRequest struct {
+	QueueId string `protobuf:"bytes,1,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	Group   string `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	Offset  uint64 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+}
+
+func (m *CommitRequest) Reset()                    { *m = CommitRequest{} }
+func (*CommitRequest) ProtoMessage()               {}
+func (*CommitRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{60} }
+
+func (m *CommitRequest) GetQueueId() string {
+	if m != nil {
+		return m.QueueId
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/proto.Q/NewQueue",
+	return ""
+}
+
+func (m *CommitRequest) GetGroup() string {
+	if m != nil {
+		return m.Group
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(QServer).NewQueue(ctx, req.(*NewQueueRequest))
+	return ""
+}
+
+func (m *CommitRequest) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _Q_GetQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetQueueRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+type CommitResponse struct {
+	Group *Group `protobuf:"bytes,1,opt,name=group" json:"group,omitempty"`
+}
+
+func (m *CommitResponse) Reset()                    { *m = CommitResponse{} }
+func (*CommitResponse) ProtoMessage()               {}
+func (*CommitResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{61} }
+
+func (m *CommitResponse) GetGroup() *Group {
+	if m != nil {
+		return m.Group
 	}
-	if interceptor == nil {
-		return srv.(QServer).GetQueue(ctx, in)
+	return nil
+}
+
+// Seek replays messages a queue has retained after they were consumed. When a
+// group is supplied its committed offset is moved to the supplied position,
+// otherwise retained messages from that position onwards are returned to the
+// queue. The position is the supplied time if one is set, or else the offset.
+type SeekRequest struct {
+	QueueId string                      `protobuf:"bytes,1,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	Group   string                      `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	Offset  uint64                      `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Time    *google_protobuf3.Timestamp `protobuf:"bytes,4,opt,name=time" json:"time,omitempty"`
+}
+
+func (m *SeekRequest) Reset()                    { *m = SeekRequest{} }
+func (*SeekRequest) ProtoMessage()               {}
+func (*SeekRequest) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{62} }
+
+func (m *SeekRequest) GetQueueId() string {
+	if m != nil {
+		return m.QueueId
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/proto.Q/GetQueue",
+	return ""
+}
+
+func (m *SeekRequest) GetGroup() string {
+	if m != nil {
+		return m.Group
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(QServer).GetQueue(ctx, req.(*GetQueueRequest))
+	return ""
+}
+
+func (m *SeekRequest) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _Q_DeleteQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteQueueRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *SeekRequest) GetTime() *google_protobuf3.Timestamp {
+	if m != nil {
+		return m.Time
 	}
-	if interceptor == nil {
-		return srv.(QServer).DeleteQueue(ctx, in)
+	return nil
+}
+
+// replayed is the number of messages returned to the queue, while group is the
+// sought consumer group, if any.
+type SeekResponse struct {
+	Replayed int64  `protobuf:"varint,1,opt,name=replayed,proto3" json:"replayed,omitempty"`
+	Group    *Group `protobuf:"bytes,2,opt,name=group" json:"group,omitempty"`
+}
+
+func (m *SeekResponse) Reset()                    { *m = SeekResponse{} }
+func (*SeekResponse) ProtoMessage()               {}
+func (*SeekResponse) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{63} }
+
+func (m *SeekResponse) GetReplayed() int64 {
+	if m != nil {
+		return m.Replayed
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/proto.Q/DeleteQueue",
+	return 0
+}
+
+func (m *SeekResponse) GetGroup() *Group {
+	if m != nil {
+		return m.Group
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(QServer).DeleteQueue(ctx, req.(*DeleteQueueRequest))
+	return nil
+}
+
+type Tag struct {
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *Tag) Reset()                    { *m = Tag{} }
+func (*Tag) ProtoMessage()               {}
+func (*Tag) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{64} }
+
+func (m *Tag) GetKey() string {
+	if m != nil {
+		return m.Key
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _Q_AddQueueTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddQueueTagRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *Tag) GetValue() string {
+	if m != nil {
+		return m.Value
 	}
-	if interceptor == nil {
-		return srv.(QServer).AddQueueTag(ctx, in)
+	return ""
+}
+
+type Metadata struct {
+	Id      string                      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Created *google_protobuf3.Timestamp `protobuf:"bytes,2,opt,name=created" json:"created,omitempty"`
+	Tags    []*Tag                      `protobuf:"bytes,3,rep,name=tags" json:"tags,omitempty"`
+}
+
+func (m *Metadata) Reset()                    { *m = Metadata{} }
+func (*Metadata) ProtoMessage()               {}
+func (*Metadata) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{65} }
+
+func (m *Metadata) GetId() string {
+	if m != nil {
+		return m.Id
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/proto.Q/AddQueueTag",
+	return ""
+}
+
+func (m *Metadata) GetCreated() *google_protobuf3.Timestamp {
+	if m != nil {
+		return m.Created
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(QServer).AddQueueTag(ctx, req.(*AddQueueTagRequest))
+	return nil
+}
+
+func (m *Metadata) GetTags() []*Tag {
+	if m != nil {
+		return m.Tags
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _Q_DeleteQueueTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteQueueTagRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+// A NewMessage is the user-writable subset of a Message. We could use Message
+// for new messages and just ignore any ID or create times the caller sent, but
+// doing so would cause the grpc-gateway swagger spec generator to generate a
+// misleading input.
+// reply_to is the ID of the queue to which replies should be added, while
+// correlation_id associates a reply with the message it replies to.
+type NewMessage struct {
+	Tags          []*Tag `protobuf:"bytes,1,rep,name=tags" json:"tags,omitempty"`
+	Payload       []byte `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	ReplyTo       string `protobuf:"bytes,3,opt,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`
+	CorrelationId string `protobuf:"bytes,4,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+}
+
+func (m *NewMessage) Reset()                    { *m = NewMessage{} }
+func (*NewMessage) ProtoMessage()               {}
+func (*NewMessage) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{66} }
+
+func (m *NewMessage) GetTags() []*Tag {
+	if m != nil {
+		return m.Tags
 	}
-	if interceptor == nil {
-		return srv.(QServer).DeleteQueueTag(ctx, in)
+	return nil
+}
+
+func (m *NewMessage) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/proto.Q/DeleteQueueTag",
+	return nil
+}
+
+func (m *NewMessage) GetReplyTo() string {
+	if m != nil {
+		return m.ReplyTo
+	}
+	return ""
+}
+
+func (m *NewMessage) GetCorrelationId() string {
+	if m != nil {
+		return m.CorrelationId
+	}
+	return ""
+}
+
+type Message struct {
+	Meta          *Metadata `protobuf:"bytes,1,opt,name=meta" json:"meta,omitempty"`
+	Payload       []byte    `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	ReplyTo       string    `protobuf:"bytes,3,opt,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`
+	CorrelationId string    `protobuf:"bytes,4,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+}
+
+func (m *Message) Reset()                    { *m = Message{} }
+func (*Message) ProtoMessage()               {}
+func (*Message) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{67} }
+
+func (m *Message) GetMeta() *Metadata {
+	if m != nil {
+		return m.Meta
+	}
+	return nil
+}
+
+func (m *Message) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *Message) GetReplyTo() string {
+	if m != nil {
+		return m.ReplyTo
+	}
+	return ""
+}
+
+func (m *Message) GetCorrelationId() string {
+	if m != nil {
+		return m.CorrelationId
+	}
+	return ""
+}
+
+type Queue struct {
+	Meta   *Metadata    `protobuf:"bytes,1,opt,name=meta" json:"meta,omitempty"`
+	Store  Queue_Store  `protobuf:"varint,2,opt,name=store,proto3,enum=proto.Queue_Store" json:"store,omitempty"`
+	Config *QueueConfig `protobuf:"bytes,3,opt,name=config" json:"config,omitempty"`
+}
+
+func (m *Queue) Reset()                    { *m = Queue{} }
+func (*Queue) ProtoMessage()               {}
+func (*Queue) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{68} }
+
+func (m *Queue) GetMeta() *Metadata {
+	if m != nil {
+		return m.Meta
+	}
+	return nil
+}
+
+func (m *Queue) GetStore() Queue_Store {
+	if m != nil {
+		return m.Store
+	}
+	return UNKNOWN
+}
+
+func (m *Queue) GetConfig() *QueueConfig {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+// QueueConfig holds the settings of a queue that may be changed after it has
+// been created. A value of -1 means the queue is unbounded by that setting.
+// max_bytes limits the total size of the queue's message payloads, while
+// max_message_bytes limits the size of a single message payload. The overflow
+// policy determines what happens when a message is added to a full queue;
+// BLOCK holds the Add call open until there is room or its deadline passes.
+// retention is how long, measured from their creation, BOLTDB and MEMORY_LOG
+// queues retain consumed messages so that they may be replayed via Seek.
+// MEMORY_LOG queues also discard unconsumed messages older than their retention.
+// MEMORY queues reject a non-zero retention.
+// expire_after_idle is how long a queue may go without messages being added,
+// popped, peeked, or read by a consumer group before it is deleted; zero never
+// deletes it.
+type QueueConfig struct {
+	Limit           int64                      `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	MaxBytes        int64                      `protobuf:"varint,2,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	MaxMessageBytes int64                      `protobuf:"varint,3,opt,name=max_message_bytes,json=maxMessageBytes,proto3" json:"max_message_bytes,omitempty"`
+	Overflow        QueueConfig_Overflow       `protobuf:"varint,4,opt,name=overflow,proto3,enum=proto.QueueConfig_Overflow" json:"overflow,omitempty"`
+	Retention       *google_protobuf1.Duration `protobuf:"bytes,5,opt,name=retention" json:"retention,omitempty"`
+	ExpireAfterIdle *google_protobuf1.Duration `protobuf:"bytes,6,opt,name=expire_after_idle,json=expireAfterIdle" json:"expire_after_idle,omitempty"`
+}
+
+func (m *QueueConfig) Reset()                    { *m = QueueConfig{} }
+func (*QueueConfig) ProtoMessage()               {}
+func (*QueueConfig) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{69} }
+
+func (m *QueueConfig) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *QueueConfig) GetMaxBytes() int64 {
+	if m != nil {
+		return m.MaxBytes
+	}
+	return 0
+}
+
+func (m *QueueConfig) GetMaxMessageBytes() int64 {
+	if m != nil {
+		return m.MaxMessageBytes
+	}
+	return 0
+}
+
+func (m *QueueConfig) GetOverflow() QueueConfig_Overflow {
+	if m != nil {
+		return m.Overflow
+	}
+	return REJECT
+}
+
+func (m *QueueConfig) GetRetention() *google_protobuf1.Duration {
+	if m != nil {
+		return m.Retention
+	}
+	return nil
+}
+
+func (m *QueueConfig) GetExpireAfterIdle() *google_protobuf1.Duration {
+	if m != nil {
+		return m.ExpireAfterIdle
+	}
+	return nil
+}
+
+// A Subscription routes messages published to a topic to a queue. When a filter
+// is supplied only messages tagged with all of its tags are routed.
+type Subscription struct {
+	QueueId string `protobuf:"bytes,1,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	Filter  []*Tag `protobuf:"bytes,2,rep,name=filter" json:"filter,omitempty"`
+}
+
+func (m *Subscription) Reset()                    { *m = Subscription{} }
+func (*Subscription) ProtoMessage()               {}
+func (*Subscription) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{70} }
+
+func (m *Subscription) GetQueueId() string {
+	if m != nil {
+		return m.QueueId
+	}
+	return ""
+}
+
+func (m *Subscription) GetFilter() []*Tag {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+type Topic struct {
+	Meta          *Metadata       `protobuf:"bytes,1,opt,name=meta" json:"meta,omitempty"`
+	Subscriptions []*Subscription `protobuf:"bytes,2,rep,name=subscriptions" json:"subscriptions,omitempty"`
+}
+
+func (m *Topic) Reset()                    { *m = Topic{} }
+func (*Topic) ProtoMessage()               {}
+func (*Topic) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{71} }
+
+func (m *Topic) GetMeta() *Metadata {
+	if m != nil {
+		return m.Meta
+	}
+	return nil
+}
+
+func (m *Topic) GetSubscriptions() []*Subscription {
+	if m != nil {
+		return m.Subscriptions
+	}
+	return nil
+}
+
+// A Group is a consumer group reading from a MEMORY_LOG queue. offset is the offset of
+// the next message the group will read, and lag is the number of offsets
+// between it and the end of the log.
+type Group struct {
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Offset uint64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Lag    uint64 `protobuf:"varint,3,opt,name=lag,proto3" json:"lag,omitempty"`
+}
+
+func (m *Group) Reset()                    { *m = Group{} }
+func (*Group) ProtoMessage()               {}
+func (*Group) Descriptor() ([]byte, []int) { return fileDescriptorQ, []int{72} }
+
+func (m *Group) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Group) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *Group) GetLag() uint64 {
+	if m != nil {
+		return m.Lag
+	}
+	return 0
+}
+
+func init() {
+	proto1.RegisterType((*NewQueueRequest)(nil), "proto.NewQueueRequest")
+	golang_proto.RegisterType((*NewQueueRequest)(nil), "proto.NewQueueRequest")
+	proto1.RegisterType((*NewQueueResponse)(nil), "proto.NewQueueResponse")
+	golang_proto.RegisterType((*NewQueueResponse)(nil), "proto.NewQueueResponse")
+	proto1.RegisterType((*GetQueueRequest)(nil), "proto.GetQueueRequest")
+	golang_proto.RegisterType((*GetQueueRequest)(nil), "proto.GetQueueRequest")
+	proto1.RegisterType((*GetQueueResponse)(nil), "proto.GetQueueResponse")
+	golang_proto.RegisterType((*GetQueueResponse)(nil), "proto.GetQueueResponse")
+	proto1.RegisterType((*ListQueuesRequest)(nil), "proto.ListQueuesRequest")
+	golang_proto.RegisterType((*ListQueuesRequest)(nil), "proto.ListQueuesRequest")
+	proto1.RegisterType((*ListQueuesResponse)(nil), "proto.ListQueuesResponse")
+	golang_proto.RegisterType((*ListQueuesResponse)(nil), "proto.ListQueuesResponse")
+	proto1.RegisterType((*UpdateQueueRequest)(nil), "proto.UpdateQueueRequest")
+	golang_proto.RegisterType((*UpdateQueueRequest)(nil), "proto.UpdateQueueRequest")
+	proto1.RegisterType((*UpdateQueueResponse)(nil), "proto.UpdateQueueResponse")
+	golang_proto.RegisterType((*UpdateQueueResponse)(nil), "proto.UpdateQueueResponse")
+	proto1.RegisterType((*DeleteQueueRequest)(nil), "proto.DeleteQueueRequest")
+	golang_proto.RegisterType((*DeleteQueueRequest)(nil), "proto.DeleteQueueRequest")
+	proto1.RegisterType((*DeleteQueueResponse)(nil), "proto.DeleteQueueResponse")
+	golang_proto.RegisterType((*DeleteQueueResponse)(nil), "proto.DeleteQueueResponse")
+	proto1.RegisterType((*PurgeQueueRequest)(nil), "proto.PurgeQueueRequest")
+	golang_proto.RegisterType((*PurgeQueueRequest)(nil), "proto.PurgeQueueRequest")
+	proto1.RegisterType((*PurgeQueueResponse)(nil), "proto.PurgeQueueResponse")
+	golang_proto.RegisterType((*PurgeQueueResponse)(nil), "proto.PurgeQueueResponse")
+	proto1.RegisterType((*AddQueueTagRequest)(nil), "proto.AddQueueTagRequest")
+	golang_proto.RegisterType((*AddQueueTagRequest)(nil), "proto.AddQueueTagRequest")
+	proto1.RegisterType((*AddQueueTagResponse)(nil), "proto.AddQueueTagResponse")
+	golang_proto.RegisterType((*AddQueueTagResponse)(nil), "proto.AddQueueTagResponse")
+	proto1.RegisterType((*DeleteQueueTagRequest)(nil), "proto.DeleteQueueTagRequest")
+	golang_proto.RegisterType((*DeleteQueueTagRequest)(nil), "proto.DeleteQueueTagRequest")
+	proto1.RegisterType((*DeleteQueueTagResponse)(nil), "proto.DeleteQueueTagResponse")
+	golang_proto.RegisterType((*DeleteQueueTagResponse)(nil), "proto.DeleteQueueTagResponse")
+	proto1.RegisterType((*AddRequest)(nil), "proto.AddRequest")
+	golang_proto.RegisterType((*AddRequest)(nil), "proto.AddRequest")
+	proto1.RegisterType((*AddResponse)(nil), "proto.AddResponse")
+	golang_proto.RegisterType((*AddResponse)(nil), "proto.AddResponse")
+	proto1.RegisterType((*AddBatchRequest)(nil), "proto.AddBatchRequest")
+	golang_proto.RegisterType((*AddBatchRequest)(nil), "proto.AddBatchRequest")
+	proto1.RegisterType((*AddBatchResponse)(nil), "proto.AddBatchResponse")
+	golang_proto.RegisterType((*AddBatchResponse)(nil), "proto.AddBatchResponse")
+	proto1.RegisterType((*RequestRequest)(nil), "proto.RequestRequest")
+	golang_proto.RegisterType((*RequestRequest)(nil), "proto.RequestRequest")
+	proto1.RegisterType((*RequestResponse)(nil), "proto.RequestResponse")
+	golang_proto.RegisterType((*RequestResponse)(nil), "proto.RequestResponse")
+	proto1.RegisterType((*PopRequest)(nil), "proto.PopRequest")
+	golang_proto.RegisterType((*PopRequest)(nil), "proto.PopRequest")
+	proto1.RegisterType((*PopResponse)(nil), "proto.PopResponse")
+	golang_proto.RegisterType((*PopResponse)(nil), "proto.PopResponse")
+	proto1.RegisterType((*PeekRequest)(nil), "proto.PeekRequest")
+	golang_proto.RegisterType((*PeekRequest)(nil), "proto.PeekRequest")
+	proto1.RegisterType((*PeekResponse)(nil), "proto.PeekResponse")
+	golang_proto.RegisterType((*PeekResponse)(nil), "proto.PeekResponse")
+	proto1.RegisterType((*ListMessagesRequest)(nil), "proto.ListMessagesRequest")
+	golang_proto.RegisterType((*ListMessagesRequest)(nil), "proto.ListMessagesRequest")
+	proto1.RegisterType((*ListMessagesResponse)(nil), "proto.ListMessagesResponse")
+	golang_proto.RegisterType((*ListMessagesResponse)(nil), "proto.ListMessagesResponse")
+	proto1.RegisterType((*GetMessageRequest)(nil), "proto.GetMessageRequest")
+	golang_proto.RegisterType((*GetMessageRequest)(nil), "proto.GetMessageRequest")
+	proto1.RegisterType((*GetMessageResponse)(nil), "proto.GetMessageResponse")
+	golang_proto.RegisterType((*GetMessageResponse)(nil), "proto.GetMessageResponse")
+	proto1.RegisterType((*DeleteMessageRequest)(nil), "proto.DeleteMessageRequest")
+	golang_proto.RegisterType((*DeleteMessageRequest)(nil), "proto.DeleteMessageRequest")
+	proto1.RegisterType((*DeleteMessageResponse)(nil), "proto.DeleteMessageResponse")
+	golang_proto.RegisterType((*DeleteMessageResponse)(nil), "proto.DeleteMessageResponse")
+	proto1.RegisterType((*MoveMessagesRequest)(nil), "proto.MoveMessagesRequest")
+	golang_proto.RegisterType((*MoveMessagesRequest)(nil), "proto.MoveMessagesRequest")
+	proto1.RegisterType((*MoveMessagesResponse)(nil), "proto.MoveMessagesResponse")
+	golang_proto.RegisterType((*MoveMessagesResponse)(nil), "proto.MoveMessagesResponse")
+	proto1.RegisterType((*CopyMessagesRequest)(nil), "proto.CopyMessagesRequest")
+	golang_proto.RegisterType((*CopyMessagesRequest)(nil), "proto.CopyMessagesRequest")
+	proto1.RegisterType((*CopyMessagesResponse)(nil), "proto.CopyMessagesResponse")
+	golang_proto.RegisterType((*CopyMessagesResponse)(nil), "proto.CopyMessagesResponse")
+	proto1.RegisterType((*NewTopicRequest)(nil), "proto.NewTopicRequest")
+	golang_proto.RegisterType((*NewTopicRequest)(nil), "proto.NewTopicRequest")
+	proto1.RegisterType((*NewTopicResponse)(nil), "proto.NewTopicResponse")
+	golang_proto.RegisterType((*NewTopicResponse)(nil), "proto.NewTopicResponse")
+	proto1.RegisterType((*GetTopicRequest)(nil), "proto.GetTopicRequest")
+	golang_proto.RegisterType((*GetTopicRequest)(nil), "proto.GetTopicRequest")
+	proto1.RegisterType((*GetTopicResponse)(nil), "proto.GetTopicResponse")
+	golang_proto.RegisterType((*GetTopicResponse)(nil), "proto.GetTopicResponse")
+	proto1.RegisterType((*ListTopicsRequest)(nil), "proto.ListTopicsRequest")
+	golang_proto.RegisterType((*ListTopicsRequest)(nil), "proto.ListTopicsRequest")
+	proto1.RegisterType((*ListTopicsResponse)(nil), "proto.ListTopicsResponse")
+	golang_proto.RegisterType((*ListTopicsResponse)(nil), "proto.ListTopicsResponse")
+	proto1.RegisterType((*DeleteTopicRequest)(nil), "proto.DeleteTopicRequest")
+	golang_proto.RegisterType((*DeleteTopicRequest)(nil), "proto.DeleteTopicRequest")
+	proto1.RegisterType((*DeleteTopicResponse)(nil), "proto.DeleteTopicResponse")
+	golang_proto.RegisterType((*DeleteTopicResponse)(nil), "proto.DeleteTopicResponse")
+	proto1.RegisterType((*SubscribeRequest)(nil), "proto.SubscribeRequest")
+	golang_proto.RegisterType((*SubscribeRequest)(nil), "proto.SubscribeRequest")
+	proto1.RegisterType((*SubscribeResponse)(nil), "proto.SubscribeResponse")
+	golang_proto.RegisterType((*SubscribeResponse)(nil), "proto.SubscribeResponse")
+	proto1.RegisterType((*UnsubscribeRequest)(nil), "proto.UnsubscribeRequest")
+	golang_proto.RegisterType((*UnsubscribeRequest)(nil), "proto.UnsubscribeRequest")
+	proto1.RegisterType((*UnsubscribeResponse)(nil), "proto.UnsubscribeResponse")
+	golang_proto.RegisterType((*UnsubscribeResponse)(nil), "proto.UnsubscribeResponse")
+	proto1.RegisterType((*PublishRequest)(nil), "proto.PublishRequest")
+	golang_proto.RegisterType((*PublishRequest)(nil), "proto.PublishRequest")
+	proto1.RegisterType((*PublishResponse)(nil), "proto.PublishResponse")
+	golang_proto.RegisterType((*PublishResponse)(nil), "proto.PublishResponse")
+	proto1.RegisterType((*ListGroupsRequest)(nil), "proto.ListGroupsRequest")
+	golang_proto.RegisterType((*ListGroupsRequest)(nil), "proto.ListGroupsRequest")
+	proto1.RegisterType((*ListGroupsResponse)(nil), "proto.ListGroupsResponse")
+	golang_proto.RegisterType((*ListGroupsResponse)(nil), "proto.ListGroupsResponse")
+	proto1.RegisterType((*NewGroupRequest)(nil), "proto.NewGroupRequest")
+	golang_proto.RegisterType((*NewGroupRequest)(nil), "proto.NewGroupRequest")
+	proto1.RegisterType((*NewGroupResponse)(nil), "proto.NewGroupResponse")
+	golang_proto.RegisterType((*NewGroupResponse)(nil), "proto.NewGroupResponse")
+	proto1.RegisterType((*GetGroupRequest)(nil), "proto.GetGroupRequest")
+	golang_proto.RegisterType((*GetGroupRequest)(nil), "proto.GetGroupRequest")
+	proto1.RegisterType((*GetGroupResponse)(nil), "proto.GetGroupResponse")
+	golang_proto.RegisterType((*GetGroupResponse)(nil), "proto.GetGroupResponse")
+	proto1.RegisterType((*DeleteGroupRequest)(nil), "proto.DeleteGroupRequest")
+	golang_proto.RegisterType((*DeleteGroupRequest)(nil), "proto.DeleteGroupRequest")
+	proto1.RegisterType((*DeleteGroupResponse)(nil), "proto.DeleteGroupResponse")
+	golang_proto.RegisterType((*DeleteGroupResponse)(nil), "proto.DeleteGroupResponse")
+	proto1.RegisterType((*ReadRequest)(nil), "proto.ReadRequest")
+	golang_proto.RegisterType((*ReadRequest)(nil), "proto.ReadRequest")
+	proto1.RegisterType((*ReadResponse)(nil), "proto.ReadResponse")
+	golang_proto.RegisterType((*ReadResponse)(nil), "proto.ReadResponse")
+	proto1.RegisterType((*CommitRequest)(nil), "proto.CommitRequest")
+	golang_proto.RegisterType((*CommitRequest)(nil), "proto.CommitRequest")
+	proto1.RegisterType((*CommitResponse)(nil), "proto.CommitResponse")
+	golang_proto.RegisterType((*CommitResponse)(nil), "proto.CommitResponse")
+	proto1.RegisterType((*SeekRequest)(nil), "proto.SeekRequest")
+	golang_proto.RegisterType((*SeekRequest)(nil), "proto.SeekRequest")
+	proto1.RegisterType((*SeekResponse)(nil), "proto.SeekResponse")
+	golang_proto.RegisterType((*SeekResponse)(nil), "proto.SeekResponse")
+	proto1.RegisterType((*Tag)(nil), "proto.Tag")
+	golang_proto.RegisterType((*Tag)(nil), "proto.Tag")
+	proto1.RegisterType((*Metadata)(nil), "proto.Metadata")
+	golang_proto.RegisterType((*Metadata)(nil), "proto.Metadata")
+	proto1.RegisterType((*NewMessage)(nil), "proto.NewMessage")
+	golang_proto.RegisterType((*NewMessage)(nil), "proto.NewMessage")
+	proto1.RegisterType((*Message)(nil), "proto.Message")
+	golang_proto.RegisterType((*Message)(nil), "proto.Message")
+	proto1.RegisterType((*Queue)(nil), "proto.Queue")
+	golang_proto.RegisterType((*Queue)(nil), "proto.Queue")
+	proto1.RegisterType((*QueueConfig)(nil), "proto.QueueConfig")
+	golang_proto.RegisterType((*QueueConfig)(nil), "proto.QueueConfig")
+	proto1.RegisterType((*Subscription)(nil), "proto.Subscription")
+	golang_proto.RegisterType((*Subscription)(nil), "proto.Subscription")
+	proto1.RegisterType((*Topic)(nil), "proto.Topic")
+	golang_proto.RegisterType((*Topic)(nil), "proto.Topic")
+	proto1.RegisterType((*Group)(nil), "proto.Group")
+	golang_proto.RegisterType((*Group)(nil), "proto.Group")
+	proto1.RegisterEnum("proto.Queue_Store", Queue_Store_name, Queue_Store_value)
+	golang_proto.RegisterEnum("proto.Queue_Store", Queue_Store_name, Queue_Store_value)
+	proto1.RegisterEnum("proto.QueueConfig_Overflow", QueueConfig_Overflow_name, QueueConfig_Overflow_value)
+	golang_proto.RegisterEnum("proto.QueueConfig_Overflow", QueueConfig_Overflow_name, QueueConfig_Overflow_value)
+}
+func (x Queue_Store) String() string {
+	s, ok := Queue_Store_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (x QueueConfig_Overflow) String() string {
+	s, ok := QueueConfig_Overflow_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (this *NewQueueRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 13)
+	s = append(s, "&proto.NewQueueRequest{")
+	s = append(s, "Store: "+fmt.Sprintf("%#v", this.Store)+",\n")
+	s = append(s, "Limit: "+fmt.Sprintf("%#v", this.Limit)+",\n")
+	if this.Tags != nil {
+		s = append(s, "Tags: "+fmt.Sprintf("%#v", this.Tags)+",\n")
+	}
+	s = append(s, "MaxBytes: "+fmt.Sprintf("%#v", this.MaxBytes)+",\n")
+	s = append(s, "MaxMessageBytes: "+fmt.Sprintf("%#v", this.MaxMessageBytes)+",\n")
+	s = append(s, "Overflow: "+fmt.Sprintf("%#v", this.Overflow)+",\n")
+	if this.Retention != nil {
+		s = append(s, "Retention: "+fmt.Sprintf("%#v", this.Retention)+",\n")
+	}
+	s = append(s, "Temporary: "+fmt.Sprintf("%#v", this.Temporary)+",\n")
+	if this.ExpireAfterIdle != nil {
+		s = append(s, "ExpireAfterIdle: "+fmt.Sprintf("%#v", this.ExpireAfterIdle)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *NewQueueResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.NewQueueResponse{")
+	if this.Queue != nil {
+		s = append(s, "Queue: "+fmt.Sprintf("%#v", this.Queue)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GetQueueRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.GetQueueRequest{")
+	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GetQueueResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.GetQueueResponse{")
+	if this.Queue != nil {
+		s = append(s, "Queue: "+fmt.Sprintf("%#v", this.Queue)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ListQueuesRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.ListQueuesRequest{")
+	s = append(s, "PageSize: "+fmt.Sprintf("%#v", this.PageSize)+",\n")
+	s = append(s, "PageToken: "+fmt.Sprintf("%#v", this.PageToken)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ListQueuesResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.ListQueuesResponse{")
+	if this.Queues != nil {
+		s = append(s, "Queues: "+fmt.Sprintf("%#v", this.Queues)+",\n")
+	}
+	s = append(s, "NextPageToken: "+fmt.Sprintf("%#v", this.NextPageToken)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *UpdateQueueRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&proto.UpdateQueueRequest{")
+	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
+	if this.Config != nil {
+		s = append(s, "Config: "+fmt.Sprintf("%#v", this.Config)+",\n")
+	}
+	if this.UpdateMask != nil {
+		s = append(s, "UpdateMask: "+fmt.Sprintf("%#v", this.UpdateMask)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *UpdateQueueResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.UpdateQueueResponse{")
+	if this.Queue != nil {
+		s = append(s, "Queue: "+fmt.Sprintf("%#v", this.Queue)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *DeleteQueueRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.DeleteQueueRequest{")
+	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
+	s = append(s, "Force: "+fmt.Sprintf("%#v", this.Force)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *DeleteQueueResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 4)
+	s = append(s, "&proto.DeleteQueueResponse{")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PurgeQueueRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.PurgeQueueRequest{")
+	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PurgeQueueResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.PurgeQueueResponse{")
+	s = append(s, "Purged: "+fmt.Sprintf("%#v", this.Purged)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *AddQueueTagRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.AddQueueTagRequest{")
+	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
+	if this.Tag != nil {
+		s = append(s, "Tag: "+fmt.Sprintf("%#v", this.Tag)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *AddQueueTagResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 4)
+	s = append(s, "&proto.AddQueueTagResponse{")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *DeleteQueueTagRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.DeleteQueueTagRequest{")
+	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
+	if this.Tag != nil {
+		s = append(s, "Tag: "+fmt.Sprintf("%#v", this.Tag)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *DeleteQueueTagResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 4)
+	s = append(s, "&proto.DeleteQueueTagResponse{")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *AddRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.AddRequest{")
+	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
+	if this.Message != nil {
+		s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *AddResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.AddResponse{")
+	if this.Message != nil {
+		s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *AddBatchRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.AddBatchRequest{")
+	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
+	if this.Messages != nil {
+		s = append(s, "Messages: "+fmt.Sprintf("%#v", this.Messages)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *AddBatchResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.AddBatchResponse{")
+	if this.Messages != nil {
+		s = append(s, "Messages: "+fmt.Sprintf("%#v", this.Messages)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *RequestRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.RequestRequest{")
+	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
+	if this.Message != nil {
+		s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *RequestResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.RequestResponse{")
+	if this.Reply != nil {
+		s = append(s, "Reply: "+fmt.Sprintf("%#v", this.Reply)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PopRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&proto.PopRequest{")
+	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
+	if this.Filter != nil {
+		s = append(s, "Filter: "+fmt.Sprintf("%#v", this.Filter)+",\n")
+	}
+	if this.Wait != nil {
+		s = append(s, "Wait: "+fmt.Sprintf("%#v", this.Wait)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PopResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.PopResponse{")
+	if this.Message != nil {
+		s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PeekRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.PeekRequest{")
+	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
+	if this.Filter != nil {
+		s = append(s, "Filter: "+fmt.Sprintf("%#v", this.Filter)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PeekResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.PeekResponse{")
+	if this.Message != nil {
+		s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ListMessagesRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&proto.ListMessagesRequest{")
+	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
+	s = append(s, "PageSize: "+fmt.Sprintf("%#v", this.PageSize)+",\n")
+	s = append(s, "PageToken: "+fmt.Sprintf("%#v", this.PageToken)+",\n")
+	if this.Filter != nil {
+		s = append(s, "Filter: "+fmt.Sprintf("%#v", this.Filter)+",\n")
+	}
+	s = append(s, "PreviewLength: "+fmt.Sprintf("%#v", this.PreviewLength)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ListMessagesResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.ListMessagesResponse{")
+	if this.Messages != nil {
+		s = append(s, "Messages: "+fmt.Sprintf("%#v", this.Messages)+",\n")
+	}
+	s = append(s, "NextPageToken: "+fmt.Sprintf("%#v", this.NextPageToken)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GetMessageRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.GetMessageRequest{")
+	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
+	s = append(s, "MessageId: "+fmt.Sprintf("%#v", this.MessageId)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GetMessageResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.GetMessageResponse{")
+	if this.Message != nil {
+		s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *DeleteMessageRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.DeleteMessageRequest{")
+	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
+	s = append(s, "MessageId: "+fmt.Sprintf("%#v", this.MessageId)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *DeleteMessageResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 4)
+	s = append(s, "&proto.DeleteMessageResponse{")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *MoveMessagesRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&proto.MoveMessagesRequest{")
+	s = append(s, "SourceQueueId: "+fmt.Sprintf("%#v", this.SourceQueueId)+",\n")
+	s = append(s, "DestinationQueueId: "+fmt.Sprintf("%#v", this.DestinationQueueId)+",\n")
+	s = append(s, "MaxCount: "+fmt.Sprintf("%#v", this.MaxCount)+",\n")
+	if this.Filter != nil {
+		s = append(s, "Filter: "+fmt.Sprintf("%#v", this.Filter)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *MoveMessagesResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.MoveMessagesResponse{")
+	s = append(s, "Moved: "+fmt.Sprintf("%#v", this.Moved)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *CopyMessagesRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&proto.CopyMessagesRequest{")
+	s = append(s, "SourceQueueId: "+fmt.Sprintf("%#v", this.SourceQueueId)+",\n")
+	s = append(s, "DestinationQueueId: "+fmt.Sprintf("%#v", this.DestinationQueueId)+",\n")
+	s = append(s, "MaxCount: "+fmt.Sprintf("%#v", this.MaxCount)+",\n")
+	if this.Filter != nil {
+		s = append(s, "Filter: "+fmt.Sprintf("%#v", this.Filter)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *CopyMessagesResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.CopyMessagesResponse{")
+	s = append(s, "Copied: "+fmt.Sprintf("%#v", this.Copied)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *NewTopicRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.NewTopicRequest{")
+	if this.Tags != nil {
+		s = append(s, "Tags: "+fmt.Sprintf("%#v", this.Tags)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *NewTopicResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.NewTopicResponse{")
+	if this.Topic != nil {
+		s = append(s, "Topic: "+fmt.Sprintf("%#v", this.Topic)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GetTopicRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.GetTopicRequest{")
+	s = append(s, "TopicId: "+fmt.Sprintf("%#v", this.TopicId)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GetTopicResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.GetTopicResponse{")
+	if this.Topic != nil {
+		s = append(s, "Topic: "+fmt.Sprintf("%#v", this.Topic)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ListTopicsRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.ListTopicsRequest{")
+	s = append(s, "PageSize: "+fmt.Sprintf("%#v", this.PageSize)+",\n")
+	s = append(s, "PageToken: "+fmt.Sprintf("%#v", this.PageToken)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ListTopicsResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.ListTopicsResponse{")
+	if this.Topics != nil {
+		s = append(s, "Topics: "+fmt.Sprintf("%#v", this.Topics)+",\n")
+	}
+	s = append(s, "NextPageToken: "+fmt.Sprintf("%#v", this.NextPageToken)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *DeleteTopicRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.DeleteTopicRequest{")
+	s = append(s, "TopicId: "+fmt.Sprintf("%#v", this.TopicId)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *DeleteTopicResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 4)
+	s = append(s, "&proto.DeleteTopicResponse{")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *SubscribeRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.SubscribeRequest{")
+	s = append(s, "TopicId: "+fmt.Sprintf("%#v", this.TopicId)+",\n")
+	if this.Subscription != nil {
+		s = append(s, "Subscription: "+fmt.Sprintf("%#v", this.Subscription)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *SubscribeResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.SubscribeResponse{")
+	if this.Topic != nil {
+		s = append(s, "Topic: "+fmt.Sprintf("%#v", this.Topic)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *UnsubscribeRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.UnsubscribeRequest{")
+	s = append(s, "TopicId: "+fmt.Sprintf("%#v", this.TopicId)+",\n")
+	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *UnsubscribeResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 4)
+	s = append(s, "&proto.UnsubscribeResponse{")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PublishRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.PublishRequest{")
+	s = append(s, "TopicId: "+fmt.Sprintf("%#v", this.TopicId)+",\n")
+	if this.Message != nil {
+		s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PublishResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.PublishResponse{")
+	if this.Message != nil {
+		s = append(s, "Message: "+fmt.Sprintf("%#v", this.Message)+",\n")
+	}
+	s = append(s, "Delivered: "+fmt.Sprintf("%#v", this.Delivered)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ListGroupsRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.ListGroupsRequest{")
+	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ListGroupsResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.ListGroupsResponse{")
+	if this.Groups != nil {
+		s = append(s, "Groups: "+fmt.Sprintf("%#v", this.Groups)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *NewGroupRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.NewGroupRequest{")
+	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
+	s = append(s, "Group: "+fmt.Sprintf("%#v", this.Group)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *NewGroupResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.NewGroupResponse{")
+	if this.Group != nil {
+		s = append(s, "Group: "+fmt.Sprintf("%#v", this.Group)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GetGroupRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.GetGroupRequest{")
+	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
+	s = append(s, "Group: "+fmt.Sprintf("%#v", this.Group)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GetGroupResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.GetGroupResponse{")
+	if this.Group != nil {
+		s = append(s, "Group: "+fmt.Sprintf("%#v", this.Group)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *DeleteGroupRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.DeleteGroupRequest{")
+	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
+	s = append(s, "Group: "+fmt.Sprintf("%#v", this.Group)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *DeleteGroupResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 4)
+	s = append(s, "&proto.DeleteGroupResponse{")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ReadRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&proto.ReadRequest{")
+	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
+	s = append(s, "Group: "+fmt.Sprintf("%#v", this.Group)+",\n")
+	s = append(s, "MaxCount: "+fmt.Sprintf("%#v", this.MaxCount)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ReadResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.ReadResponse{")
+	if this.Messages != nil {
+		s = append(s, "Messages: "+fmt.Sprintf("%#v", this.Messages)+",\n")
+	}
+	s = append(s, "NextOffset: "+fmt.Sprintf("%#v", this.NextOffset)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *CommitRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&proto.CommitRequest{")
+	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
+	s = append(s, "Group: "+fmt.Sprintf("%#v", this.Group)+",\n")
+	s = append(s, "Offset: "+fmt.Sprintf("%#v", this.Offset)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *CommitResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&proto.CommitResponse{")
+	if this.Group != nil {
+		s = append(s, "Group: "+fmt.Sprintf("%#v", this.Group)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *SeekRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&proto.SeekRequest{")
+	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
+	s = append(s, "Group: "+fmt.Sprintf("%#v", this.Group)+",\n")
+	s = append(s, "Offset: "+fmt.Sprintf("%#v", this.Offset)+",\n")
+	if this.Time != nil {
+		s = append(s, "Time: "+fmt.Sprintf("%#v", this.Time)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *SeekResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.SeekResponse{")
+	s = append(s, "Replayed: "+fmt.Sprintf("%#v", this.Replayed)+",\n")
+	if this.Group != nil {
+		s = append(s, "Group: "+fmt.Sprintf("%#v", this.Group)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Tag) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.Tag{")
+	s = append(s, "Key: "+fmt.Sprintf("%#v", this.Key)+",\n")
+	s = append(s, "Value: "+fmt.Sprintf("%#v", this.Value)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Metadata) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&proto.Metadata{")
+	s = append(s, "Id: "+fmt.Sprintf("%#v", this.Id)+",\n")
+	if this.Created != nil {
+		s = append(s, "Created: "+fmt.Sprintf("%#v", this.Created)+",\n")
+	}
+	if this.Tags != nil {
+		s = append(s, "Tags: "+fmt.Sprintf("%#v", this.Tags)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *NewMessage) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&proto.NewMessage{")
+	if this.Tags != nil {
+		s = append(s, "Tags: "+fmt.Sprintf("%#v", this.Tags)+",\n")
+	}
+	s = append(s, "Payload: "+fmt.Sprintf("%#v", this.Payload)+",\n")
+	s = append(s, "ReplyTo: "+fmt.Sprintf("%#v", this.ReplyTo)+",\n")
+	s = append(s, "CorrelationId: "+fmt.Sprintf("%#v", this.CorrelationId)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Message) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&proto.Message{")
+	if this.Meta != nil {
+		s = append(s, "Meta: "+fmt.Sprintf("%#v", this.Meta)+",\n")
+	}
+	s = append(s, "Payload: "+fmt.Sprintf("%#v", this.Payload)+",\n")
+	s = append(s, "ReplyTo: "+fmt.Sprintf("%#v", this.ReplyTo)+",\n")
+	s = append(s, "CorrelationId: "+fmt.Sprintf("%#v", this.CorrelationId)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Queue) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&proto.Queue{")
+	if this.Meta != nil {
+		s = append(s, "Meta: "+fmt.Sprintf("%#v", this.Meta)+",\n")
+	}
+	s = append(s, "Store: "+fmt.Sprintf("%#v", this.Store)+",\n")
+	if this.Config != nil {
+		s = append(s, "Config: "+fmt.Sprintf("%#v", this.Config)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *QueueConfig) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 10)
+	s = append(s, "&proto.QueueConfig{")
+	s = append(s, "Limit: "+fmt.Sprintf("%#v", this.Limit)+",\n")
+	s = append(s, "MaxBytes: "+fmt.Sprintf("%#v", this.MaxBytes)+",\n")
+	s = append(s, "MaxMessageBytes: "+fmt.Sprintf("%#v", this.MaxMessageBytes)+",\n")
+	s = append(s, "Overflow: "+fmt.Sprintf("%#v", this.Overflow)+",\n")
+	if this.Retention != nil {
+		s = append(s, "Retention: "+fmt.Sprintf("%#v", this.Retention)+",\n")
+	}
+	if this.ExpireAfterIdle != nil {
+		s = append(s, "ExpireAfterIdle: "+fmt.Sprintf("%#v", this.ExpireAfterIdle)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Subscription) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.Subscription{")
+	s = append(s, "QueueId: "+fmt.Sprintf("%#v", this.QueueId)+",\n")
+	if this.Filter != nil {
+		s = append(s, "Filter: "+fmt.Sprintf("%#v", this.Filter)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Topic) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&proto.Topic{")
+	if this.Meta != nil {
+		s = append(s, "Meta: "+fmt.Sprintf("%#v", this.Meta)+",\n")
+	}
+	if this.Subscriptions != nil {
+		s = append(s, "Subscriptions: "+fmt.Sprintf("%#v", this.Subscriptions)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Group) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&proto.Group{")
+	s = append(s, "Name: "+fmt.Sprintf("%#v", this.Name)+",\n")
+	s = append(s, "Offset: "+fmt.Sprintf("%#v", this.Offset)+",\n")
+	s = append(s, "Lag: "+fmt.Sprintf("%#v", this.Lag)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringQ(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Q service
+
+type QClient interface {
+	ListQueues(ctx context.Context, in *ListQueuesRequest, opts ...grpc.CallOption) (*ListQueuesResponse, error)
+	NewQueue(ctx context.Context, in *NewQueueRequest, opts ...grpc.CallOption) (*NewQueueResponse, error)
+	GetQueue(ctx context.Context, in *GetQueueRequest, opts ...grpc.CallOption) (*GetQueueResponse, error)
+	UpdateQueue(ctx context.Context, in *UpdateQueueRequest, opts ...grpc.CallOption) (*UpdateQueueResponse, error)
+	DeleteQueue(ctx context.Context, in *DeleteQueueRequest, opts ...grpc.CallOption) (*DeleteQueueResponse, error)
+	PurgeQueue(ctx context.Context, in *PurgeQueueRequest, opts ...grpc.CallOption) (*PurgeQueueResponse, error)
+	AddQueueTag(ctx context.Context, in *AddQueueTagRequest, opts ...grpc.CallOption) (*AddQueueTagResponse, error)
+	DeleteQueueTag(ctx context.Context, in *DeleteQueueTagRequest, opts ...grpc.CallOption) (*DeleteQueueTagResponse, error)
+	Add(ctx context.Context, in *AddRequest, opts ...grpc.CallOption) (*AddResponse, error)
+	AddBatch(ctx context.Context, in *AddBatchRequest, opts ...grpc.CallOption) (*AddBatchResponse, error)
+	Pop(ctx context.Context, in *PopRequest, opts ...grpc.CallOption) (*PopResponse, error)
+	Peek(ctx context.Context, in *PeekRequest, opts ...grpc.CallOption) (*PeekResponse, error)
+	ListMessages(ctx context.Context, in *ListMessagesRequest, opts ...grpc.CallOption) (*ListMessagesResponse, error)
+	GetMessage(ctx context.Context, in *GetMessageRequest, opts ...grpc.CallOption) (*GetMessageResponse, error)
+	DeleteMessage(ctx context.Context, in *DeleteMessageRequest, opts ...grpc.CallOption) (*DeleteMessageResponse, error)
+	MoveMessages(ctx context.Context, in *MoveMessagesRequest, opts ...grpc.CallOption) (*MoveMessagesResponse, error)
+	CopyMessages(ctx context.Context, in *CopyMessagesRequest, opts ...grpc.CallOption) (*CopyMessagesResponse, error)
+	ListTopics(ctx context.Context, in *ListTopicsRequest, opts ...grpc.CallOption) (*ListTopicsResponse, error)
+	NewTopic(ctx context.Context, in *NewTopicRequest, opts ...grpc.CallOption) (*NewTopicResponse, error)
+	GetTopic(ctx context.Context, in *GetTopicRequest, opts ...grpc.CallOption) (*GetTopicResponse, error)
+	DeleteTopic(ctx context.Context, in *DeleteTopicRequest, opts ...grpc.CallOption) (*DeleteTopicResponse, error)
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (*SubscribeResponse, error)
+	Unsubscribe(ctx context.Context, in *UnsubscribeRequest, opts ...grpc.CallOption) (*UnsubscribeResponse, error)
+	Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*PublishResponse, error)
+	ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error)
+	NewGroup(ctx context.Context, in *NewGroupRequest, opts ...grpc.CallOption) (*NewGroupResponse, error)
+	GetGroup(ctx context.Context, in *GetGroupRequest, opts ...grpc.CallOption) (*GetGroupResponse, error)
+	DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*DeleteGroupResponse, error)
+	Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error)
+	Commit(ctx context.Context, in *CommitRequest, opts ...grpc.CallOption) (*CommitResponse, error)
+	Seek(ctx context.Context, in *SeekRequest, opts ...grpc.CallOption) (*SeekResponse, error)
+	Request(ctx context.Context, in *RequestRequest, opts ...grpc.CallOption) (*RequestResponse, error)
+}
+
+type qClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewQClient(cc *grpc.ClientConn) QClient {
+	return &qClient{cc}
+}
+
+func (c *qClient) ListQueues(ctx context.Context, in *ListQueuesRequest, opts ...grpc.CallOption) (*ListQueuesResponse, error) {
+	out := new(ListQueuesResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/ListQueues", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) NewQueue(ctx context.Context, in *NewQueueRequest, opts ...grpc.CallOption) (*NewQueueResponse, error) {
+	out := new(NewQueueResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/NewQueue", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) GetQueue(ctx context.Context, in *GetQueueRequest, opts ...grpc.CallOption) (*GetQueueResponse, error) {
+	out := new(GetQueueResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/GetQueue", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) UpdateQueue(ctx context.Context, in *UpdateQueueRequest, opts ...grpc.CallOption) (*UpdateQueueResponse, error) {
+	out := new(UpdateQueueResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/UpdateQueue", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) DeleteQueue(ctx context.Context, in *DeleteQueueRequest, opts ...grpc.CallOption) (*DeleteQueueResponse, error) {
+	out := new(DeleteQueueResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/DeleteQueue", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) PurgeQueue(ctx context.Context, in *PurgeQueueRequest, opts ...grpc.CallOption) (*PurgeQueueResponse, error) {
+	out := new(PurgeQueueResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/PurgeQueue", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) AddQueueTag(ctx context.Context, in *AddQueueTagRequest, opts ...grpc.CallOption) (*AddQueueTagResponse, error) {
+	out := new(AddQueueTagResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/AddQueueTag", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) DeleteQueueTag(ctx context.Context, in *DeleteQueueTagRequest, opts ...grpc.CallOption) (*DeleteQueueTagResponse, error) {
+	out := new(DeleteQueueTagResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/DeleteQueueTag", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) Add(ctx context.Context, in *AddRequest, opts ...grpc.CallOption) (*AddResponse, error) {
+	out := new(AddResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/Add", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) AddBatch(ctx context.Context, in *AddBatchRequest, opts ...grpc.CallOption) (*AddBatchResponse, error) {
+	out := new(AddBatchResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/AddBatch", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) Pop(ctx context.Context, in *PopRequest, opts ...grpc.CallOption) (*PopResponse, error) {
+	out := new(PopResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/Pop", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) Peek(ctx context.Context, in *PeekRequest, opts ...grpc.CallOption) (*PeekResponse, error) {
+	out := new(PeekResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/Peek", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) ListMessages(ctx context.Context, in *ListMessagesRequest, opts ...grpc.CallOption) (*ListMessagesResponse, error) {
+	out := new(ListMessagesResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/ListMessages", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) GetMessage(ctx context.Context, in *GetMessageRequest, opts ...grpc.CallOption) (*GetMessageResponse, error) {
+	out := new(GetMessageResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/GetMessage", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) DeleteMessage(ctx context.Context, in *DeleteMessageRequest, opts ...grpc.CallOption) (*DeleteMessageResponse, error) {
+	out := new(DeleteMessageResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/DeleteMessage", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) MoveMessages(ctx context.Context, in *MoveMessagesRequest, opts ...grpc.CallOption) (*MoveMessagesResponse, error) {
+	out := new(MoveMessagesResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/MoveMessages", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) CopyMessages(ctx context.Context, in *CopyMessagesRequest, opts ...grpc.CallOption) (*CopyMessagesResponse, error) {
+	out := new(CopyMessagesResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/CopyMessages", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) ListTopics(ctx context.Context, in *ListTopicsRequest, opts ...grpc.CallOption) (*ListTopicsResponse, error) {
+	out := new(ListTopicsResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/ListTopics", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) NewTopic(ctx context.Context, in *NewTopicRequest, opts ...grpc.CallOption) (*NewTopicResponse, error) {
+	out := new(NewTopicResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/NewTopic", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) GetTopic(ctx context.Context, in *GetTopicRequest, opts ...grpc.CallOption) (*GetTopicResponse, error) {
+	out := new(GetTopicResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/GetTopic", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) DeleteTopic(ctx context.Context, in *DeleteTopicRequest, opts ...grpc.CallOption) (*DeleteTopicResponse, error) {
+	out := new(DeleteTopicResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/DeleteTopic", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (*SubscribeResponse, error) {
+	out := new(SubscribeResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/Subscribe", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) Unsubscribe(ctx context.Context, in *UnsubscribeRequest, opts ...grpc.CallOption) (*UnsubscribeResponse, error) {
+	out := new(UnsubscribeResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/Unsubscribe", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*PublishResponse, error) {
+	out := new(PublishResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/Publish", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error) {
+	out := new(ListGroupsResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/ListGroups", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) NewGroup(ctx context.Context, in *NewGroupRequest, opts ...grpc.CallOption) (*NewGroupResponse, error) {
+	out := new(NewGroupResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/NewGroup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) GetGroup(ctx context.Context, in *GetGroupRequest, opts ...grpc.CallOption) (*GetGroupResponse, error) {
+	out := new(GetGroupResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/GetGroup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*DeleteGroupResponse, error) {
+	out := new(DeleteGroupResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/DeleteGroup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (*ReadResponse, error) {
+	out := new(ReadResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/Read", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) Commit(ctx context.Context, in *CommitRequest, opts ...grpc.CallOption) (*CommitResponse, error) {
+	out := new(CommitResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/Commit", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) Seek(ctx context.Context, in *SeekRequest, opts ...grpc.CallOption) (*SeekResponse, error) {
+	out := new(SeekResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/Seek", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qClient) Request(ctx context.Context, in *RequestRequest, opts ...grpc.CallOption) (*RequestResponse, error) {
+	out := new(RequestResponse)
+	err := grpc.Invoke(ctx, "/proto.Q/Request", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Q service
+
+type QServer interface {
+	ListQueues(context.Context, *ListQueuesRequest) (*ListQueuesResponse, error)
+	NewQueue(context.Context, *NewQueueRequest) (*NewQueueResponse, error)
+	GetQueue(context.Context, *GetQueueRequest) (*GetQueueResponse, error)
+	UpdateQueue(context.Context, *UpdateQueueRequest) (*UpdateQueueResponse, error)
+	DeleteQueue(context.Context, *DeleteQueueRequest) (*DeleteQueueResponse, error)
+	PurgeQueue(context.Context, *PurgeQueueRequest) (*PurgeQueueResponse, error)
+	AddQueueTag(context.Context, *AddQueueTagRequest) (*AddQueueTagResponse, error)
+	DeleteQueueTag(context.Context, *DeleteQueueTagRequest) (*DeleteQueueTagResponse, error)
+	Add(context.Context, *AddRequest) (*AddResponse, error)
+	AddBatch(context.Context, *AddBatchRequest) (*AddBatchResponse, error)
+	Pop(context.Context, *PopRequest) (*PopResponse, error)
+	Peek(context.Context, *PeekRequest) (*PeekResponse, error)
+	ListMessages(context.Context, *ListMessagesRequest) (*ListMessagesResponse, error)
+	GetMessage(context.Context, *GetMessageRequest) (*GetMessageResponse, error)
+	DeleteMessage(context.Context, *DeleteMessageRequest) (*DeleteMessageResponse, error)
+	MoveMessages(context.Context, *MoveMessagesRequest) (*MoveMessagesResponse, error)
+	CopyMessages(context.Context, *CopyMessagesRequest) (*CopyMessagesResponse, error)
+	ListTopics(context.Context, *ListTopicsRequest) (*ListTopicsResponse, error)
+	NewTopic(context.Context, *NewTopicRequest) (*NewTopicResponse, error)
+	GetTopic(context.Context, *GetTopicRequest) (*GetTopicResponse, error)
+	DeleteTopic(context.Context, *DeleteTopicRequest) (*DeleteTopicResponse, error)
+	Subscribe(context.Context, *SubscribeRequest) (*SubscribeResponse, error)
+	Unsubscribe(context.Context, *UnsubscribeRequest) (*UnsubscribeResponse, error)
+	Publish(context.Context, *PublishRequest) (*PublishResponse, error)
+	ListGroups(context.Context, *ListGroupsRequest) (*ListGroupsResponse, error)
+	NewGroup(context.Context, *NewGroupRequest) (*NewGroupResponse, error)
+	GetGroup(context.Context, *GetGroupRequest) (*GetGroupResponse, error)
+	DeleteGroup(context.Context, *DeleteGroupRequest) (*DeleteGroupResponse, error)
+	Read(context.Context, *ReadRequest) (*ReadResponse, error)
+	Commit(context.Context, *CommitRequest) (*CommitResponse, error)
+	Seek(context.Context, *SeekRequest) (*SeekResponse, error)
+	Request(context.Context, *RequestRequest) (*RequestResponse, error)
+}
+
+func RegisterQServer(s *grpc.Server, srv QServer) {
+	s.RegisterService(&_Q_serviceDesc, srv)
+}
+
+func _Q_ListQueues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListQueuesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).ListQueues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/ListQueues",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).ListQueues(ctx, req.(*ListQueuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_NewQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).NewQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/NewQueue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).NewQueue(ctx, req.(*NewQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_GetQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).GetQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/GetQueue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).GetQueue(ctx, req.(*GetQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_UpdateQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).UpdateQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/UpdateQueue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).UpdateQueue(ctx, req.(*UpdateQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_DeleteQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).DeleteQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/DeleteQueue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).DeleteQueue(ctx, req.(*DeleteQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_PurgeQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).PurgeQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/PurgeQueue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).PurgeQueue(ctx, req.(*PurgeQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_AddQueueTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddQueueTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).AddQueueTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/AddQueueTag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).AddQueueTag(ctx, req.(*AddQueueTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_DeleteQueueTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteQueueTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).DeleteQueueTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/DeleteQueueTag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).DeleteQueueTag(ctx, req.(*DeleteQueueTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_Add_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).Add(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/Add",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).Add(ctx, req.(*AddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_AddBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).AddBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/AddBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).AddBatch(ctx, req.(*AddBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_Pop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).Pop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/Pop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).Pop(ctx, req.(*PopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_Peek_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeekRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).Peek(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/Peek",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).Peek(ctx, req.(*PeekRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_ListMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).ListMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/ListMessages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).ListMessages(ctx, req.(*ListMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_GetMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).GetMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/GetMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).GetMessage(ctx, req.(*GetMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_DeleteMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).DeleteMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/DeleteMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).DeleteMessage(ctx, req.(*DeleteMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_MoveMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).MoveMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/MoveMessages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).MoveMessages(ctx, req.(*MoveMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_CopyMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).CopyMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/CopyMessages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).CopyMessages(ctx, req.(*CopyMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_ListTopics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTopicsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).ListTopics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/ListTopics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).ListTopics(ctx, req.(*ListTopicsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_NewTopic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewTopicRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).NewTopic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/NewTopic",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).NewTopic(ctx, req.(*NewTopicRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_GetTopic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopicRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).GetTopic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/GetTopic",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).GetTopic(ctx, req.(*GetTopicRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_DeleteTopic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTopicRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).DeleteTopic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/DeleteTopic",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).DeleteTopic(ctx, req.(*DeleteTopicRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_Subscribe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubscribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).Subscribe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/Subscribe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).Subscribe(ctx, req.(*SubscribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_Unsubscribe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsubscribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).Unsubscribe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/Unsubscribe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).Unsubscribe(ctx, req.(*UnsubscribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_Publish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).Publish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/Publish",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).Publish(ctx, req.(*PublishRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_ListGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).ListGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/ListGroups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).ListGroups(ctx, req.(*ListGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_NewGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).NewGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/NewGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).NewGroup(ctx, req.(*NewGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_GetGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).GetGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/GetGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).GetGroup(ctx, req.(*GetGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_DeleteGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).DeleteGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/DeleteGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).DeleteGroup(ctx, req.(*DeleteGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_Read_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).Read(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/Read",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).Read(ctx, req.(*ReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_Commit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).Commit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/Commit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).Commit(ctx, req.(*CommitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_Seek_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeekRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).Seek(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/Seek",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).Seek(ctx, req.(*SeekRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Q_Request_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QServer).Request(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Q/Request",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QServer).Request(ctx, req.(*RequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Q_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.Q",
+	HandlerType: (*QServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListQueues",
+			Handler:    _Q_ListQueues_Handler,
+		},
+		{
+			MethodName: "NewQueue",
+			Handler:    _Q_NewQueue_Handler,
+		},
+		{
+			MethodName: "GetQueue",
+			Handler:    _Q_GetQueue_Handler,
+		},
+		{
+			MethodName: "UpdateQueue",
+			Handler:    _Q_UpdateQueue_Handler,
+		},
+		{
+			MethodName: "DeleteQueue",
+			Handler:    _Q_DeleteQueue_Handler,
+		},
+		{
+			MethodName: "PurgeQueue",
+			Handler:    _Q_PurgeQueue_Handler,
+		},
+		{
+			MethodName: "AddQueueTag",
+			Handler:    _Q_AddQueueTag_Handler,
+		},
+		{
+			MethodName: "DeleteQueueTag",
+			Handler:    _Q_DeleteQueueTag_Handler,
+		},
+		{
+			MethodName: "Add",
+			Handler:    _Q_Add_Handler,
+		},
+		{
+			MethodName: "AddBatch",
+			Handler:    _Q_AddBatch_Handler,
+		},
+		{
+			MethodName: "Pop",
+			Handler:    _Q_Pop_Handler,
+		},
+		{
+			MethodName: "Peek",
+			Handler:    _Q_Peek_Handler,
+		},
+		{
+			MethodName: "ListMessages",
+			Handler:    _Q_ListMessages_Handler,
+		},
+		{
+			MethodName: "GetMessage",
+			Handler:    _Q_GetMessage_Handler,
+		},
+		{
+			MethodName: "DeleteMessage",
+			Handler:    _Q_DeleteMessage_Handler,
+		},
+		{
+			MethodName: "MoveMessages",
+			Handler:    _Q_MoveMessages_Handler,
+		},
+		{
+			MethodName: "CopyMessages",
+			Handler:    _Q_CopyMessages_Handler,
+		},
+		{
+			MethodName: "ListTopics",
+			Handler:    _Q_ListTopics_Handler,
+		},
+		{
+			MethodName: "NewTopic",
+			Handler:    _Q_NewTopic_Handler,
+		},
+		{
+			MethodName: "GetTopic",
+			Handler:    _Q_GetTopic_Handler,
+		},
+		{
+			MethodName: "DeleteTopic",
+			Handler:    _Q_DeleteTopic_Handler,
+		},
+		{
+			MethodName: "Subscribe",
+			Handler:    _Q_Subscribe_Handler,
+		},
+		{
+			MethodName: "Unsubscribe",
+			Handler:    _Q_Unsubscribe_Handler,
+		},
+		{
+			MethodName: "Publish",
+			Handler:    _Q_Publish_Handler,
+		},
+		{
+			MethodName: "ListGroups",
+			Handler:    _Q_ListGroups_Handler,
+		},
+		{
+			MethodName: "NewGroup",
+			Handler:    _Q_NewGroup_Handler,
+		},
+		{
+			MethodName: "GetGroup",
+			Handler:    _Q_GetGroup_Handler,
+		},
+		{
+			MethodName: "DeleteGroup",
+			Handler:    _Q_DeleteGroup_Handler,
+		},
+		{
+			MethodName: "Read",
+			Handler:    _Q_Read_Handler,
+		},
+		{
+			MethodName: "Commit",
+			Handler:    _Q_Commit_Handler,
+		},
+		{
+			MethodName: "Seek",
+			Handler:    _Q_Seek_Handler,
+		},
+		{
+			MethodName: "Request",
+			Handler:    _Q_Request_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "q.proto",
+}
+
+func (this *NewQueueRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&NewQueueRequest{`,
+		`Store:` + fmt.Sprintf("%v", this.Store) + `,`,
+		`Limit:` + fmt.Sprintf("%v", this.Limit) + `,`,
+		`Tags:` + strings.Replace(fmt.Sprintf("%v", this.Tags), "Tag", "Tag", 1) + `,`,
+		`MaxBytes:` + fmt.Sprintf("%v", this.MaxBytes) + `,`,
+		`MaxMessageBytes:` + fmt.Sprintf("%v", this.MaxMessageBytes) + `,`,
+		`Overflow:` + fmt.Sprintf("%v", this.Overflow) + `,`,
+		`Retention:` + strings.Replace(fmt.Sprintf("%v", this.Retention), "Duration", "google_protobuf1.Duration", 1) + `,`,
+		`Temporary:` + fmt.Sprintf("%v", this.Temporary) + `,`,
+		`ExpireAfterIdle:` + strings.Replace(fmt.Sprintf("%v", this.ExpireAfterIdle), "Duration", "google_protobuf1.Duration", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *NewQueueResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&NewQueueResponse{`,
+		`Queue:` + strings.Replace(fmt.Sprintf("%v", this.Queue), "Queue", "Queue", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GetQueueRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GetQueueRequest{`,
+		`QueueId:` + fmt.Sprintf("%v", this.QueueId) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GetQueueResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GetQueueResponse{`,
+		`Queue:` + strings.Replace(fmt.Sprintf("%v", this.Queue), "Queue", "Queue", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListQueuesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ListQueuesRequest{`,
+		`PageSize:` + fmt.Sprintf("%v", this.PageSize) + `,`,
+		`PageToken:` + fmt.Sprintf("%v", this.PageToken) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListQueuesResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ListQueuesResponse{`,
+		`Queues:` + strings.Replace(fmt.Sprintf("%v", this.Queues), "Queue", "Queue", 1) + `,`,
+		`NextPageToken:` + fmt.Sprintf("%v", this.NextPageToken) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UpdateQueueRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&UpdateQueueRequest{`,
+		`QueueId:` + fmt.Sprintf("%v", this.QueueId) + `,`,
+		`Config:` + strings.Replace(fmt.Sprintf("%v", this.Config), "QueueConfig", "QueueConfig", 1) + `,`,
+		`UpdateMask:` + strings.Replace(fmt.Sprintf("%v", this.UpdateMask), "FieldMask", "google_protobuf2.FieldMask", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UpdateQueueResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&UpdateQueueResponse{`,
+		`Queue:` + strings.Replace(fmt.Sprintf("%v", this.Queue), "Queue", "Queue", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *DeleteQueueRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&DeleteQueueRequest{`,
+		`QueueId:` + fmt.Sprintf("%v", this.QueueId) + `,`,
+		`Force:` + fmt.Sprintf("%v", this.Force) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *DeleteQueueResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&DeleteQueueResponse{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PurgeQueueRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PurgeQueueRequest{`,
+		`QueueId:` + fmt.Sprintf("%v", this.QueueId) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PurgeQueueResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PurgeQueueResponse{`,
+		`Purged:` + fmt.Sprintf("%v", this.Purged) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AddQueueTagRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AddQueueTagRequest{`,
+		`QueueId:` + fmt.Sprintf("%v", this.QueueId) + `,`,
+		`Tag:` + strings.Replace(fmt.Sprintf("%v", this.Tag), "Tag", "Tag", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AddQueueTagResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AddQueueTagResponse{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *DeleteQueueTagRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&DeleteQueueTagRequest{`,
+		`QueueId:` + fmt.Sprintf("%v", this.QueueId) + `,`,
+		`Tag:` + strings.Replace(fmt.Sprintf("%v", this.Tag), "Tag", "Tag", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *DeleteQueueTagResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&DeleteQueueTagResponse{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AddRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AddRequest{`,
+		`QueueId:` + fmt.Sprintf("%v", this.QueueId) + `,`,
+		`Message:` + strings.Replace(fmt.Sprintf("%v", this.Message), "NewMessage", "NewMessage", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AddResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AddResponse{`,
+		`Message:` + strings.Replace(fmt.Sprintf("%v", this.Message), "Message", "Message", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AddBatchRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AddBatchRequest{`,
+		`QueueId:` + fmt.Sprintf("%v", this.QueueId) + `,`,
+		`Messages:` + strings.Replace(fmt.Sprintf("%v", this.Messages), "NewMessage", "NewMessage", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *AddBatchResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&AddBatchResponse{`,
+		`Messages:` + strings.Replace(fmt.Sprintf("%v", this.Messages), "Message", "Message", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RequestRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RequestRequest{`,
+		`QueueId:` + fmt.Sprintf("%v", this.QueueId) + `,`,
+		`Message:` + strings.Replace(fmt.Sprintf("%v", this.Message), "NewMessage", "NewMessage", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RequestResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RequestResponse{`,
+		`Reply:` + strings.Replace(fmt.Sprintf("%v", this.Reply), "Message", "Message", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PopRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PopRequest{`,
+		`QueueId:` + fmt.Sprintf("%v", this.QueueId) + `,`,
+		`Filter:` + strings.Replace(fmt.Sprintf("%v", this.Filter), "Tag", "Tag", 1) + `,`,
+		`Wait:` + strings.Replace(fmt.Sprintf("%v", this.Wait), "Duration", "google_protobuf1.Duration", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PopResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PopResponse{`,
+		`Message:` + strings.Replace(fmt.Sprintf("%v", this.Message), "Message", "Message", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PeekRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PeekRequest{`,
+		`QueueId:` + fmt.Sprintf("%v", this.QueueId) + `,`,
+		`Filter:` + strings.Replace(fmt.Sprintf("%v", this.Filter), "Tag", "Tag", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *PeekResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&PeekResponse{`,
+		`Message:` + strings.Replace(fmt.Sprintf("%v", this.Message), "Message", "Message", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListMessagesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ListMessagesRequest{`,
+		`QueueId:` + fmt.Sprintf("%v", this.QueueId) + `,`,
+		`PageSize:` + fmt.Sprintf("%v", this.PageSize) + `,`,
+		`PageToken:` + fmt.Sprintf("%v", this.PageToken) + `,`,
+		`Filter:` + strings.Replace(fmt.Sprintf("%v", this.Filter), "Tag", "Tag", 1) + `,`,
+		`PreviewLength:` + fmt.Sprintf("%v", this.PreviewLength) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListMessagesResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ListMessagesResponse{`,
+		`Messages:` + strings.Replace(fmt.Sprintf("%v", this.Messages), "Message", "Message", 1) + `,`,
+		`NextPageToken:` + fmt.Sprintf("%v", this.NextPageToken) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GetMessageRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GetMessageRequest{`,
+		`QueueId:` + fmt.Sprintf("%v", this.QueueId) + `,`,
+		`MessageId:` + fmt.Sprintf("%v", this.MessageId) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GetMessageResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GetMessageResponse{`,
+		`Message:` + strings.Replace(fmt.Sprintf("%v", this.Message), "Message", "Message", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *DeleteMessageRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&DeleteMessageRequest{`,
+		`QueueId:` + fmt.Sprintf("%v", this.QueueId) + `,`,
+		`MessageId:` + fmt.Sprintf("%v", this.MessageId) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *DeleteMessageResponse) String() string {
+	if this == nil {
+		return "nil"
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(QServer).DeleteQueueTag(ctx, req.(*DeleteQueueTagRequest))
+	s := strings.Join([]string{`&DeleteMessageResponse{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *MoveMessagesRequest) String() string {
+	if this == nil {
+		return "nil"
 	}
-	return interceptor(ctx, in, info, handler)
+	s := strings.Join([]string{`&MoveMessagesRequest{`,
+		`SourceQueueId:` + fmt.Sprintf("%v", this.SourceQueueId) + `,`,
+		`DestinationQueueId:` + fmt.Sprintf("%v", this.DestinationQueueId) + `,`,
+		`MaxCount:` + fmt.Sprintf("%v", this.MaxCount) + `,`,
+		`Filter:` + strings.Replace(fmt.Sprintf("%v", this.Filter), "Tag", "Tag", 1) + `,`,
+		`}`,
+	}, "")
+	return s
 }
-
-func _Q_Add_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (this *MoveMessagesResponse) String() string {
+	if this == nil {
+		return "nil"
 	}
-	if interceptor == nil {
-		return srv.(QServer).Add(ctx, in)
+	s := strings.Join([]string{`&MoveMessagesResponse{`,
+		`Moved:` + fmt.Sprintf("%v", this.Moved) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CopyMessagesRequest) String() string {
+	if this == nil {
+		return "nil"
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/proto.Q/Add",
+	s := strings.Join([]string{`&CopyMessagesRequest{`,
+		`SourceQueueId:` + fmt.Sprintf("%v", this.SourceQueueId) + `,`,
+		`DestinationQueueId:` + fmt.Sprintf("%v", this.DestinationQueueId) + `,`,
+		`MaxCount:` + fmt.Sprintf("%v", this.MaxCount) + `,`,
+		`Filter:` + strings.Replace(fmt.Sprintf("%v", this.Filter), "Tag", "Tag", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CopyMessagesResponse) String() string {
+	if this == nil {
+		return "nil"
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(QServer).Add(ctx, req.(*AddRequest))
+	s := strings.Join([]string{`&CopyMessagesResponse{`,
+		`Copied:` + fmt.Sprintf("%v", this.Copied) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *NewTopicRequest) String() string {
+	if this == nil {
+		return "nil"
 	}
-	return interceptor(ctx, in, info, handler)
+	s := strings.Join([]string{`&NewTopicRequest{`,
+		`Tags:` + strings.Replace(fmt.Sprintf("%v", this.Tags), "Tag", "Tag", 1) + `,`,
+		`}`,
+	}, "")
+	return s
 }
-
-func _Q_Pop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PopRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (this *NewTopicResponse) String() string {
+	if this == nil {
+		return "nil"
 	}
-	if interceptor == nil {
-		return srv.(QServer).Pop(ctx, in)
+	s := strings.Join([]string{`&NewTopicResponse{`,
+		`Topic:` + strings.Replace(fmt.Sprintf("%v", this.Topic), "Topic", "Topic", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GetTopicRequest) String() string {
+	if this == nil {
+		return "nil"
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/proto.Q/Pop",
+	s := strings.Join([]string{`&GetTopicRequest{`,
+		`TopicId:` + fmt.Sprintf("%v", this.TopicId) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GetTopicResponse) String() string {
+	if this == nil {
+		return "nil"
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(QServer).Pop(ctx, req.(*PopRequest))
+	s := strings.Join([]string{`&GetTopicResponse{`,
+		`Topic:` + strings.Replace(fmt.Sprintf("%v", this.Topic), "Topic", "Topic", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListTopicsRequest) String() string {
+	if this == nil {
+		return "nil"
 	}
-	return interceptor(ctx, in, info, handler)
+	s := strings.Join([]string{`&ListTopicsRequest{`,
+		`PageSize:` + fmt.Sprintf("%v", this.PageSize) + `,`,
+		`PageToken:` + fmt.Sprintf("%v", this.PageToken) + `,`,
+		`}`,
+	}, "")
+	return s
 }
-
-func _Q_Peek_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PeekRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (this *ListTopicsResponse) String() string {
+	if this == nil {
+		return "nil"
 	}
-	if interceptor == nil {
-		return srv.(QServer).Peek(ctx, in)
+	s := strings.Join([]string{`&ListTopicsResponse{`,
+		`Topics:` + strings.Replace(fmt.Sprintf("%v", this.Topics), "Topic", "Topic", 1) + `,`,
+		`NextPageToken:` + fmt.Sprintf("%v", this.NextPageToken) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *DeleteTopicRequest) String() string {
+	if this == nil {
+		return "nil"
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/proto.Q/Peek",
+	s := strings.Join([]string{`&DeleteTopicRequest{`,
+		`TopicId:` + fmt.Sprintf("%v", this.TopicId) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *DeleteTopicResponse) String() string {
+	if this == nil {
+		return "nil"
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(QServer).Peek(ctx, req.(*PeekRequest))
+	s := strings.Join([]string{`&DeleteTopicResponse{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SubscribeRequest) String() string {
+	if this == nil {
+		return "nil"
 	}
-	return interceptor(ctx, in, info, handler)
+	s := strings.Join([]string{`&SubscribeRequest{`,
+		`TopicId:` + fmt.Sprintf("%v", this.TopicId) + `,`,
+		`Subscription:` + strings.Replace(fmt.Sprintf("%v", this.Subscription), "Subscription", "Subscription", 1) + `,`,
+		`}`,
+	}, "")
+	return s
 }
-
-var _Q_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "proto.Q",
-	HandlerType: (*QServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "ListQueues",
-			Handler:    _Q_ListQueues_Handler,
-		},
-		{
-			MethodName: "NewQueue",
-			Handler:    _Q_NewQueue_Handler,
-		},
-		{
-			MethodName: "GetQueue",
-			Handler:    _Q_GetQueue_Handler,
-		},
-		{
-			MethodName: "DeleteQueue",
-			Handler:    _Q_DeleteQueue_Handler,
-		},
-		{
-			MethodName: "AddQueueTag",
-			Handler:    _Q_AddQueueTag_Handler,
-		},
-		{
-			MethodName: "DeleteQueueTag",
-			Handler:    _Q_DeleteQueueTag_Handler,
-		},
-		{
-			MethodName: "Add",
-			Handler:    _Q_Add_Handler,
-		},
-		{
-			MethodName: "Pop",
-			Handler:    _Q_Pop_Handler,
-		},
-		{
-			MethodName: "Peek",
-			Handler:    _Q_Peek_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "q.proto",
+func (this *SubscribeResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SubscribeResponse{`,
+		`Topic:` + strings.Replace(fmt.Sprintf("%v", this.Topic), "Topic", "Topic", 1) + `,`,
+		`}`,
+	}, "")
+	return s
 }
-
-func (this *NewQueueRequest) String() string {
+func (this *UnsubscribeRequest) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&NewQueueRequest{`,
-		`Store:` + fmt.Sprintf("%v", this.Store) + `,`,
-		`Limit:` + fmt.Sprintf("%v", this.Limit) + `,`,
-		`Tags:` + strings.Replace(fmt.Sprintf("%v", this.Tags), "Tag", "Tag", 1) + `,`,
+	s := strings.Join([]string{`&UnsubscribeRequest{`,
+		`TopicId:` + fmt.Sprintf("%v", this.TopicId) + `,`,
+		`QueueId:` + fmt.Sprintf("%v", this.QueueId) + `,`,
 		`}`,
 	}, "")
 	return s
 }
-func (this *NewQueueResponse) String() string {
+func (this *UnsubscribeResponse) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&NewQueueResponse{`,
-		`Queue:` + strings.Replace(fmt.Sprintf("%v", this.Queue), "Queue", "Queue", 1) + `,`,
+	s := strings.Join([]string{`&UnsubscribeResponse{`,
 		`}`,
 	}, "")
 	return s
 }
-func (this *GetQueueRequest) String() string {
+func (this *PublishRequest) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&GetQueueRequest{`,
-		`QueueId:` + fmt.Sprintf("%v", this.QueueId) + `,`,
+	s := strings.Join([]string{`&PublishRequest{`,
+		`TopicId:` + fmt.Sprintf("%v", this.TopicId) + `,`,
+		`Message:` + strings.Replace(fmt.Sprintf("%v", this.Message), "NewMessage", "NewMessage", 1) + `,`,
 		`}`,
 	}, "")
 	return s
 }
-func (this *GetQueueResponse) String() string {
+func (this *PublishResponse) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&GetQueueResponse{`,
-		`Queue:` + strings.Replace(fmt.Sprintf("%v", this.Queue), "Queue", "Queue", 1) + `,`,
+	s := strings.Join([]string{`&PublishResponse{`,
+		`Message:` + strings.Replace(fmt.Sprintf("%v", this.Message), "Message", "Message", 1) + `,`,
+		`Delivered:` + fmt.Sprintf("%v", this.Delivered) + `,`,
 		`}`,
 	}, "")
 	return s
 }
-func (this *ListQueuesRequest) String() string {
+func (this *ListGroupsRequest) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&ListQueuesRequest{`,
+	s := strings.Join([]string{`&ListGroupsRequest{`,
+		`QueueId:` + fmt.Sprintf("%v", this.QueueId) + `,`,
 		`}`,
 	}, "")
 	return s
 }
-func (this *ListQueuesResponse) String() string {
+func (this *ListGroupsResponse) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&ListQueuesResponse{`,
-		`Queues:` + strings.Replace(fmt.Sprintf("%v", this.Queues), "Queue", "Queue", 1) + `,`,
+	s := strings.Join([]string{`&ListGroupsResponse{`,
+		`Groups:` + strings.Replace(fmt.Sprintf("%v", this.Groups), "Group", "Group", 1) + `,`,
 		`}`,
 	}, "")
 	return s
 }
-func (this *DeleteQueueRequest) String() string {
+func (this *NewGroupRequest) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&DeleteQueueRequest{`,
+	s := strings.Join([]string{`&NewGroupRequest{`,
 		`QueueId:` + fmt.Sprintf("%v", this.QueueId) + `,`,
+		`Group:` + fmt.Sprintf("%v", this.Group) + `,`,
 		`}`,
 	}, "")
 	return s
 }
-func (this *DeleteQueueResponse) String() string {
+func (this *NewGroupResponse) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&DeleteQueueResponse{`,
+	s := strings.Join([]string{`&NewGroupResponse{`,
+		`Group:` + strings.Replace(fmt.Sprintf("%v", this.Group), "Group", "Group", 1) + `,`,
 		`}`,
 	}, "")
 	return s
 }
-func (this *AddQueueTagRequest) String() string {
+func (this *GetGroupRequest) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&AddQueueTagRequest{`,
+	s := strings.Join([]string{`&GetGroupRequest{`,
 		`QueueId:` + fmt.Sprintf("%v", this.QueueId) + `,`,
-		`Tag:` + strings.Replace(fmt.Sprintf("%v", this.Tag), "Tag", "Tag", 1) + `,`,
+		`Group:` + fmt.Sprintf("%v", this.Group) + `,`,
 		`}`,
 	}, "")
 	return s
 }
-func (this *AddQueueTagResponse) String() string {
+func (this *GetGroupResponse) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&AddQueueTagResponse{`,
+	s := strings.Join([]string{`&GetGroupResponse{`,
+		`Group:` + strings.Replace(fmt.Sprintf("%v", this.Group), "Group", "Group", 1) + `,`,
 		`}`,
 	}, "")
 	return s
 }
-func (this *DeleteQueueTagRequest) String() string {
+func (this *DeleteGroupRequest) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&DeleteQueueTagRequest{`,
+	s := strings.Join([]string{`&DeleteGroupRequest{`,
 		`QueueId:` + fmt.Sprintf("%v", this.QueueId) + `,`,
-		`Tag:` + strings.Replace(fmt.Sprintf("%v", this.Tag), "Tag", "Tag", 1) + `,`,
+		`Group:` + fmt.Sprintf("%v", this.Group) + `,`,
 		`}`,
 	}, "")
 	return s
 }
-func (this *DeleteQueueTagResponse) String() string {
+func (this *DeleteGroupResponse) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&DeleteQueueTagResponse{`,
+	s := strings.Join([]string{`&DeleteGroupResponse{`,
 		`}`,
 	}, "")
 	return s
 }
-func (this *AddRequest) String() string {
+func (this *ReadRequest) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&AddRequest{`,
+	s := strings.Join([]string{`&ReadRequest{`,
 		`QueueId:` + fmt.Sprintf("%v", this.QueueId) + `,`,
-		`Message:` + strings.Replace(fmt.Sprintf("%v", this.Message), "NewMessage", "NewMessage", 1) + `,`,
+		`Group:` + fmt.Sprintf("%v", this.Group) + `,`,
+		`MaxCount:` + fmt.Sprintf("%v", this.MaxCount) + `,`,
 		`}`,
 	}, "")
 	return s
 }
-func (this *AddResponse) String() string {
+func (this *ReadResponse) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&AddResponse{`,
-		`Message:` + strings.Replace(fmt.Sprintf("%v", this.Message), "Message", "Message", 1) + `,`,
+	s := strings.Join([]string{`&ReadResponse{`,
+		`Messages:` + strings.Replace(fmt.Sprintf("%v", this.Messages), "Message", "Message", 1) + `,`,
+		`NextOffset:` + fmt.Sprintf("%v", this.NextOffset) + `,`,
 		`}`,
 	}, "")
 	return s
 }
-func (this *PopRequest) String() string {
+func (this *CommitRequest) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&PopRequest{`,
+	s := strings.Join([]string{`&CommitRequest{`,
 		`QueueId:` + fmt.Sprintf("%v", this.QueueId) + `,`,
+		`Group:` + fmt.Sprintf("%v", this.Group) + `,`,
+		`Offset:` + fmt.Sprintf("%v", this.Offset) + `,`,
 		`}`,
 	}, "")
 	return s
 }
-func (this *PopResponse) String() string {
+func (this *CommitResponse) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&PopResponse{`,
-		`Message:` + strings.Replace(fmt.Sprintf("%v", this.Message), "Message", "Message", 1) + `,`,
+	s := strings.Join([]string{`&CommitResponse{`,
+		`Group:` + strings.Replace(fmt.Sprintf("%v", this.Group), "Group", "Group", 1) + `,`,
 		`}`,
 	}, "")
 	return s
 }
-func (this *PeekRequest) String() string {
+func (this *SeekRequest) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&PeekRequest{`,
+	s := strings.Join([]string{`&SeekRequest{`,
 		`QueueId:` + fmt.Sprintf("%v", this.QueueId) + `,`,
+		`Group:` + fmt.Sprintf("%v", this.Group) + `,`,
+		`Offset:` + fmt.Sprintf("%v", this.Offset) + `,`,
+		`Time:` + strings.Replace(fmt.Sprintf("%v", this.Time), "Timestamp", "google_protobuf3.Timestamp", 1) + `,`,
 		`}`,
 	}, "")
 	return s
 }
-func (this *PeekResponse) String() string {
+func (this *SeekResponse) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&PeekResponse{`,
-		`Message:` + strings.Replace(fmt.Sprintf("%v", this.Message), "Message", "Message", 1) + `,`,
+	s := strings.Join([]string{`&SeekResponse{`,
+		`Replayed:` + fmt.Sprintf("%v", this.Replayed) + `,`,
+		`Group:` + strings.Replace(fmt.Sprintf("%v", this.Group), "Group", "Group", 1) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1357,7 +4742,7 @@ func (this *Metadata) String() string {
 	}
 	s := strings.Join([]string{`&Metadata{`,
 		`Id:` + fmt.Sprintf("%v", this.Id) + `,`,
-		`Created:` + strings.Replace(fmt.Sprintf("%v", this.Created), "Timestamp", "google_protobuf1.Timestamp", 1) + `,`,
+		`Created:` + strings.Replace(fmt.Sprintf("%v", this.Created), "Timestamp", "google_protobuf3.Timestamp", 1) + `,`,
 		`Tags:` + strings.Replace(fmt.Sprintf("%v", this.Tags), "Tag", "Tag", 1) + `,`,
 		`}`,
 	}, "")
@@ -1370,6 +4755,8 @@ func (this *NewMessage) String() string {
 	s := strings.Join([]string{`&NewMessage{`,
 		`Tags:` + strings.Replace(fmt.Sprintf("%v", this.Tags), "Tag", "Tag", 1) + `,`,
 		`Payload:` + fmt.Sprintf("%v", this.Payload) + `,`,
+		`ReplyTo:` + fmt.Sprintf("%v", this.ReplyTo) + `,`,
+		`CorrelationId:` + fmt.Sprintf("%v", this.CorrelationId) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1381,6 +4768,8 @@ func (this *Message) String() string {
 	s := strings.Join([]string{`&Message{`,
 		`Meta:` + strings.Replace(fmt.Sprintf("%v", this.Meta), "Metadata", "Metadata", 1) + `,`,
 		`Payload:` + fmt.Sprintf("%v", this.Payload) + `,`,
+		`ReplyTo:` + fmt.Sprintf("%v", this.ReplyTo) + `,`,
+		`CorrelationId:` + fmt.Sprintf("%v", this.CorrelationId) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1392,6 +4781,56 @@ func (this *Queue) String() string {
 	s := strings.Join([]string{`&Queue{`,
 		`Meta:` + strings.Replace(fmt.Sprintf("%v", this.Meta), "Metadata", "Metadata", 1) + `,`,
 		`Store:` + fmt.Sprintf("%v", this.Store) + `,`,
+		`Config:` + strings.Replace(fmt.Sprintf("%v", this.Config), "QueueConfig", "QueueConfig", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *QueueConfig) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&QueueConfig{`,
+		`Limit:` + fmt.Sprintf("%v", this.Limit) + `,`,
+		`MaxBytes:` + fmt.Sprintf("%v", this.MaxBytes) + `,`,
+		`MaxMessageBytes:` + fmt.Sprintf("%v", this.MaxMessageBytes) + `,`,
+		`Overflow:` + fmt.Sprintf("%v", this.Overflow) + `,`,
+		`Retention:` + strings.Replace(fmt.Sprintf("%v", this.Retention), "Duration", "google_protobuf1.Duration", 1) + `,`,
+		`ExpireAfterIdle:` + strings.Replace(fmt.Sprintf("%v", this.ExpireAfterIdle), "Duration", "google_protobuf1.Duration", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Subscription) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Subscription{`,
+		`QueueId:` + fmt.Sprintf("%v", this.QueueId) + `,`,
+		`Filter:` + strings.Replace(fmt.Sprintf("%v", this.Filter), "Tag", "Tag", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Topic) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Topic{`,
+		`Meta:` + strings.Replace(fmt.Sprintf("%v", this.Meta), "Metadata", "Metadata", 1) + `,`,
+		`Subscriptions:` + strings.Replace(fmt.Sprintf("%v", this.Subscriptions), "Subscription", "Subscription", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Group) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Group{`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`Offset:` + fmt.Sprintf("%v", this.Offset) + `,`,
+		`Lag:` + fmt.Sprintf("%v", this.Lag) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1409,61 +4848,169 @@ func init() { proto1.RegisterFile("q.proto", fileDescriptorQ) }
 func init() { golang_proto.RegisterFile("q.proto", fileDescriptorQ) }
 
 var fileDescriptorQ = []byte{
-	// 887 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x94, 0x4d, 0x6f, 0x1b, 0x45,
-	0x18, 0xc7, 0x77, 0xbd, 0x71, 0x9c, 0x3e, 0x8e, 0x12, 0xe7, 0x09, 0x69, 0x9d, 0x25, 0x5d, 0xc2,
-	0x80, 0xc0, 0x0a, 0xad, 0x17, 0x02, 0xa2, 0x28, 0xb7, 0x44, 0xe5, 0x4d, 0xd4, 0x4e, 0xba, 0x75,
-	0x85, 0xe0, 0x82, 0x26, 0xd9, 0x61, 0x59, 0xc5, 0xf6, 0xae, 0xb3, 0xe3, 0x56, 0x15, 0x42, 0x42,
-	0x88, 0x0f, 0x80, 0xc4, 0x97, 0xe0, 0x63, 0x70, 0xe4, 0x18, 0x89, 0x0b, 0x47, 0xe2, 0x72, 0xe0,
-	0xd8, 0x8f, 0x80, 0x76, 0x66, 0xf6, 0xcd, 0x2f, 0xd8, 0x91, 0x7a, 0xf2, 0x3e, 0x6f, 0xff, 0xdf,
-	0xbc, 0x78, 0xfe, 0x50, 0x19, 0x34, 0xc3, 0x8b, 0x80, 0x07, 0x58, 0x16, 0x3f, 0xe6, 0x5d, 0xcf,
-	0xe7, 0xdf, 0x0d, 0x4f, 0x9b, 0x67, 0x41, 0xcf, 0xf6, 0x02, 0x2f, 0xb0, 0x45, 0xfa, 0x74, 0xf8,
-	0xad, 0x88, 0x44, 0x20, 0xbe, 0xe4, 0x94, 0xb9, 0xe3, 0x05, 0x81, 0xd7, 0x65, 0x36, 0x0d, 0x7d,
-	0x9b, 0xf6, 0xfb, 0x01, 0xa7, 0xdc, 0x0f, 0xfa, 0x91, 0xaa, 0xbe, 0xa6, 0xaa, 0xa9, 0x06, 0xf7,
-	0x7b, 0x2c, 0xe2, 0xb4, 0x17, 0xca, 0x06, 0x32, 0x80, 0xf5, 0x36, 0x7b, 0xfa, 0x70, 0xc8, 0x86,
-	0xcc, 0x61, 0x83, 0x21, 0x8b, 0x38, 0x36, 0xa0, 0x1c, 0xf1, 0xe0, 0x82, 0xd5, 0xf5, 0x5d, 0xbd,
-	0xb1, 0xb6, 0x8f, 0xb2, 0xb3, 0x29, 0x7a, 0x9a, 0x8f, 0xe2, 0x8a, 0x23, 0x1b, 0xf0, 0x15, 0x28,
-	0x77, 0xfd, 0x9e, 0xcf, 0xeb, 0xa5, 0x5d, 0xbd, 0x61, 0x38, 0x32, 0x40, 0x0b, 0x96, 0x38, 0xf5,
-	0xa2, 0xba, 0xb1, 0x6b, 0x34, 0xaa, 0xfb, 0xa0, 0xc6, 0x3b, 0xd4, 0x73, 0x44, 0x9e, 0x7c, 0x08,
-	0xb5, 0x0c, 0x19, 0x85, 0x41, 0x3f, 0x62, 0x48, 0xa0, 0x3c, 0x88, 0x13, 0x82, 0x59, 0xdd, 0x5f,
-	0xcd, 0x33, 0x1d, 0x59, 0x22, 0x77, 0x60, 0xfd, 0x53, 0xc6, 0x0b, 0x4b, 0xdd, 0x86, 0x15, 0x51,
-	0xfb, 0xc6, 0x77, 0xc5, 0xe4, 0x0d, 0xa7, 0x22, 0xe2, 0xcf, 0xdd, 0x98, 0x92, 0x75, 0x5f, 0x83,
-	0xb2, 0x09, 0x1b, 0x0f, 0xfc, 0x48, 0x0e, 0x46, 0x8a, 0x43, 0x0e, 0x00, 0xf3, 0x49, 0x25, 0xf7,
-	0x26, 0x2c, 0x8b, 0x99, 0xa8, 0xae, 0x8b, 0xad, 0x16, 0xf5, 0x54, 0x8d, 0xd8, 0x80, 0xf7, 0x59,
-	0x97, 0x71, 0xb6, 0xe8, 0xca, 0xb7, 0x60, 0xb3, 0x30, 0x20, 0x69, 0xa4, 0x05, 0x78, 0xe8, 0xba,
-	0x22, 0x17, 0x9f, 0xe5, 0x5c, 0x1d, 0xdc, 0x01, 0x83, 0x53, 0x4f, 0xdc, 0x4d, 0xf1, 0x1a, 0xe2,
-	0x74, 0x4c, 0x29, 0xc8, 0x29, 0xca, 0x09, 0x6c, 0xe5, 0xe0, 0x2f, 0x03, 0x54, 0x87, 0x9b, 0xe3,
-	0x8a, 0x8a, 0xd5, 0x01, 0x38, 0x74, 0xdd, 0x05, 0x00, 0xef, 0x40, 0xa5, 0xc7, 0xa2, 0x88, 0x7a,
-	0x4c, 0x41, 0x36, 0x14, 0xa4, 0xcd, 0x9e, 0xb6, 0x64, 0xc1, 0x49, 0x3a, 0xc8, 0x3d, 0xa8, 0x0a,
-	0x55, 0x75, 0x49, 0x8d, 0x6c, 0x56, 0xde, 0xfa, 0x9a, 0x9a, 0x9d, 0x18, 0x7c, 0x1b, 0xe0, 0x24,
-	0x08, 0x17, 0xb8, 0xa0, 0x7b, 0x50, 0x15, 0x8d, 0xd7, 0x26, 0x34, 0xa0, 0x7a, 0xc2, 0xd8, 0xf9,
-	0x02, 0x88, 0x8f, 0x60, 0x55, 0x76, 0x5e, 0x9b, 0x71, 0x17, 0x8c, 0x0e, 0xf5, 0xb0, 0x06, 0xc6,
-	0x39, 0x7b, 0xa6, 0x64, 0xe3, 0xcf, 0xf8, 0xb1, 0x3e, 0xa1, 0xdd, 0xa1, 0x3c, 0xc2, 0x1b, 0x8e,
-	0x0c, 0x48, 0x08, 0x2b, 0x2d, 0xc6, 0xa9, 0x4b, 0x39, 0xc5, 0x35, 0x28, 0xa5, 0x2b, 0x29, 0xf9,
-	0x2e, 0x7e, 0x00, 0x95, 0xb3, 0x0b, 0x46, 0x39, 0x73, 0xd5, 0xb1, 0x9b, 0x4d, 0x69, 0x27, 0xcd,
-	0xc4, 0x4e, 0x9a, 0x9d, 0xc4, 0x4e, 0x9c, 0xa4, 0x75, 0xee, 0xf3, 0xff, 0x04, 0x20, 0xbb, 0xb6,
-	0xb4, 0x5b, 0x9f, 0xde, 0x8d, 0x75, 0xa8, 0x84, 0xf4, 0x59, 0x37, 0xa0, 0x72, 0x0d, 0xab, 0x4e,
-	0x12, 0x92, 0xcf, 0xa0, 0x92, 0x88, 0xbc, 0x01, 0x4b, 0x3d, 0xc6, 0xa9, 0x3a, 0x9a, 0xf5, 0xf4,
-	0x68, 0xe4, 0xbe, 0x1c, 0x51, 0xfc, 0x1f, 0xa5, 0x9f, 0x75, 0x28, 0x8b, 0x3f, 0xe7, 0x62, 0x42,
-	0xa9, 0x3f, 0x96, 0xe6, 0xf8, 0x23, 0xb9, 0x03, 0x65, 0x11, 0x63, 0x15, 0x2a, 0x8f, 0xdb, 0x5f,
-	0xb4, 0x8f, 0xbf, 0x6c, 0xd7, 0x34, 0x04, 0x58, 0x6e, 0x7d, 0xdc, 0x3a, 0x76, 0xbe, 0xaa, 0xe9,
-	0xf1, 0xf7, 0xd1, 0xf1, 0x83, 0xce, 0xfd, 0xa3, 0x5a, 0x69, 0xff, 0x72, 0x19, 0xf4, 0x87, 0xf8,
-	0x18, 0x20, 0xb3, 0x1a, 0xac, 0x2b, 0xf1, 0x09, 0x4b, 0x32, 0xb7, 0xa7, 0x54, 0xd4, 0xbb, 0xc2,
-	0x9f, 0xfe, 0xfc, 0xe7, 0xd7, 0xd2, 0x2a, 0x82, 0xfd, 0xe4, 0x3d, 0x5b, 0xba, 0x10, 0x3a, 0xb0,
-	0x92, 0x98, 0x2e, 0xde, 0xcc, 0x5e, 0x4f, 0xde, 0x93, 0xcc, 0x5b, 0x13, 0x79, 0x25, 0xb8, 0x25,
-	0x04, 0xd7, 0x49, 0x4e, 0xf0, 0x40, 0xdf, 0xc3, 0xaf, 0x61, 0x25, 0xb1, 0xd8, 0x54, 0x73, 0xcc,
-	0xa1, 0x53, 0xcd, 0x71, 0x2f, 0x26, 0xb7, 0x85, 0xe6, 0x2d, 0xdc, 0xca, 0x34, 0xed, 0xef, 0x93,
-	0xe7, 0xf0, 0x03, 0x9e, 0x41, 0x35, 0xe7, 0x1a, 0x98, 0xec, 0x76, 0xd2, 0x49, 0x4d, 0x73, 0x5a,
-	0xa9, 0x08, 0xd9, 0x9b, 0x01, 0xe9, 0x0a, 0xab, 0x48, 0x7c, 0x29, 0x85, 0x4c, 0xda, 0x6c, 0x0a,
-	0x99, 0x66, 0x99, 0x6f, 0x09, 0xc8, 0x2e, 0xd9, 0x9e, 0x0a, 0xb1, 0x39, 0xf5, 0x0e, 0x62, 0x23,
-	0xc4, 0x21, 0xac, 0x15, 0x8d, 0x10, 0x77, 0x26, 0x97, 0x9e, 0x63, 0xde, 0x9e, 0x51, 0x2d, 0x62,
-	0xf7, 0xe6, 0x61, 0x3b, 0x60, 0x1c, 0xba, 0x2e, 0x6e, 0x64, 0x3b, 0x48, 0x00, 0x98, 0x4f, 0x8d,
-	0x6d, 0x66, 0xfa, 0x89, 0x1d, 0x24, 0x36, 0x83, 0xc7, 0x60, 0x9c, 0x04, 0x61, 0xaa, 0x9a, 0x19,
-	0x67, 0xaa, 0x9a, 0xb3, 0x48, 0xf2, 0xba, 0x50, 0x7d, 0x15, 0x67, 0xac, 0x35, 0x0c, 0x42, 0x7c,
-	0x04, 0x4b, 0xb1, 0xe3, 0x61, 0x3a, 0x9e, 0x19, 0xa5, 0xb9, 0x59, 0xc8, 0x29, 0x4d, 0x22, 0x34,
-	0x77, 0xd0, 0x9c, 0xa1, 0xc9, 0xd8, 0xf9, 0xd1, 0xbb, 0x97, 0x57, 0x96, 0xf6, 0xd7, 0x95, 0xa5,
-	0xbd, 0xb8, 0xb2, 0xf4, 0x1f, 0x47, 0x96, 0xfe, 0xdb, 0xc8, 0xd2, 0xfe, 0x18, 0x59, 0xda, 0xe5,
-	0xc8, 0xd2, 0xfe, 0x1e, 0x59, 0xda, 0xbf, 0x23, 0x4b, 0x7b, 0x31, 0xb2, 0xf4, 0x5f, 0x9e, 0x5b,
-	0xda, 0xef, 0xcf, 0x2d, 0xfd, 0x74, 0x59, 0x90, 0xde, 0xff, 0x2f, 0x00, 0x00, 0xff, 0xff, 0x51,
-	0xb2, 0xb4, 0xba, 0x96, 0x09, 0x00, 0x00,
+	// 2615 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x59, 0xcd, 0x53, 0x1c, 0xc7,
+	0x15, 0x67, 0xf6, 0x03, 0x96, 0xb7, 0xc0, 0x2e, 0x0d, 0x88, 0x65, 0x40, 0x6b, 0xdc, 0xb6, 0x15,
+	0x0a, 0x8b, 0x5d, 0x1b, 0x3b, 0x56, 0xac, 0xa4, 0x5c, 0x25, 0x10, 0x51, 0xc9, 0xe6, 0x4b, 0x03,
+	0x4a, 0xe4, 0xe8, 0x40, 0x86, 0x9d, 0x66, 0x35, 0x61, 0x77, 0x67, 0x34, 0x33, 0x8b, 0x84, 0x14,
+	0x55, 0x52, 0x39, 0xa4, 0x52, 0x39, 0x25, 0x95, 0x4b, 0xfe, 0x84, 0x1c, 0x73, 0xcb, 0xd5, 0xc7,
+	0x1c, 0x5d, 0x95, 0x4b, 0x72, 0x8b, 0x70, 0x0e, 0x39, 0xba, 0x2a, 0xff, 0x40, 0xaa, 0x3f, 0x66,
+	0xa6, 0xe7, 0x03, 0x98, 0x95, 0x94, 0x43, 0x4e, 0x6c, 0xbf, 0xf7, 0xfa, 0xfd, 0x5e, 0x77, 0xbf,
+	0xe9, 0xf7, 0xfa, 0x07, 0x8c, 0x3c, 0x6e, 0xd8, 0x8e, 0xe5, 0x59, 0xa8, 0xc8, 0xfe, 0xa8, 0x2b,
+	0x6d, 0xd3, 0x7b, 0xd4, 0x3f, 0x6c, 0xb4, 0xac, 0x6e, 0xb3, 0x6d, 0xb5, 0xad, 0x26, 0x13, 0x1f,
+	0xf6, 0x8f, 0xd8, 0x88, 0x0d, 0xd8, 0x2f, 0x3e, 0x4b, 0x5d, 0x68, 0x5b, 0x56, 0xbb, 0x43, 0x9a,
+	0xba, 0x6d, 0x36, 0xf5, 0x5e, 0xcf, 0xf2, 0x74, 0xcf, 0xb4, 0x7a, 0xae, 0xd0, 0xd6, 0x85, 0x36,
+	0xf0, 0x61, 0xf4, 0x1d, 0x66, 0x20, 0xf4, 0x8b, 0x71, 0xfd, 0x91, 0x49, 0x3a, 0xc6, 0x41, 0x57,
+	0x77, 0x8f, 0x85, 0xc5, 0x5b, 0x71, 0x0b, 0xcf, 0xec, 0x12, 0xd7, 0xd3, 0xbb, 0x36, 0x37, 0xc0,
+	0xbf, 0xcf, 0x43, 0x65, 0x9b, 0x3c, 0xb9, 0xd7, 0x27, 0x7d, 0xa2, 0x91, 0xc7, 0x7d, 0xe2, 0x7a,
+	0x68, 0x09, 0x8a, 0xae, 0x67, 0x39, 0xa4, 0xa6, 0x2c, 0x2a, 0x4b, 0x13, 0xab, 0x88, 0x9b, 0x36,
+	0x98, 0x4d, 0x63, 0x8f, 0x6a, 0x34, 0x6e, 0x80, 0xa6, 0xa1, 0xd8, 0x31, 0xbb, 0xa6, 0x57, 0xcb,
+	0x2d, 0x2a, 0x4b, 0x79, 0x8d, 0x0f, 0x50, 0x1d, 0x0a, 0x9e, 0xde, 0x76, 0x6b, 0xf9, 0xc5, 0xfc,
+	0x52, 0x79, 0x15, 0xc4, 0xf4, 0x7d, 0xbd, 0xad, 0x31, 0x39, 0x9a, 0x87, 0xd1, 0xae, 0xfe, 0xf4,
+	0xe0, 0xf0, 0xd4, 0x23, 0x6e, 0xad, 0xc0, 0x66, 0x96, 0xba, 0xfa, 0xd3, 0x35, 0x3a, 0x46, 0xcb,
+	0x30, 0x49, 0x95, 0x5d, 0xe2, 0xba, 0x7a, 0x9b, 0x08, 0xa3, 0x22, 0x33, 0xaa, 0x74, 0xf5, 0xa7,
+	0x5b, 0x5c, 0xce, 0x6d, 0x6f, 0x40, 0xc9, 0x3a, 0x21, 0xce, 0x51, 0xc7, 0x7a, 0x52, 0x1b, 0x66,
+	0xb1, 0xce, 0xcb, 0xb1, 0xae, 0x5b, 0xbd, 0x23, 0xb3, 0xdd, 0xd8, 0x11, 0x26, 0x5a, 0x60, 0x8c,
+	0x6e, 0xc0, 0xa8, 0x43, 0x3c, 0xd2, 0xa3, 0x7b, 0x59, 0x1b, 0x59, 0x54, 0x96, 0xca, 0xab, 0x73,
+	0x0d, 0xbe, 0x55, 0x0d, 0x7f, 0xab, 0x1a, 0xb7, 0xc5, 0x66, 0x6b, 0xa1, 0x2d, 0x5a, 0x80, 0x51,
+	0x8f, 0x74, 0x6d, 0xcb, 0xd1, 0x9d, 0xd3, 0x5a, 0x69, 0x51, 0x59, 0x2a, 0x69, 0xa1, 0x00, 0x6d,
+	0xc0, 0x24, 0x79, 0x6a, 0x9b, 0x0e, 0x39, 0xd0, 0x8f, 0x3c, 0xe2, 0x1c, 0x98, 0x46, 0x87, 0xd4,
+	0x46, 0x2f, 0x73, 0x5f, 0xe1, 0x73, 0x6e, 0xd1, 0x29, 0x77, 0x8d, 0x0e, 0xc1, 0x9f, 0x40, 0x35,
+	0x3c, 0x12, 0xd7, 0xb6, 0x7a, 0x2e, 0x41, 0x18, 0x8a, 0x8f, 0xa9, 0x80, 0x9d, 0x49, 0x79, 0x75,
+	0x4c, 0x5e, 0xa7, 0xc6, 0x55, 0xf8, 0x3a, 0x54, 0xee, 0x10, 0x2f, 0x72, 0x94, 0x73, 0x50, 0x62,
+	0xba, 0x03, 0xd3, 0x60, 0x33, 0x47, 0xb5, 0x11, 0x36, 0xbe, 0x6b, 0x50, 0x94, 0xd0, 0x7a, 0x00,
+	0x94, 0x1d, 0x98, 0xdc, 0x34, 0x5d, 0x3e, 0xd1, 0xf5, 0x71, 0xe6, 0x61, 0xd4, 0xa6, 0xc7, 0xe5,
+	0x9a, 0xcf, 0xf8, 0xe4, 0xa2, 0x56, 0xa2, 0x82, 0x3d, 0xf3, 0x19, 0x41, 0x57, 0x01, 0x98, 0xd2,
+	0xb3, 0x8e, 0x49, 0x8f, 0xa5, 0xca, 0xa8, 0xc6, 0xcc, 0xf7, 0xa9, 0x00, 0x1f, 0x02, 0x92, 0x1d,
+	0x8a, 0x50, 0xde, 0x85, 0x61, 0x86, 0xe7, 0xd6, 0x94, 0xc5, 0x7c, 0x22, 0x16, 0xa1, 0x43, 0xd7,
+	0xa0, 0xd2, 0x23, 0x4f, 0xbd, 0x83, 0x84, 0xff, 0x71, 0x2a, 0xde, 0x0d, 0x30, 0xfe, 0xa8, 0x00,
+	0xba, 0x6f, 0x1b, 0xba, 0x47, 0x32, 0x6e, 0x0f, 0x5a, 0x86, 0xe1, 0x16, 0xcb, 0x1f, 0xe6, 0xb0,
+	0xbc, 0x8a, 0x92, 0x99, 0xa5, 0x09, 0x0b, 0xf4, 0x7d, 0x28, 0xf7, 0x99, 0x73, 0xf6, 0xe9, 0xd5,
+	0xf2, 0x6c, 0x82, 0x9a, 0x38, 0xf1, 0x1f, 0xd2, 0xaf, 0x73, 0x4b, 0x77, 0x8f, 0x35, 0xe0, 0xe6,
+	0xf4, 0x37, 0xfe, 0x14, 0xa6, 0x22, 0x91, 0x0d, 0x70, 0x14, 0x1b, 0x80, 0x6e, 0x93, 0x0e, 0xc9,
+	0xbe, 0xa8, 0x69, 0x28, 0x1e, 0x59, 0x4e, 0x8b, 0xb0, 0x35, 0x95, 0x34, 0x3e, 0xc0, 0x33, 0x30,
+	0x15, 0x71, 0xc3, 0x23, 0xc0, 0x0d, 0x98, 0xdc, 0xed, 0x3b, 0xed, 0xac, 0xce, 0xf1, 0x75, 0x40,
+	0xb2, 0xbd, 0x58, 0xc7, 0x15, 0x18, 0xb6, 0xa9, 0x94, 0x9b, 0xe7, 0x35, 0x31, 0xc2, 0x5b, 0x80,
+	0x6e, 0x19, 0x06, 0xb3, 0xa5, 0x37, 0xc3, 0xe5, 0xb1, 0x2f, 0x40, 0xde, 0xd3, 0xfd, 0xd3, 0x90,
+	0x2f, 0x15, 0x2a, 0xa6, 0x6b, 0x88, 0xb8, 0x13, 0x6b, 0xd8, 0x85, 0x19, 0x69, 0x69, 0x6f, 0x02,
+	0xa8, 0x06, 0x57, 0xe2, 0x1e, 0x05, 0xd6, 0x3e, 0xc0, 0x2d, 0xc3, 0xc8, 0x00, 0xf0, 0x3e, 0x8c,
+	0x88, 0xeb, 0x4d, 0x80, 0x4c, 0x0a, 0x90, 0x6d, 0xf2, 0x44, 0xdc, 0x6f, 0x9a, 0x6f, 0x81, 0x6f,
+	0x40, 0x99, 0x79, 0x15, 0xdb, 0xb9, 0x14, 0xce, 0xe5, 0x89, 0x31, 0x21, 0xe6, 0x26, 0x26, 0x3e,
+	0x84, 0xca, 0x2d, 0xc3, 0x58, 0xd3, 0xbd, 0xd6, 0xa3, 0x0c, 0x31, 0xad, 0x40, 0x49, 0x4c, 0x74,
+	0x6b, 0xb9, 0xc5, 0x7c, 0x7a, 0x50, 0x81, 0x09, 0xfe, 0x0c, 0xaa, 0xa1, 0x73, 0x11, 0xda, 0xb2,
+	0xe4, 0x82, 0x7f, 0xb3, 0xf1, 0xd8, 0xc2, 0xf9, 0x0f, 0x60, 0x42, 0x04, 0xf5, 0xe6, 0xf7, 0xab,
+	0x12, 0x78, 0x0e, 0xae, 0x92, 0xa2, 0x43, 0xec, 0xce, 0xe9, 0x39, 0x3b, 0xc6, 0x95, 0xf8, 0x19,
+	0xc0, 0xae, 0x65, 0x67, 0x08, 0x07, 0xc3, 0xf0, 0x91, 0xd9, 0xf1, 0x88, 0x23, 0x36, 0x4a, 0x4e,
+	0x11, 0xa1, 0x41, 0x2b, 0x50, 0x78, 0xa2, 0x9b, 0x5e, 0x2d, 0x7f, 0xd9, 0xe5, 0xcf, 0xcc, 0xe8,
+	0x21, 0x33, 0xec, 0x81, 0x0f, 0x79, 0x13, 0xca, 0xbb, 0x84, 0x1c, 0xbf, 0x99, 0xa8, 0xf1, 0xf7,
+	0x60, 0x8c, 0x7b, 0x1b, 0x38, 0x8e, 0xbf, 0x28, 0x30, 0x45, 0x2f, 0x71, 0xa1, 0x70, 0x33, 0x04,
+	0x14, 0x29, 0x19, 0xb9, 0x0b, 0x4b, 0x46, 0x3e, 0x56, 0x32, 0xa4, 0xc5, 0x14, 0xce, 0x3d, 0x82,
+	0xf7, 0x60, 0xc2, 0x76, 0xc8, 0x89, 0x49, 0x9e, 0x1c, 0x74, 0x48, 0xaf, 0xed, 0x3d, 0x62, 0x5d,
+	0x44, 0x51, 0x1b, 0x17, 0xd2, 0x4d, 0x26, 0xc4, 0x3f, 0x83, 0xe9, 0x68, 0xe0, 0x83, 0x67, 0x73,
+	0xe6, 0x2a, 0xb4, 0x05, 0x93, 0x77, 0x88, 0x0f, 0x95, 0x61, 0x8b, 0xae, 0x02, 0xf8, 0x7d, 0x90,
+	0x69, 0xf8, 0x85, 0x53, 0x48, 0xee, 0x1a, 0xf8, 0x33, 0x40, 0xb2, 0xbb, 0x81, 0x0f, 0x6d, 0x17,
+	0xa6, 0xf9, 0x55, 0xf6, 0xc6, 0x22, 0x9a, 0x85, 0x99, 0x98, 0x47, 0x71, 0x37, 0xfe, 0x59, 0x81,
+	0xa9, 0x2d, 0xeb, 0x84, 0xc4, 0xf3, 0xe3, 0x1a, 0x54, 0x5c, 0xab, 0xef, 0xb4, 0xc8, 0x41, 0x0c,
+	0x71, 0x9c, 0x8b, 0xef, 0x09, 0xdc, 0x0f, 0x60, 0xda, 0x20, 0xae, 0x67, 0xf6, 0xd8, 0x57, 0x13,
+	0x1a, 0xf3, 0x08, 0x90, 0xa4, 0xbb, 0x17, 0xa6, 0x17, 0xed, 0x23, 0x5b, 0x56, 0xbf, 0xc7, 0x3f,
+	0x43, 0xde, 0x64, 0xae, 0xd3, 0x71, 0x96, 0xfc, 0xc1, 0xd7, 0x61, 0x3a, 0x1a, 0xb1, 0xd8, 0xdf,
+	0x69, 0x28, 0x76, 0xad, 0x93, 0xa0, 0x9e, 0xf1, 0x01, 0x5b, 0xe0, 0xba, 0x65, 0x9f, 0xfe, 0x1f,
+	0x2d, 0xb0, 0x01, 0xd3, 0xd1, 0x88, 0xc3, 0x8a, 0xdd, 0xb2, 0x6c, 0x33, 0xac, 0xd8, 0x7c, 0x84,
+	0x3f, 0x64, 0x2f, 0x85, 0x7d, 0xcb, 0x36, 0x5b, 0xfe, 0xea, 0xfc, 0x4e, 0x5f, 0x49, 0xef, 0xf4,
+	0x45, 0x27, 0x2b, 0xa6, 0x84, 0x8d, 0x8d, 0x47, 0x05, 0xb1, 0xc6, 0x86, 0x1b, 0x71, 0x95, 0xe8,
+	0x64, 0x23, 0x50, 0x73, 0x50, 0x62, 0x3a, 0x29, 0x29, 0xd9, 0x38, 0xe8, 0x64, 0x07, 0x47, 0x11,
+	0x9d, 0x2c, 0x93, 0xbd, 0xc9, 0x4e, 0xd6, 0x77, 0x18, 0x76, 0xb2, 0x0c, 0x2f, 0xde, 0xc9, 0xf2,
+	0x58, 0x84, 0x2e, 0xf3, 0x1d, 0xd2, 0xf4, 0x7b, 0xbe, 0xac, 0xbb, 0x13, 0x74, 0x77, 0x91, 0x0d,
+	0xc2, 0x47, 0x50, 0xdd, 0xeb, 0x1f, 0xba, 0x2d, 0xc7, 0x3c, 0x24, 0x97, 0x7b, 0x41, 0x37, 0x60,
+	0xcc, 0xe5, 0xe6, 0x36, 0x7b, 0x34, 0xf1, 0x42, 0x3c, 0x25, 0x96, 0xb2, 0x27, 0xa9, 0xb4, 0x88,
+	0x21, 0xbe, 0x01, 0x93, 0x12, 0xce, 0x00, 0xa7, 0xf3, 0x39, 0xa0, 0xfb, 0x3d, 0x77, 0x80, 0x10,
+	0xe5, 0x6b, 0x2b, 0x17, 0x6d, 0x4d, 0x67, 0x60, 0x2a, 0xe2, 0x4b, 0xec, 0xc1, 0x03, 0x98, 0xd8,
+	0xed, 0x1f, 0x76, 0x4c, 0xf7, 0x51, 0x06, 0xf7, 0x03, 0x75, 0x21, 0x5f, 0x42, 0x25, 0xf0, 0x3c,
+	0xe8, 0xbd, 0x4c, 0x1f, 0x99, 0x06, 0xe9, 0x98, 0x27, 0xc4, 0x21, 0x86, 0x78, 0x59, 0x87, 0x02,
+	0xda, 0x96, 0xd3, 0x24, 0xbb, 0xe3, 0x58, 0x7d, 0x3b, 0x43, 0x9d, 0xc5, 0x37, 0x01, 0xc9, 0xf6,
+	0x61, 0x52, 0xb6, 0x99, 0x24, 0x96, 0x94, 0xcc, 0x4c, 0x13, 0x3a, 0xbc, 0xc6, 0x3e, 0x79, 0x2e,
+	0xcb, 0xf4, 0xba, 0x60, 0xf3, 0xc4, 0xee, 0xf3, 0x81, 0xb8, 0x03, 0x84, 0x8f, 0xf0, 0xfc, 0xb9,
+	0x65, 0xf4, 0xfc, 0xb9, 0x91, 0x98, 0xb7, 0xc6, 0xee, 0x80, 0xd7, 0xc6, 0x0e, 0x7d, 0x0c, 0x80,
+	0x1d, 0x3c, 0xac, 0x5e, 0x0f, 0x3e, 0xf8, 0xf4, 0x22, 0x11, 0xe0, 0x87, 0x50, 0xd6, 0x88, 0x6e,
+	0xbc, 0xaa, 0xdb, 0x0b, 0x6f, 0x7e, 0xfc, 0x10, 0xc6, 0xb8, 0xf3, 0x57, 0xe8, 0x63, 0xde, 0x82,
+	0x32, 0xbb, 0x83, 0xac, 0xa3, 0x23, 0x97, 0x70, 0x52, 0xa7, 0xa0, 0x01, 0x15, 0xed, 0x30, 0x09,
+	0x7e, 0x00, 0xe3, 0xeb, 0x56, 0xb7, 0x6b, 0x7a, 0xaf, 0x1c, 0xfb, 0x15, 0x18, 0x16, 0xde, 0xf3,
+	0xcc, 0xbb, 0x18, 0xe1, 0x8f, 0x61, 0xc2, 0xf7, 0x3c, 0xc0, 0x39, 0xfd, 0x5a, 0x81, 0xf2, 0x5e,
+	0xb6, 0xfe, 0x77, 0xa0, 0x70, 0x50, 0x03, 0x0a, 0x94, 0x29, 0xab, 0x15, 0xce, 0x79, 0xca, 0xef,
+	0xfb, 0x34, 0x9a, 0xc6, 0xec, 0xf0, 0x36, 0x8c, 0xed, 0xc9, 0x9d, 0xb3, 0x0a, 0x25, 0xfa, 0xaa,
+	0xd0, 0x4f, 0x83, 0x2a, 0x1a, 0x8c, 0x11, 0x96, 0x23, 0x39, 0x67, 0x61, 0x2b, 0x90, 0xdf, 0xd7,
+	0xdb, 0xa8, 0x0a, 0xf9, 0x63, 0x72, 0x2a, 0x96, 0x42, 0x7f, 0xd2, 0x65, 0x9c, 0xe8, 0x9d, 0x3e,
+	0xf1, 0x97, 0xc1, 0x06, 0xd8, 0x86, 0xd2, 0x16, 0xf1, 0x74, 0x43, 0xf7, 0x74, 0x34, 0x01, 0xb9,
+	0x60, 0xf5, 0x39, 0xd3, 0x40, 0x1f, 0xc3, 0x48, 0xcb, 0x21, 0xba, 0x27, 0xee, 0x92, 0x8b, 0x57,
+	0xe3, 0x9b, 0x5e, 0xc6, 0xe1, 0xe1, 0xdf, 0x28, 0x00, 0xe1, 0xc5, 0x77, 0x59, 0x23, 0x80, 0x6a,
+	0x30, 0x62, 0xeb, 0xa7, 0x1d, 0x4b, 0xe7, 0x41, 0x8c, 0x69, 0xfe, 0x90, 0x1e, 0x19, 0x7b, 0x7f,
+	0x1d, 0x78, 0x96, 0xe8, 0xf3, 0x47, 0xd8, 0x78, 0xdf, 0xa2, 0x1d, 0x7c, 0xcb, 0x72, 0x1c, 0xd2,
+	0xe1, 0x3d, 0x91, 0x69, 0xb0, 0xe3, 0x18, 0xd5, 0xc6, 0x25, 0xe9, 0x5d, 0x03, 0xff, 0x56, 0x81,
+	0x11, 0x3f, 0x8e, 0x77, 0xa0, 0xd0, 0x25, 0x9e, 0x2e, 0x72, 0xa6, 0x12, 0x64, 0x3a, 0xdf, 0x1b,
+	0x8d, 0x29, 0xff, 0xa7, 0xc1, 0x7c, 0xa5, 0x40, 0x91, 0x75, 0x68, 0xd9, 0x42, 0x09, 0xa8, 0xd6,
+	0xdc, 0x65, 0x54, 0x6b, 0xc8, 0x47, 0xe5, 0x2f, 0xe3, 0xa3, 0xf0, 0x0f, 0xa0, 0xc8, 0xe6, 0xa2,
+	0x32, 0x8c, 0xdc, 0xdf, 0xfe, 0x62, 0x7b, 0xe7, 0xc7, 0xdb, 0xd5, 0x21, 0x04, 0x30, 0xbc, 0xb5,
+	0xb1, 0xb5, 0xa3, 0x7d, 0x59, 0x55, 0xe8, 0xef, 0xb5, 0x9d, 0xcd, 0xfd, 0xdb, 0x6b, 0xd5, 0x1c,
+	0x9a, 0x00, 0xe0, 0xf2, 0x83, 0xcd, 0x9d, 0x3b, 0xd5, 0x3c, 0xfe, 0x47, 0x0e, 0xca, 0x92, 0xd7,
+	0x90, 0xe4, 0x55, 0x64, 0x92, 0x37, 0x42, 0xe2, 0xe6, 0xb2, 0x90, 0xb8, 0xf9, 0xcb, 0x49, 0xdc,
+	0xc2, 0x2b, 0x93, 0xb8, 0xc5, 0x01, 0x48, 0xdc, 0x54, 0x9a, 0x76, 0x78, 0x60, 0x9a, 0x76, 0x15,
+	0x4a, 0x7e, 0x54, 0x74, 0x3f, 0xb5, 0x8d, 0xcf, 0x37, 0xd6, 0xf7, 0xab, 0x43, 0xa8, 0x02, 0xe5,
+	0xdb, 0xda, 0xce, 0xee, 0xc1, 0xce, 0xe6, 0xed, 0x8d, 0xbd, 0xfd, 0xaa, 0x82, 0x46, 0xa1, 0xb8,
+	0xb6, 0xb9, 0xb3, 0xfe, 0x45, 0x35, 0x87, 0xb7, 0x60, 0x4c, 0xee, 0x95, 0x5e, 0xf7, 0xc1, 0xde,
+	0x86, 0x22, 0xeb, 0x99, 0xb2, 0x25, 0xdb, 0xa7, 0x30, 0x2e, 0xb7, 0x66, 0x3e, 0xd1, 0x93, 0xda,
+	0xc4, 0x45, 0x2d, 0xf1, 0x06, 0x14, 0xd9, 0xfd, 0x84, 0x10, 0x14, 0x7a, 0x7a, 0x97, 0x88, 0x60,
+	0xd9, 0x6f, 0xe9, 0x12, 0xcd, 0x45, 0x2e, 0xd1, 0x2a, 0xe4, 0x3b, 0x7a, 0x5b, 0xdc, 0xac, 0xf4,
+	0xe7, 0xea, 0x7f, 0xe6, 0x40, 0xb9, 0x87, 0xee, 0x03, 0x84, 0x84, 0x2f, 0xaa, 0x09, 0xf8, 0x04,
+	0xa9, 0xac, 0xce, 0xa5, 0x68, 0x44, 0x09, 0x45, 0xbf, 0xfa, 0xdb, 0xbf, 0xfe, 0x90, 0x1b, 0x43,
+	0xd0, 0x3c, 0xf9, 0xb0, 0x29, 0xb8, 0x60, 0x0d, 0x4a, 0x3e, 0x6d, 0x8e, 0xae, 0x84, 0xbd, 0x99,
+	0x4c, 0x5f, 0xaa, 0xb3, 0x09, 0xb9, 0x70, 0x38, 0xc3, 0x1c, 0x56, 0xb0, 0xe4, 0xf0, 0xa6, 0xb2,
+	0x8c, 0x7e, 0x02, 0x25, 0x9f, 0x24, 0x0f, 0x7c, 0xc6, 0x38, 0x76, 0x75, 0x36, 0x21, 0x17, 0x3e,
+	0xaf, 0x32, 0x9f, 0xb3, 0x68, 0x26, 0xf4, 0xd9, 0x7c, 0xee, 0x1f, 0xf7, 0x0b, 0x74, 0x0c, 0x65,
+	0x89, 0xf8, 0x45, 0xfe, 0x6a, 0x93, 0x34, 0xb5, 0xaa, 0xa6, 0xa9, 0x04, 0xc8, 0x7b, 0x0c, 0xe4,
+	0xad, 0xd5, 0x74, 0x90, 0x9b, 0x3e, 0x45, 0xdd, 0x82, 0xb2, 0x44, 0x5b, 0x06, 0x60, 0x49, 0xfa,
+	0x58, 0x55, 0xd3, 0x54, 0xd1, 0x15, 0x2d, 0x9f, 0xb3, 0x22, 0x13, 0x20, 0x64, 0x80, 0x83, 0x83,
+	0x4d, 0x90, 0xc8, 0xea, 0x5c, 0x8a, 0x46, 0x20, 0x5c, 0x63, 0x08, 0x8b, 0x78, 0x3e, 0x15, 0xa1,
+	0xc9, 0xc8, 0x63, 0x7a, 0x30, 0x1d, 0x46, 0x8b, 0xfa, 0x1c, 0x6c, 0xb0, 0x9e, 0x24, 0xa5, 0xac,
+	0xaa, 0x69, 0xaa, 0x18, 0xda, 0x5c, 0x3a, 0x9a, 0xa7, 0xb7, 0x6f, 0x52, 0xd2, 0x17, 0xf5, 0x61,
+	0x22, 0x4a, 0xfa, 0xa2, 0x85, 0xe4, 0x2e, 0x49, 0x98, 0x57, 0xcf, 0xd1, 0x46, 0x61, 0x97, 0x2f,
+	0x83, 0xdd, 0x87, 0xfc, 0x2d, 0xc3, 0x40, 0x93, 0xe1, 0x0a, 0x7c, 0x00, 0x24, 0x8b, 0x62, 0x8b,
+	0x39, 0x27, 0x13, 0x82, 0xe7, 0x45, 0x0b, 0x4a, 0x3e, 0x77, 0x1b, 0xe4, 0x74, 0x8c, 0x29, 0x56,
+	0x67, 0x13, 0xf2, 0x6c, 0xe7, 0x73, 0x48, 0x8d, 0xe9, 0xf9, 0xec, 0x40, 0x7e, 0xd7, 0xb2, 0x83,
+	0xd0, 0x43, 0x66, 0x55, 0x45, 0xb2, 0x48, 0x78, 0x7d, 0x9b, 0x79, 0x9d, 0x47, 0xe7, 0x6c, 0x88,
+	0x6d, 0xd9, 0x68, 0x0f, 0x0a, 0x94, 0x9b, 0x44, 0xc1, 0xf4, 0xb0, 0xed, 0x53, 0xa7, 0x22, 0x32,
+	0xe1, 0x13, 0x33, 0x9f, 0x0b, 0x48, 0x3d, 0xc7, 0x27, 0x75, 0x66, 0xc1, 0x98, 0x4c, 0xfe, 0x21,
+	0x55, 0xba, 0x71, 0x62, 0x4c, 0x8e, 0x3a, 0x9f, 0xaa, 0x8b, 0x6e, 0x0b, 0xaa, 0xa7, 0x83, 0x05,
+	0x1d, 0xf6, 0x09, 0x40, 0x48, 0xd9, 0x05, 0x5f, 0x48, 0x82, 0x14, 0x54, 0xe7, 0x52, 0x34, 0x02,
+	0xea, 0x23, 0x06, 0xb5, 0x82, 0xde, 0xbf, 0x18, 0xaa, 0xf9, 0x3c, 0x24, 0xea, 0x5e, 0xa0, 0x5f,
+	0xc0, 0x78, 0x84, 0x98, 0x43, 0xf3, 0x91, 0x0c, 0x8d, 0xa1, 0x2f, 0xa4, 0x2b, 0xa3, 0x01, 0x2c,
+	0x0f, 0x14, 0x40, 0x1f, 0xc6, 0x64, 0x36, 0x2d, 0xd8, 0xe9, 0x14, 0x52, 0x50, 0x9d, 0x4f, 0xd5,
+	0x09, 0xf4, 0xeb, 0x0c, 0xfd, 0x1a, 0x7e, 0x5b, 0x46, 0x8f, 0x51, 0x6c, 0x2f, 0x9a, 0x94, 0x94,
+	0xa3, 0x69, 0xd8, 0x87, 0x31, 0x99, 0xe3, 0x0a, 0x60, 0x53, 0xa8, 0x3a, 0x75, 0x3e, 0x55, 0x37,
+	0x10, 0x6c, 0xcb, 0xb2, 0x4f, 0x29, 0xac, 0xa8, 0x70, 0x9c, 0x08, 0x8a, 0x54, 0xb8, 0x08, 0xd9,
+	0xa4, 0xce, 0xa5, 0x68, 0xd2, 0x2a, 0x9c, 0xe0, 0x88, 0x78, 0x85, 0x63, 0x86, 0x72, 0x85, 0x93,
+	0x99, 0x20, 0x75, 0x36, 0x21, 0x4f, 0xab, 0x70, 0xdc, 0x61, 0x58, 0xe1, 0xa2, 0x3e, 0x63, 0xdc,
+	0x9b, 0x3a, 0x9b, 0x90, 0xa7, 0x55, 0x38, 0xee, 0xb3, 0xf9, 0xdc, 0x27, 0x50, 0x5e, 0x84, 0x45,
+	0x87, 0xbb, 0x8f, 0x16, 0x9d, 0x08, 0x82, 0x9a, 0xa6, 0x4a, 0x2b, 0x3a, 0x49, 0x90, 0x53, 0x18,
+	0x0d, 0x08, 0x26, 0x34, 0x1b, 0xed, 0x65, 0x02, 0xde, 0x48, 0xad, 0x25, 0x15, 0xc2, 0xfd, 0xa7,
+	0xcc, 0xfd, 0x47, 0xf8, 0x9d, 0x54, 0xf7, 0xcd, 0x48, 0x1f, 0x74, 0x33, 0xc2, 0x6d, 0xa1, 0x9f,
+	0x43, 0x59, 0xa2, 0x95, 0xc2, 0x0a, 0x9e, 0xa0, 0xad, 0x54, 0x35, 0x4d, 0x25, 0x02, 0xf8, 0x84,
+	0x05, 0xf0, 0xc1, 0x72, 0x23, 0x43, 0x00, 0x72, 0xb5, 0xfd, 0x29, 0x8c, 0x08, 0x8e, 0x09, 0xcd,
+	0x04, 0x05, 0x55, 0x66, 0xb3, 0xd4, 0x2b, 0x71, 0x71, 0x5a, 0xa5, 0x48, 0x20, 0x86, 0x95, 0xa2,
+	0xcd, 0xd3, 0x98, 0x53, 0x47, 0x91, 0x34, 0x8e, 0xb0, 0x4f, 0xea, 0x5c, 0x8a, 0x46, 0x40, 0xbd,
+	0xcb, 0xa0, 0xea, 0x68, 0x21, 0xfd, 0xb2, 0xe0, 0x3c, 0x13, 0x32, 0x58, 0x62, 0xdf, 0xe1, 0x4f,
+	0xf2, 0x30, 0x81, 0x65, 0xf6, 0x45, 0x9d, 0x4d, 0xc8, 0x05, 0xc4, 0x77, 0x18, 0xc4, 0xdb, 0xf8,
+	0x42, 0x08, 0x9a, 0xea, 0x26, 0x4b, 0xf5, 0x28, 0x4a, 0x8c, 0x62, 0x52, 0x67, 0x13, 0xf2, 0xe8,
+	0x05, 0x80, 0xde, 0xbd, 0x08, 0xa5, 0xf9, 0x9c, 0xfd, 0x7d, 0x81, 0x1c, 0x3f, 0xf3, 0x39, 0x5a,
+	0x34, 0xf3, 0x23, 0x80, 0x6a, 0x9a, 0x2a, 0x8a, 0xb9, 0x9c, 0x0d, 0xf3, 0x08, 0x0a, 0x94, 0xf9,
+	0x09, 0x2a, 0xa4, 0xc4, 0x31, 0xa9, 0x53, 0x11, 0x99, 0x70, 0xff, 0x5d, 0xe6, 0xbe, 0x89, 0x56,
+	0xb2, 0xb8, 0x0f, 0x6b, 0x58, 0x17, 0x86, 0x39, 0x55, 0x83, 0xa6, 0x83, 0x1b, 0x53, 0xe2, 0x84,
+	0xd4, 0x99, 0x98, 0x34, 0x9a, 0xe6, 0xf8, 0xfd, 0x4c, 0x68, 0xfc, 0x09, 0x41, 0x4f, 0xed, 0x47,
+	0x50, 0xd8, 0x93, 0x0b, 0xff, 0x5e, 0x4a, 0xe1, 0x97, 0xb9, 0x17, 0xbf, 0x23, 0xc6, 0xe7, 0x14,
+	0x7e, 0x97, 0x90, 0x63, 0xea, 0xf7, 0x11, 0x8c, 0x08, 0x37, 0xc1, 0xe7, 0x13, 0xfd, 0x97, 0x74,
+	0xf0, 0xf9, 0xc4, 0xfe, 0x9f, 0x8c, 0x1b, 0x0c, 0x60, 0x09, 0x5f, 0x4d, 0x07, 0x70, 0xb8, 0x79,
+	0xf0, 0x19, 0xad, 0x7d, 0xf0, 0xf5, 0xcb, 0xfa, 0xd0, 0xdf, 0x5f, 0xd6, 0x87, 0xbe, 0x7d, 0x59,
+	0x57, 0x7e, 0x79, 0x56, 0x57, 0xfe, 0x74, 0x56, 0x1f, 0xfa, 0xeb, 0x59, 0x7d, 0xe8, 0xeb, 0xb3,
+	0xfa, 0xd0, 0x3f, 0xcf, 0xea, 0x43, 0xff, 0x3e, 0xab, 0x0f, 0x7d, 0x7b, 0x56, 0x57, 0x7e, 0xf7,
+	0x4d, 0x7d, 0xe8, 0xab, 0x6f, 0xea, 0xca, 0xe1, 0x30, 0x03, 0xfe, 0xe8, 0xbf, 0x03, 0x00, 0x46,
+	0xfa, 0xc2, 0xe4, 0x5e, 0x26, 0x00, 0x00,
 }
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 
@@ -17,13 +19,29 @@ var FromStore = map[q.Store]Queue_Store{
 	q.UnknownStore: UNKNOWN,
 	q.Memory:       MEMORY,
 	q.BoltDB:       BOLTDB,
+	q.MemoryLog:    MEMORY_LOG,
 }
 
 // ToStore maps protobuf generated store types to q.Store.
 var ToStore = map[Queue_Store]q.Store{
-	UNKNOWN: q.UnknownStore,
-	MEMORY:  q.Memory,
-	BOLTDB:  q.BoltDB,
+	UNKNOWN:    q.UnknownStore,
+	MEMORY:     q.Memory,
+	BOLTDB:     q.BoltDB,
+	MEMORY_LOG: q.MemoryLog,
+}
+
+// FromOverflow maps q.Overflow to its protobuf generated equivalent.
+var FromOverflow = map[q.Overflow]QueueConfig_Overflow{
+	q.Reject:     REJECT,
+	q.DropOldest: DROP_OLDEST,
+	q.Block:      BLOCK,
+}
+
+// ToOverflow maps protobuf generated overflow policies to q.Overflow.
+var ToOverflow = map[QueueConfig_Overflow]q.Overflow{
+	REJECT:      q.Reject,
+	DROP_OLDEST: q.DropOldest,
+	BLOCK:       q.Block,
 }
 
 // ParseID parses a string ID into a uuid.UUID.
@@ -50,8 +68,7 @@ func ToMeta(m *Metadata) (*q.Metadata, error) {
 	if err != nil {
 		return nil, e.ErrInvalid(errors.Wrap(err, "cannot parse metadata ID"))
 	}
-	created := time.Unix(m.GetCreated().GetSeconds(), int64(m.GetCreated().GetNanos()))
-	meta := &q.Metadata{ID: id, Created: created, Tags: &q.Tags{}}
+	meta := &q.Metadata{ID: id, Created: ToTime(m.GetCreated()), Tags: &q.Tags{}}
 
 	// TODO(negz): Revisit the Tags API. It's starting to feel pretty awkward.
 	for _, t := range ToTags(m.GetTags()) {
@@ -67,21 +84,88 @@ func FromQueue(queue q.Queue) (*Queue, error) {
 		return nil, errors.Wrap(err, "cannot parse timestamp")
 	}
 	return &Queue{
-		Meta:  &Metadata{Id: fmt.Sprint(queue.ID()), Created: t, Tags: FromTags(queue.Tags().Get())},
-		Store: FromStore[queue.Store()],
+		Meta:   &Metadata{Id: fmt.Sprint(queue.ID()), Created: t, Tags: FromTags(queue.Tags().Get())},
+		Store:  FromStore[queue.Store()],
+		Config: FromConfig(queue.Config()),
 	}, nil
 }
 
+// FromTopic converts a q.Topic to its protobuf generated equivalent.
+func FromTopic(t q.Topic) (*Topic, error) {
+	created, err := ptypes.TimestampProto(t.Created())
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse timestamp")
+	}
+	subs := t.Subscriptions()
+	ps := make([]*Subscription, 0, len(subs))
+	for _, s := range subs {
+		ps = append(ps, FromSubscription(s))
+	}
+	return &Topic{
+		Meta:          &Metadata{Id: fmt.Sprint(t.ID()), Created: created, Tags: FromTags(t.Tags().Get())},
+		Subscriptions: ps,
+	}, nil
+}
+
+// FromSubscription converts a q.Subscription to its protobuf generated
+// equivalent.
+func FromSubscription(s q.Subscription) *Subscription {
+	return &Subscription{QueueId: fmt.Sprint(s.Queue), Filter: FromTags(s.Filter)}
+}
+
+// ToSubscription converts protobuf generated code into a q.Subscription.
+func ToSubscription(s *Subscription) (q.Subscription, error) {
+	id, err := ParseID(s.GetQueueId())
+	if err != nil {
+		return q.Subscription{}, errors.Wrap(err, "cannot parse subscription queue ID")
+	}
+	return q.Subscription{Queue: id, Filter: ToTags(s.GetFilter())}, nil
+}
+
+// FromConfig converts q.Config to its protobuf generated equivalent.
+func FromConfig(c q.Config) *QueueConfig {
+	return &QueueConfig{
+		Limit:           int64(c.Limit),
+		MaxBytes:        int64(c.MaxBytes),
+		MaxMessageBytes: int64(c.MaxMessageBytes),
+		Overflow:        FromOverflow[c.Overflow],
+		Retention:       ptypes.DurationProto(c.Retention),
+		ExpireAfterIdle: ptypes.DurationProto(c.ExpireAfterIdle),
+	}
+}
+
+// ToDuration converts a protobuf duration to a time.Duration. A nil duration is
+// zero.
+func ToDuration(d *duration.Duration) time.Duration {
+	return time.Duration(d.GetSeconds())*time.Second + time.Duration(d.GetNanos())
+}
+
+// ToTime converts a protobuf timestamp to a time.Time. A nil timestamp is the
+// Unix epoch.
+func ToTime(t *timestamp.Timestamp) time.Time {
+	return time.Unix(t.GetSeconds(), int64(t.GetNanos()))
+}
+
+// FromGroup converts a q.Group to its protobuf generated equivalent.
+func FromGroup(g q.Group) *Group {
+	return &Group{Name: g.Name, Offset: g.Offset, Lag: g.Lag}
+}
+
 // FromMessage converts a *q.Message to its protobuf generated equivalent.
 func FromMessage(m *q.Message) (*Message, error) {
 	t, err := ptypes.TimestampProto(m.Created)
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot parse timestamp")
 	}
-	return &Message{
-		Meta:    &Metadata{Id: fmt.Sprint(m.ID), Created: t, Tags: FromTags(m.Tags.Get())},
-		Payload: m.Payload,
-	}, nil
+	pm := &Message{
+		Meta:          &Metadata{Id: fmt.Sprint(m.ID), Created: t, Tags: FromTags(m.Tags.Get())},
+		Payload:       m.Payload,
+		CorrelationId: m.CorrelationID,
+	}
+	if m.ReplyTo != uuid.Nil {
+		pm.ReplyTo = fmt.Sprint(m.ReplyTo)
+	}
+	return pm, nil
 }
 
 // ToMessage converts protobuf generated code into a *q.Message
@@ -90,7 +174,39 @@ func ToMessage(m *Message) (*q.Message, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot parse metadata")
 	}
-	return &q.Message{Metadata: meta, Payload: m.GetPayload()}, nil
+	qm := &q.Message{Metadata: meta, Payload: m.GetPayload(), CorrelationID: m.GetCorrelationId()}
+	if m.GetReplyTo() != "" {
+		if qm.ReplyTo, err = ParseID(m.GetReplyTo()); err != nil {
+			return nil, errors.Wrap(err, "cannot parse reply queue ID")
+		}
+	}
+	return qm, nil
+}
+
+// ToNewMessage creates a *q.Message from the user-writable subset of a message.
+func ToNewMessage(m *NewMessage) (*q.Message, error) {
+	o := []q.Option{q.Tagged(ToTags(m.GetTags())...), q.Correlated(m.GetCorrelationId())}
+	if m.GetReplyTo() != "" {
+		id, err := ParseID(m.GetReplyTo())
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot parse reply queue ID")
+		}
+		o = append(o, q.ReplyTo(id))
+	}
+	return q.NewMessage(m.GetPayload(), o...), nil
+}
+
+// FromNewMessage converts the user-writable subset of a *q.Message to a
+// protobuf generated NewMessage.
+func FromNewMessage(m *q.Message) *NewMessage {
+	pm := &NewMessage{Payload: m.Payload, CorrelationId: m.CorrelationID}
+	if m.Metadata != nil && m.Tags != nil {
+		pm.Tags = FromTags(m.Tags.Get())
+	}
+	if m.ReplyTo != uuid.Nil {
+		pm.ReplyTo = fmt.Sprint(m.ReplyTo)
+	}
+	return pm
 }
 
 // FromTags converts q.Tag to its protobuf generated equivalent.
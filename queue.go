@@ -2,9 +2,14 @@
 package q
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+
+	"github.com/negz/q/e"
 )
 
 // Unbounded queues will accept messages until they exhaust available resources.
@@ -22,6 +27,10 @@ const (
 
 	// BoltDB queues are persisted to disk using a BoltDB store.
 	BoltDB
+
+	// MemoryLog queues are in-memory append-only logs, read by consumer
+	// groups. Their contents do not persist across process restarts.
+	MemoryLog
 )
 
 // Error differentiates errors for metric collection purposes.
@@ -38,6 +47,23 @@ const (
 	NotFound
 )
 
+// Overflow determines what a bounded queue does when a message is added while
+// it is full.
+type Overflow int
+
+const (
+	// Reject new messages when the queue is full.
+	Reject Overflow = iota
+
+	// DropOldest evicts the oldest messages in the queue to make room for new
+	// messages.
+	DropOldest
+
+	// Block producers until the queue has room for their message, or their
+	// context is done.
+	Block
+)
+
 // Metadata is useful information associated with either queues or messages.
 type Metadata struct {
 	ID      uuid.UUID // ID is a globally unique identifier for a resource.
@@ -49,6 +75,14 @@ type Metadata struct {
 type Message struct {
 	*Metadata
 	Payload []byte // The Payload of a Message is an arbitrary byte array.
+
+	// ReplyTo is the ID of the queue to which replies to this message should
+	// be added, or uuid.Nil if the message expects no reply.
+	ReplyTo uuid.UUID
+
+	// CorrelationID associates a reply with the message it replies to.
+	// Replies should copy the CorrelationID of the message they reply to.
+	CorrelationID string
 }
 
 // An Option represents an optional argument to a new message.
@@ -63,6 +97,21 @@ func Tagged(t ...Tag) Option {
 	}
 }
 
+// ReplyTo requests that replies to a new message be added to the queue with the
+// supplied ID.
+func ReplyTo(id uuid.UUID) Option {
+	return func(m *Message) {
+		m.ReplyTo = id
+	}
+}
+
+// Correlated applies the supplied correlation ID to a new message.
+func Correlated(id string) Option {
+	return func(m *Message) {
+		m.CorrelationID = id
+	}
+}
+
 // NewMessage creates a message from the supplied payload.
 func NewMessage(payload []byte, o ...Option) *Message {
 	m := &Message{Metadata: &Metadata{ID: uuid.New(), Created: time.Now(), Tags: &Tags{}}, Payload: payload}
@@ -72,36 +121,228 @@ func NewMessage(payload []byte, o ...Option) *Message {
 	return m
 }
 
+// Copy returns a copy of the message with a new ID. The copy shares none of the
+// message's tags or payload, so either may be changed without affecting the
+// other.
+func (m *Message) Copy() *Message {
+	c := &Message{
+		Metadata:      &Metadata{ID: uuid.New(), Created: m.Created, Tags: &Tags{}},
+		Payload:       append([]byte(nil), m.Payload...),
+		ReplyTo:       m.ReplyTo,
+		CorrelationID: m.CorrelationID,
+	}
+	for _, t := range m.Tags.Get() {
+		c.Tags.AddTag(t)
+	}
+	return c
+}
+
+// Config represents the settings of a queue that may be changed after it has
+// been created.
+type Config struct {
+	Limit           int      // Limit is the maximum number of messages in the queue, or Unbounded.
+	MaxBytes        int      // MaxBytes is the maximum total size of the queue's message payloads, or Unbounded.
+	MaxMessageBytes int      // MaxMessageBytes is the maximum size of a single message payload, or Unbounded.
+	Overflow        Overflow // Overflow determines what happens when a message is added to a full queue.
+
+	// Retention is how long, measured from their creation, BoltDB queues and
+	// logs retain messages after they are consumed so that they may be
+	// replayed. Logs also discard unconsumed messages older than their
+	// retention. Zero discards messages as soon as they are consumed. In-memory
+	// FIFO queues do not support retention.
+	Retention time.Duration
+
+	// ExpireAfterIdle is how long a queue may go without messages being
+	// added, popped, peeked, or read by a consumer group before a manager that
+	// expires queues deletes it. Queues that still hold messages are not
+	// expired. Zero never expires the queue.
+	ExpireAfterIdle time.Duration
+}
+
+// Validate returns an error if any of the config's settings are invalid.
+func (c Config) Validate() error {
+	switch {
+	case c.Limit < Unbounded:
+		return fmt.Errorf("invalid limit %d", c.Limit)
+	case c.MaxBytes < Unbounded:
+		return fmt.Errorf("invalid maximum bytes %d", c.MaxBytes)
+	case c.MaxMessageBytes < Unbounded:
+		return fmt.Errorf("invalid maximum message bytes %d", c.MaxMessageBytes)
+	case c.Overflow < Reject || c.Overflow > Block:
+		return fmt.Errorf("invalid overflow policy %d", c.Overflow)
+	case c.Retention < 0:
+		return fmt.Errorf("invalid retention %s", c.Retention)
+	case c.ExpireAfterIdle < 0:
+		return fmt.Errorf("invalid idle expiry %s", c.ExpireAfterIdle)
+	}
+	return nil
+}
+
+// Admit returns an error if the supplied message cannot be added to the queue
+// with the supplied ID, which uses this config and currently holds the supplied
+// number of messages and payload bytes.
+func (c Config) Admit(id uuid.UUID, length, bytes int, m *Message) error {
+	size := len(m.Payload)
+	if (c.MaxMessageBytes != Unbounded) && (size > c.MaxMessageBytes) {
+		err := e.ErrInvalid(errors.Errorf("message of %d bytes exceeds queue %s limit of %d bytes per message", size, id, c.MaxMessageBytes))
+		return e.WithReason(err, e.MESSAGE_TOO_LARGE)
+	}
+	if (c.Limit != Unbounded) && (length >= c.Limit) {
+		err := e.ErrFull(errors.Errorf("queue %s has reached limit of %d messages", id, c.Limit))
+		return e.WithReason(e.WithQuota(err, "queue "+id.String(), fmt.Sprintf("limit of %d messages", c.Limit)), e.QUEUE_FULL)
+	}
+	if (c.MaxBytes != Unbounded) && (bytes+size > c.MaxBytes) {
+		err := e.ErrFull(errors.Errorf("queue %s cannot hold %d more bytes within its limit of %d bytes", id, size, c.MaxBytes))
+		return e.WithReason(e.WithQuota(err, "queue "+id.String(), fmt.Sprintf("limit of %d bytes", c.MaxBytes)), e.QUEUE_FULL)
+	}
+	return nil
+}
+
 // A Queue stores Messages for consumption by another process.
 type Queue interface {
 	ID() uuid.UUID           // ID is the globally unique identifier for this queue.
 	Created() time.Time      // Created is the creation time of this queue.
 	Tags() *Tags             // Tags are arbitrary key:value pairs associated with this queue.
 	Store() Store            // Store indicates which backing store this queue uses.
-	Add(*Message) error      // Add amends a message to this queue.
+	Config() Config          // Config returns the current settings of this queue.
+	Add(*Message) error      // Add amends a message to this queue. It is equivalent to AddContext with a background context.
 	Pop() (*Message, error)  // Pop consumes and returns the next message in the queue.
 	Peek() (*Message, error) // Peek returns the next message in the queue without consuming it.
+
+	// AddContext amends a message to this queue. Queues with the Block
+	// overflow policy wait for room until the supplied context is done.
+	AddContext(ctx context.Context, m *Message) error
+
+	// OnEvict registers a function to be called with each message the queue
+	// evicts to make room for new messages. fn must not call the queue's
+	// methods.
+	OnEvict(fn func(m *Message))
+
+	// PopMatching consumes and returns the next message in the queue that is
+	// tagged with all of the supplied tags. Messages that do not match are
+	// left in place.
+	PopMatching(t ...Tag) (*Message, error)
+
+	// PeekMatching returns the next message in the queue that is tagged with
+	// all of the supplied tags without consuming it.
+	PeekMatching(t ...Tag) (*Message, error)
+
+	// Get returns the message with the supplied ID without consuming it.
+	Get(id uuid.UUID) (*Message, error)
+
+	// Delete removes the message with the supplied ID from the queue,
+	// regardless of its position.
+	Delete(id uuid.UUID) error
+
+	// Configure changes the settings of the queue. Existing messages are never
+	// dropped to satisfy new settings; a queue whose limit is reduced below its
+	// current depth rejects new messages until enough have been consumed.
+	Configure(c Config) error
+
+	// Purge atomically removes all messages from the queue, returning the
+	// number of messages removed.
+	Purge() (int, error)
+
+	// Walk calls fn for each message in the queue, in order, without consuming
+	// them. Walking starts at the first message with an offset greater than or
+	// equal to the supplied offset and stops when fn returns false. fn must not
+	// call the queue's other methods.
+	Walk(offset uint64, fn WalkFunc) error
 }
 
+// A WalkFunc is called for each message visited by Queue.Walk. Each message has
+// an offset that identifies its position in the queue. Offsets increase
+// monotonically as messages are added, and are never reused.
+type WalkFunc func(offset uint64, m *Message) bool
+
 // Metrics for a queue.
 // We only expose counts, not gauges, because they don't lose meaning when
-// downsampled in a timeseries. See https://goo.gl/WTHgAq for details.
+// downsampled in a timeseries. See https://goo.gl/WTHgAq for details. Budget
+// usage and consumer group lag are the exceptions; they are levels that cannot
+// be derived from counts of messages that may have been added before the
+// process started.
 type Metrics interface {
-	Enqueued(id uuid.UUID) // Enqueued increments the enqueued message count.
-	Consumed(id uuid.UUID) // Consumed increments the consumed message count.
+	Enqueued(id uuid.UUID)      // Enqueued increments the enqueued message count.
+	Consumed(id uuid.UUID)      // Consumed increments the consumed message count.
+	Purged(id uuid.UUID, n int) // Purged increases the purged message count by n.
+	Evicted(id uuid.UUID)       // Evicted increments the evicted message count.
 	// Error increments the count of errors encountered while queueing or consuming messages.
 	Error(id uuid.UUID, t Error)
+	// Expired increments the count of queues deleted for being idle.
+	Expired(id uuid.UUID)
+	// Usage sets the number of queues, messages, and bytes used by a tenant.
+	Usage(tenant string, queues, messages, bytes int)
+	// GlobalUsage sets the number of queues, messages, and bytes used by all
+	// tenants.
+	GlobalUsage(queues, messages, bytes int)
+	// Lag sets the lag of a consumer group reading from a log.
+	Lag(id uuid.UUID, group string, lag uint64)
 }
 
-// A Manager manages a set of queues.
+// A Manager manages a set of queues and the topics that fan out to them.
 type Manager interface {
 	Add(Queue) error                 // Add a new queue to the manager.
 	Get(id uuid.UUID) (Queue, error) // Get an existing queue given its ID.
-	Delete(id uuid.UUID) error       // Delete an existing queue given its ID.
-	List() ([]Queue, error)          // List all existing queues.
+	List() ([]Queue, error)          // List all existing queues, ordered by creation time then ID.
+
+	// Delete an existing queue given its ID, unsubscribing it from all topics
+	// and destroying it. Queues that still hold messages are deleted only if
+	// force is true.
+	Delete(id uuid.UUID, force bool) error
+
+	AddTopic(Topic) error                 // AddTopic adds a new topic to the manager.
+	GetTopic(id uuid.UUID) (Topic, error) // GetTopic gets an existing topic given its ID.
+	DeleteTopic(id uuid.UUID) error       // DeleteTopic deletes an existing topic given its ID.
+	ListTopics() ([]Topic, error)         // ListTopics lists all existing topics, ordered by creation time then ID.
+}
+
+// A Destroyer is a queue that holds resources, for example storage, that must be
+// released when it is deleted.
+type Destroyer interface {
+	// Destroy releases the queue's resources, removing all of its messages.
+	// Unless forced it returns an error satisfying e.IsFailedPrecondition
+	// instead if the queue holds messages. The queue may not be used after it
+	// is destroyed.
+	Destroy(force bool) error
+}
+
+// Destroy destroys the queue wrapped by the supplied queue, if it is a
+// Destroyer. Queues that are not Destroyers are only checked for messages.
+func Destroy(queue Queue, force bool) error {
+	for {
+		if d, ok := queue.(Destroyer); ok {
+			return d.Destroy(force)
+		}
+		w, ok := queue.(Wrapper)
+		if !ok {
+			break
+		}
+		queue = w.Unwrap()
+	}
+	if force {
+		return nil
+	}
+	empty := true
+	if err := queue.Walk(0, func(_ uint64, _ *Message) bool {
+		empty = false
+		return false
+	}); err != nil {
+		return errors.Wrapf(err, "cannot determine whether queue %s is empty", queue.ID())
+	}
+	if !empty {
+		return NotEmpty(queue.ID())
+	}
+	return nil
+}
+
+// NotEmpty returns an error indicating that the queue with the supplied ID
+// holds messages.
+func NotEmpty(id uuid.UUID) error {
+	err := e.WithResource(e.ErrFailedPrecondition(errors.Errorf("queue %s is not empty", id)), "queue", id.String())
+	return e.WithReason(err, e.QUEUE_NOT_EMPTY)
 }
 
-// A Factory produces new queues with the requested store, limit, and tags.
+// A Factory produces new queues with the requested store, config, and tags.
 type Factory interface {
-	New(s Store, limit int, t ...Tag) (Queue, error)
+	New(s Store, c Config, t ...Tag) (Queue, error)
 }
@@ -1,12 +1,12 @@
-// Code generated by "stringer -type=Store,Error -output=queue_strings.go"; DO NOT EDIT.
+// Code generated by "stringer -type=Store,Error,Overflow -output=queue_strings.go"; DO NOT EDIT.
 
 package q
 
 import "fmt"
 
-const _Store_name = "UnknownStoreMemory"
+const _Store_name = "UnknownStoreMemoryBoltDBMemoryLog"
 
-var _Store_index = [...]uint8{0, 12, 18}
+var _Store_index = [...]uint8{0, 12, 18, 24, 33}
 
 func (i Store) String() string {
 	if i < 0 || i >= Store(len(_Store_index)-1) {
@@ -25,3 +25,14 @@ func (i Error) String() string {
 	}
 	return _Error_name[_Error_index[i]:_Error_index[i+1]]
 }
+
+const _Overflow_name = "RejectDropOldestBlock"
+
+var _Overflow_index = [...]uint8{0, 6, 16, 21}
+
+func (i Overflow) String() string {
+	if i < 0 || i >= Overflow(len(_Overflow_index)-1) {
+		return fmt.Sprintf("Overflow(%d)", i)
+	}
+	return _Overflow_name[_Overflow_index[i]:_Overflow_index[i+1]]
+}
@@ -0,0 +1,212 @@
+package queuetest
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+	"github.com/negz/q/topic"
+)
+
+// RunManagerSuite runs a suite of tests against managers returned by
+// newManager, which must return a new manager that manages no queues or
+// topics. The suite adds queues returned by newQueue to the manager; see
+// RunQueueSuite. Run the suite with the race detector enabled to test that
+// managers are safe for concurrent use.
+func RunManagerSuite(t *testing.T, newManager func() q.Manager, newQueue func(limit int) q.Queue) {
+	t.Run("Queues", func(t *testing.T) { testManagerQueues(t, newManager(), newQueue) })
+	t.Run("Delete", func(t *testing.T) { testManagerDelete(t, newManager(), newQueue) })
+	t.Run("Topics", func(t *testing.T) { testManagerTopics(t, newManager(), newQueue) })
+	t.Run("Concurrent", func(t *testing.T) { testManagerConcurrent(t, newManager(), newQueue) })
+}
+
+func testManagerQueues(t *testing.T, m q.Manager, newQueue func(limit int) q.Queue) {
+	queues := []q.Queue{newQueue(q.Unbounded), newQueue(q.Unbounded), newQueue(2)}
+	for _, queue := range queues {
+		if err := m.Add(queue); err != nil {
+			t.Fatalf("m.Add(%v): %v", queue.ID(), err)
+		}
+	}
+	for _, queue := range queues {
+		if err := m.Add(queue); !e.IsAlreadyExists(err) {
+			t.Errorf("m.Add(%v): want error satisfying e.IsAlreadyExists(), got %v", queue.ID(), err)
+		}
+	}
+
+	for _, queue := range queues {
+		got, err := m.Get(queue.ID())
+		if err != nil {
+			t.Errorf("m.Get(%v): %v", queue.ID(), err)
+			continue
+		}
+		if got.ID() != queue.ID() {
+			t.Errorf("m.Get(%v): want queue %v, got %v", queue.ID(), queue.ID(), got.ID())
+		}
+	}
+	id := uuid.New()
+	if _, err := m.Get(id); !e.IsNotFound(err) {
+		t.Errorf("m.Get(%v): want error satisfying e.IsNotFound(), got %v", id, err)
+	}
+
+	l, err := m.List()
+	if err != nil {
+		t.Fatalf("m.List(): %v", err)
+	}
+	if len(l) != len(queues) {
+		t.Errorf("m.List(): want %v queues, got %v", len(queues), len(l))
+	}
+	listed := make(map[uuid.UUID]bool)
+	for i, queue := range l {
+		listed[queue.ID()] = true
+		if i == 0 {
+			continue
+		}
+		prev := l[i-1]
+		c, pc := queue.Created().UnixNano(), prev.Created().UnixNano()
+		if c < pc || c == pc && !lessID(prev.ID(), queue.ID()) {
+			t.Errorf("m.List(): queue %v created %v listed after queue %v created %v",
+				queue.ID(), queue.Created(), prev.ID(), prev.Created())
+		}
+	}
+	for _, queue := range queues {
+		if !listed[queue.ID()] {
+			t.Errorf("m.List(): queue %v not listed", queue.ID())
+		}
+	}
+}
+
+func lessID(a, b uuid.UUID) bool {
+	return a.String() < b.String()
+}
+
+func testManagerDelete(t *testing.T, m q.Manager, newQueue func(limit int) q.Queue) {
+	empty := newQueue(q.Unbounded)
+	full := newQueue(q.Unbounded)
+	for _, queue := range []q.Queue{empty, full} {
+		if err := m.Add(queue); err != nil {
+			t.Fatalf("m.Add(%v): %v", queue.ID(), err)
+		}
+	}
+	// Add via the manager's queue, which may be wrapped in order to account
+	// for the messages it holds.
+	managed, err := m.Get(full.ID())
+	if err != nil {
+		t.Fatalf("m.Get(%v): %v", full.ID(), err)
+	}
+	if err := managed.Add(q.NewMessage([]byte("apollo"))); err != nil {
+		t.Fatalf("queue.Add(): %v", err)
+	}
+
+	id := uuid.New()
+	if err := m.Delete(id, true); !e.IsNotFound(err) {
+		t.Errorf("m.Delete(%v, true): want error satisfying e.IsNotFound(), got %v", id, err)
+	}
+
+	if err := m.Delete(empty.ID(), false); err != nil {
+		t.Errorf("m.Delete(%v, false): %v", empty.ID(), err)
+	}
+	if _, err := m.Get(empty.ID()); !e.IsNotFound(err) {
+		t.Errorf("m.Get(%v): want error satisfying e.IsNotFound(), got %v", empty.ID(), err)
+	}
+
+	if err := m.Delete(full.ID(), false); !e.IsFailedPrecondition(err) {
+		t.Errorf("m.Delete(%v, false): want error satisfying e.IsFailedPrecondition(), got %v", full.ID(), err)
+	}
+	if _, err := m.Get(full.ID()); err != nil {
+		t.Errorf("m.Get(%v): non-empty queue was deleted: %v", full.ID(), err)
+	}
+	if err := m.Delete(full.ID(), true); err != nil {
+		t.Errorf("m.Delete(%v, true): %v", full.ID(), err)
+	}
+	if _, err := m.Get(full.ID()); !e.IsNotFound(err) {
+		t.Errorf("m.Get(%v): want error satisfying e.IsNotFound(), got %v", full.ID(), err)
+	}
+}
+
+func testManagerTopics(t *testing.T, m q.Manager, newQueue func(limit int) q.Queue) {
+	queue := newQueue(q.Unbounded)
+	if err := m.Add(queue); err != nil {
+		t.Fatalf("m.Add(%v): %v", queue.ID(), err)
+	}
+	tp := topic.New()
+	if err := m.AddTopic(tp); err != nil {
+		t.Fatalf("m.AddTopic(%v): %v", tp.ID(), err)
+	}
+	if err := m.AddTopic(tp); !e.IsAlreadyExists(err) {
+		t.Errorf("m.AddTopic(%v): want error satisfying e.IsAlreadyExists(), got %v", tp.ID(), err)
+	}
+
+	got, err := m.GetTopic(tp.ID())
+	if err != nil {
+		t.Fatalf("m.GetTopic(%v): %v", tp.ID(), err)
+	}
+	if got.ID() != tp.ID() {
+		t.Errorf("m.GetTopic(%v): want topic %v, got %v", tp.ID(), tp.ID(), got.ID())
+	}
+	l, err := m.ListTopics()
+	if err != nil {
+		t.Errorf("m.ListTopics(): %v", err)
+	}
+	if len(l) != 1 || l[0].ID() != tp.ID() {
+		t.Errorf("m.ListTopics(): want only topic %v, got %v topics", tp.ID(), len(l))
+	}
+
+	if err := got.Subscribe(q.Subscription{Queue: queue.ID()}); err != nil {
+		t.Fatalf("topic.Subscribe(%v): %v", queue.ID(), err)
+	}
+	if err := m.Delete(queue.ID(), true); err != nil {
+		t.Fatalf("m.Delete(%v, true): %v", queue.ID(), err)
+	}
+	if s := got.Subscriptions(); len(s) != 0 {
+		t.Errorf("m.Delete(%v, true): want queue unsubscribed from topic, got subscriptions %v", queue.ID(), s)
+	}
+
+	if err := m.DeleteTopic(tp.ID()); err != nil {
+		t.Errorf("m.DeleteTopic(%v): %v", tp.ID(), err)
+	}
+	if _, err := m.GetTopic(tp.ID()); !e.IsNotFound(err) {
+		t.Errorf("m.GetTopic(%v): want error satisfying e.IsNotFound(), got %v", tp.ID(), err)
+	}
+	if err := m.DeleteTopic(tp.ID()); !e.IsNotFound(err) {
+		t.Errorf("m.DeleteTopic(%v): want error satisfying e.IsNotFound(), got %v", tp.ID(), err)
+	}
+}
+
+// testManagerConcurrent adds, gets, lists, and deletes queues concurrently.
+func testManagerConcurrent(t *testing.T, m q.Manager, newQueue func(limit int) q.Queue) {
+	done := make(chan struct{})
+	for w := 0; w < producers; w++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for i := 0; i < produced; i++ {
+				queue := newQueue(q.Unbounded)
+				if err := m.Add(queue); err != nil {
+					t.Errorf("m.Add(%v): %v", queue.ID(), err)
+					continue
+				}
+				if _, err := m.Get(queue.ID()); err != nil {
+					t.Errorf("m.Get(%v): %v", queue.ID(), err)
+				}
+				if _, err := m.List(); err != nil {
+					t.Errorf("m.List(): %v", err)
+				}
+				if err := m.Delete(queue.ID(), false); err != nil {
+					t.Errorf("m.Delete(%v, false): %v", queue.ID(), err)
+				}
+			}
+		}()
+	}
+	for w := 0; w < producers; w++ {
+		<-done
+	}
+
+	l, err := m.List()
+	if err != nil {
+		t.Fatalf("m.List(): %v", err)
+	}
+	if len(l) != 0 {
+		t.Errorf("m.List(): want 0 queues, got %v", len(l))
+	}
+}
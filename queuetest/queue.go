@@ -0,0 +1,329 @@
+// Package queuetest provides suites of tests that implementations of q.Queue
+// and q.Manager should pass.
+package queuetest
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+)
+
+const (
+	producers = 4  // The number of concurrent producers.
+	consumers = 4  // The number of concurrent consumers.
+	produced  = 50 // The number of messages each concurrent producer adds.
+)
+
+// RunQueueSuite runs a suite of tests against queues returned by newQueue,
+// which must return a new, empty queue that holds at most limit messages, or
+// q.Unbounded. Queues must reject messages while they are full. Run the suite
+// with the race detector enabled to test that queues are safe for concurrent
+// use. Queues that are a q.Notifier must notify consumers of added messages.
+func RunQueueSuite(t *testing.T, newQueue func(limit int) q.Queue) {
+	t.Run("FIFO", func(t *testing.T) { testFIFO(t, newQueue(q.Unbounded)) })
+	t.Run("Limit", func(t *testing.T) { testLimit(t, newQueue(2)) })
+	t.Run("Empty", func(t *testing.T) { testEmpty(t, newQueue(q.Unbounded)) })
+	t.Run("Tags", func(t *testing.T) { testTags(t, newQueue(q.Unbounded)) })
+	t.Run("Concurrent", func(t *testing.T) { testConcurrent(t, newQueue(q.Unbounded)) })
+	t.Run("Added", func(t *testing.T) { testAdded(t, newQueue(q.Unbounded)) })
+}
+
+// equal returns an error describing how the got message differs from the want
+// message, if at all. Stores may not preserve every field of a message exactly,
+// for example the monotonic clock reading of its creation time, so only the
+// fields a consumer depends on are compared.
+func equal(want, got *q.Message) error {
+	switch {
+	case got == nil:
+		return fmt.Errorf("want message %v, got nil", want.ID)
+	case got.ID != want.ID:
+		return fmt.Errorf("want message %v, got %v", want.ID, got.ID)
+	case string(got.Payload) != string(want.Payload):
+		return fmt.Errorf("want payload %s, got %s", want.Payload, got.Payload)
+	case !got.Tags.ContainsAll(want.Tags.Get()...) || !want.Tags.ContainsAll(got.Tags.Get()...):
+		return fmt.Errorf("want tags %v, got %v", want.Tags.Get(), got.Tags.Get())
+	}
+	return nil
+}
+
+func testFIFO(t *testing.T, queue q.Queue) {
+	messages := []*q.Message{
+		q.NewMessage([]byte("salyut"), q.Tagged(q.Tag{Key: "country", Value: "USSR"})),
+		q.NewMessage([]byte("skylab"), q.Tagged(q.Tag{Key: "country", Value: "USA"})),
+		q.NewMessage([]byte("mir")),
+		q.NewMessage([]byte("iss")),
+		q.NewMessage([]byte("tiangong")),
+	}
+	for _, m := range messages {
+		if err := queue.Add(m); err != nil {
+			t.Fatalf("queue.Add(%v): %v", m.ID, err)
+		}
+	}
+
+	m, err := queue.Peek()
+	if err != nil {
+		t.Fatalf("queue.Peek(): %v", err)
+	}
+	if err := equal(messages[0], m); err != nil {
+		t.Errorf("queue.Peek(): %v", err)
+	}
+
+	for _, want := range messages {
+		m, err := queue.Get(want.ID)
+		if err != nil {
+			t.Errorf("queue.Get(%v): %v", want.ID, err)
+			continue
+		}
+		if err := equal(want, m); err != nil {
+			t.Errorf("queue.Get(%v): %v", want.ID, err)
+		}
+	}
+
+	for _, want := range messages {
+		m, err := queue.Pop()
+		if err != nil {
+			t.Errorf("queue.Pop(): %v", err)
+			continue
+		}
+		if err := equal(want, m); err != nil {
+			t.Errorf("queue.Pop(): %v", err)
+		}
+	}
+	if _, err := queue.Pop(); !e.IsNotFound(err) {
+		t.Errorf("queue.Pop(): want error satisfying e.IsNotFound(), got %v", err)
+	}
+}
+
+func testLimit(t *testing.T, queue q.Queue) {
+	if got := queue.Config().Limit; got != 2 {
+		t.Errorf("queue.Config().Limit: want 2, got %v", got)
+	}
+	messages := []*q.Message{
+		q.NewMessage([]byte("vostok")),
+		q.NewMessage([]byte("voskhod")),
+	}
+	for _, m := range messages {
+		if err := queue.Add(m); err != nil {
+			t.Fatalf("queue.Add(%v): %v", m.ID, err)
+		}
+	}
+
+	full := q.NewMessage([]byte("soyuz"))
+	if err := queue.Add(full); !e.IsFull(err) {
+		t.Errorf("queue.Add(%v): want error satisfying e.IsFull(), got %v", full.ID, err)
+	}
+	if _, err := queue.Get(full.ID); !e.IsNotFound(err) {
+		t.Errorf("queue.Get(%v): want rejected message to satisfy e.IsNotFound(), got %v", full.ID, err)
+	}
+
+	// Consuming a message makes room for another.
+	m, err := queue.Pop()
+	if err != nil {
+		t.Fatalf("queue.Pop(): %v", err)
+	}
+	if err := equal(messages[0], m); err != nil {
+		t.Errorf("queue.Pop(): %v", err)
+	}
+	if err := queue.Add(full); err != nil {
+		t.Errorf("queue.Add(%v): %v", full.ID, err)
+	}
+
+	for _, want := range []*q.Message{messages[1], full} {
+		m, err := queue.Pop()
+		if err != nil {
+			t.Errorf("queue.Pop(): %v", err)
+			continue
+		}
+		if err := equal(want, m); err != nil {
+			t.Errorf("queue.Pop(): %v", err)
+		}
+	}
+}
+
+func testEmpty(t *testing.T, queue q.Queue) {
+	tag := q.Tag{Key: "crew", Value: "none"}
+	if _, err := queue.Peek(); !e.IsNotFound(err) {
+		t.Errorf("queue.Peek(): want error satisfying e.IsNotFound(), got %v", err)
+	}
+	if _, err := queue.Pop(); !e.IsNotFound(err) {
+		t.Errorf("queue.Pop(): want error satisfying e.IsNotFound(), got %v", err)
+	}
+	if _, err := queue.PeekMatching(tag); !e.IsNotFound(err) {
+		t.Errorf("queue.PeekMatching(%v): want error satisfying e.IsNotFound(), got %v", tag, err)
+	}
+	if _, err := queue.PopMatching(tag); !e.IsNotFound(err) {
+		t.Errorf("queue.PopMatching(%v): want error satisfying e.IsNotFound(), got %v", tag, err)
+	}
+	id := uuid.New()
+	if _, err := queue.Get(id); !e.IsNotFound(err) {
+		t.Errorf("queue.Get(%v): want error satisfying e.IsNotFound(), got %v", id, err)
+	}
+
+	walked := 0
+	if err := queue.Walk(0, func(_ uint64, _ *q.Message) bool {
+		walked++
+		return true
+	}); err != nil {
+		t.Errorf("queue.Walk(): %v", err)
+	}
+	if walked != 0 {
+		t.Errorf("queue.Walk(): want 0 messages, got %v", walked)
+	}
+
+	n, err := queue.Purge()
+	if err != nil {
+		t.Errorf("queue.Purge(): %v", err)
+	}
+	if n != 0 {
+		t.Errorf("queue.Purge(): want 0 messages purged, got %v", n)
+	}
+}
+
+func testTags(t *testing.T, queue q.Queue) {
+	eu := q.Tag{Key: "region", Value: "eu"}
+	us := q.Tag{Key: "region", Value: "us"}
+	crewed := q.Tag{Key: "crewed", Value: "true"}
+	messages := []*q.Message{
+		q.NewMessage([]byte("vostok"), q.Tagged(us, crewed)),
+		q.NewMessage([]byte("ariane"), q.Tagged(eu)),
+		q.NewMessage([]byte("hermes"), q.Tagged(eu, crewed)),
+		q.NewMessage([]byte("atlas"), q.Tagged(us)),
+	}
+	for _, m := range messages {
+		if err := queue.Add(m); err != nil {
+			t.Fatalf("queue.Add(%v): %v", m.ID, err)
+		}
+	}
+
+	m, err := queue.PeekMatching(eu)
+	if err != nil {
+		t.Fatalf("queue.PeekMatching(%v): %v", eu, err)
+	}
+	if err := equal(messages[1], m); err != nil {
+		t.Errorf("queue.PeekMatching(%v): %v", eu, err)
+	}
+
+	// Messages must match all of the supplied tags.
+	m, err = queue.PopMatching(eu, crewed)
+	if err != nil {
+		t.Fatalf("queue.PopMatching(%v, %v): %v", eu, crewed, err)
+	}
+	if err := equal(messages[2], m); err != nil {
+		t.Errorf("queue.PopMatching(%v, %v): %v", eu, crewed, err)
+	}
+	if _, err := queue.PopMatching(eu, crewed); !e.IsNotFound(err) {
+		t.Errorf("queue.PopMatching(%v, %v): want error satisfying e.IsNotFound(), got %v", eu, crewed, err)
+	}
+
+	// Messages that do not match are left in place.
+	for _, want := range []*q.Message{messages[0], messages[1], messages[3]} {
+		m, err := queue.Pop()
+		if err != nil {
+			t.Errorf("queue.Pop(): %v", err)
+			continue
+		}
+		if err := equal(want, m); err != nil {
+			t.Errorf("queue.Pop(): %v", err)
+		}
+	}
+}
+
+// testConcurrent adds messages using several producers while several consumers
+// pop them. Every message must be consumed exactly once, and each consumer must
+// receive each producer's messages in the order they were added.
+func testConcurrent(t *testing.T, queue q.Queue) {
+	total := int64(producers * produced)
+	consumed := int64(0)
+	seen := make([]map[uuid.UUID]bool, consumers)
+	wg := &sync.WaitGroup{}
+
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < produced; i++ {
+				m := q.NewMessage([]byte(strconv.Itoa(i)), q.Tagged(q.Tag{Key: "producer", Value: strconv.Itoa(p)}))
+				if err := queue.Add(m); err != nil {
+					t.Errorf("queue.Add(%v): %v", m.ID, err)
+					atomic.AddInt64(&consumed, 1)
+				}
+			}
+		}(p)
+	}
+
+	for c := 0; c < consumers; c++ {
+		seen[c] = make(map[uuid.UUID]bool)
+		wg.Add(1)
+		go func(c int) {
+			defer wg.Done()
+			last := make(map[string]int)
+			for atomic.LoadInt64(&consumed) < total {
+				m, err := queue.Pop()
+				if e.IsNotFound(err) {
+					runtime.Gosched()
+					continue
+				}
+				if err != nil {
+					t.Errorf("queue.Pop(): %v", err)
+					return
+				}
+				atomic.AddInt64(&consumed, 1)
+				seen[c][m.ID] = true
+
+				p := m.Tags.Get()[0].Value
+				i, err := strconv.Atoi(string(m.Payload))
+				if err != nil {
+					t.Errorf("queue.Pop(): cannot parse payload %s: %v", m.Payload, err)
+					continue
+				}
+				if prev, ok := last[p]; ok && i <= prev {
+					t.Errorf("queue.Pop(): consumed message %v from producer %v after message %v", i, p, prev)
+				}
+				last[p] = i
+			}
+		}(c)
+	}
+	wg.Wait()
+
+	ids := make(map[uuid.UUID]bool)
+	for _, s := range seen {
+		for id := range s {
+			if ids[id] {
+				t.Errorf("queue.Pop(): message %v consumed more than once", id)
+			}
+			ids[id] = true
+		}
+	}
+	if int64(len(ids)) != total {
+		t.Errorf("queue.Pop(): want %v messages consumed, got %v", total, len(ids))
+	}
+}
+
+func testAdded(t *testing.T, queue q.Queue) {
+	added := q.Added(queue)
+	if added == nil {
+		t.Skipf("queue %T cannot notify consumers of added messages", queue)
+	}
+	select {
+	case <-added:
+		t.Fatalf("q.Added(): notified before a message was added")
+	default:
+	}
+	m := q.NewMessage([]byte("sputnik"))
+	if err := queue.Add(m); err != nil {
+		t.Fatalf("queue.Add(%v): %v", m, err)
+	}
+	select {
+	case <-added:
+	default:
+		t.Errorf("q.Added(): not notified after a message was added")
+	}
+}
@@ -0,0 +1,17 @@
+package q
+
+import "time"
+
+// A Seeker is a queue that retains consumed messages for its configured
+// retention so that they may be replayed. Replayed messages are returned to
+// their original position in the queue, and may be consumed again.
+type Seeker interface {
+	// Seek replays every retained consumed message with an offset of at least
+	// the supplied offset, returning the replayed messages.
+	Seek(offset uint64) ([]*Message, error)
+
+	// SeekTime replays every retained consumed message from the oldest that
+	// was created at or after the supplied time, returning the replayed
+	// messages.
+	SeekTime(t time.Time) ([]*Message, error)
+}
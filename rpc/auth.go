@@ -0,0 +1,175 @@
+package rpc
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/negz/q/e"
+)
+
+// An Authenticator identifies the principal that made a gRPC request.
+type Authenticator interface {
+	// Authenticate returns the principal that made the request of the supplied
+	// context. It returns an error satisfying e.IsUnauthenticated if the
+	// request does not carry credentials it recognises.
+	Authenticate(ctx context.Context) (string, error)
+}
+
+// An AuthenticatorFunc is a function that satisfies Authenticator.
+type AuthenticatorFunc func(ctx context.Context) (string, error)
+
+// Authenticate calls the AuthenticatorFunc.
+func (fn AuthenticatorFunc) Authenticate(ctx context.Context) (string, error) {
+	return fn(ctx)
+}
+
+// Authenticators returns an authenticator that tries each of the supplied
+// authenticators in order, returning the principal identified by the first to
+// succeed. The error of the last authenticator is returned if none succeed.
+func Authenticators(a ...Authenticator) Authenticator {
+	return AuthenticatorFunc(func(ctx context.Context) (string, error) {
+		err := e.ErrUnauthenticated(errors.New("no authenticators"))
+		for _, auth := range a {
+			var principal string
+			if principal, err = auth.Authenticate(ctx); err == nil {
+				return principal, nil
+			}
+		}
+		return "", err
+	})
+}
+
+type token struct {
+	principal string
+	token     []byte
+}
+
+// Tokens authenticates requests that carry a static bearer token in their
+// authorization metadata, i.e. "authorization: Bearer <token>".
+type Tokens struct {
+	t []token
+}
+
+// LoadTokens loads bearer tokens from the supplied file. Each line of the file
+// is a principal followed by whitespace and one of its tokens. A principal may
+// have several tokens, for example while rotating them. Blank lines and lines
+// starting with # are ignored. The file is read once.
+func LoadTokens(file string) (*Tokens, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot open %s", file)
+	}
+	defer f.Close()
+
+	t := &Tokens{}
+	s := bufio.NewScanner(f)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, errors.Errorf("%s line %d: want a principal and a token", file, n)
+		}
+		t.t = append(t.t, token{principal: fields[0], token: []byte(fields[1])})
+	}
+	if err := s.Err(); err != nil {
+		return nil, errors.Wrapf(err, "cannot read %s", file)
+	}
+	return t, nil
+}
+
+// Authenticate returns the principal whose token the request carries.
+func (t *Tokens) Authenticate(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var bearer []byte
+	for _, v := range md["authorization"] {
+		if strings.HasPrefix(v, "Bearer ") {
+			bearer = []byte(strings.TrimPrefix(v, "Bearer "))
+			break
+		}
+	}
+	if bearer == nil {
+		return "", e.ErrUnauthenticated(errors.New("request has no bearer token"))
+	}
+
+	// Every token is compared in constant time to avoid revealing valid
+	// tokens, or how much of a token is valid, via response times.
+	principal := ""
+	for _, tk := range t.t {
+		if subtle.ConstantTimeCompare(tk.token, bearer) == 1 {
+			principal = tk.principal
+		}
+	}
+	if principal == "" {
+		return "", e.ErrUnauthenticated(errors.New("invalid bearer token"))
+	}
+	return principal, nil
+}
+
+// ClientCertificates returns an authenticator that identifies the principal
+// that made a request by the common name of the verified certificate its
+// client presented. Servers must verify client certificates; use certs.Server
+// with a client CA.
+func ClientCertificates() Authenticator {
+	return AuthenticatorFunc(func(ctx context.Context) (string, error) {
+		p, ok := peer.FromContext(ctx)
+		if !ok {
+			return "", e.ErrUnauthenticated(errors.New("cannot determine peer"))
+		}
+		ti, ok := p.AuthInfo.(credentials.TLSInfo)
+		if !ok {
+			return "", e.ErrUnauthenticated(errors.New("request was not made over TLS"))
+		}
+		if len(ti.State.VerifiedChains) == 0 || len(ti.State.VerifiedChains[0]) == 0 {
+			return "", e.ErrUnauthenticated(errors.New("client did not present a verified certificate"))
+		}
+		cn := ti.State.VerifiedChains[0][0].Subject.CommonName
+		if cn == "" {
+			return "", e.ErrUnauthenticated(errors.New("client certificate has no common name"))
+		}
+		return cn, nil
+	})
+}
+
+// authorize is a gRPC interceptor that authenticates and authorizes requests
+// before they are handled.
+func (s *Server) authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	principal := ""
+	if s.auth != nil {
+		p, err := s.auth.Authenticate(ctx)
+		if err != nil {
+			return nil, e.GRPC(errors.Wrap(err, "cannot authenticate request"))
+		}
+		principal = p
+	}
+	if s.pol == nil {
+		return handler(ctx, req)
+	}
+
+	method := path.Base(info.FullMethod)
+	rs, ok := requirements[method]
+	if !ok {
+		rs = []requirement{{Admin, everyQueue}}
+	}
+	for _, r := range rs {
+		tags, what := r.target(s.m, req)
+		if what == "" || s.pol.Allows(principal, r.p, tags) {
+			continue
+		}
+		err := errors.Errorf("principal %q does not have %s permission on %s", principal, r.p, what)
+		return nil, e.GRPC(e.ErrPermissionDenied(errors.Wrapf(err, "cannot authorize %s", method)))
+	}
+	return handler(ctx, req)
+}
@@ -0,0 +1,90 @@
+package rpc
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/protobuf/field_mask"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+	"github.com/negz/q/proto"
+)
+
+// updaters apply a field of a protobuf queue config to a q.Config. They are
+// keyed by the field's path in an UpdateQueueRequest's update mask.
+var updaters = map[string]func(c *q.Config, pc *proto.QueueConfig){
+	"limit":             func(c *q.Config, pc *proto.QueueConfig) { c.Limit = int(pc.GetLimit()) },
+	"max_bytes":         func(c *q.Config, pc *proto.QueueConfig) { c.MaxBytes = int(pc.GetMaxBytes()) },
+	"max_message_bytes": func(c *q.Config, pc *proto.QueueConfig) { c.MaxMessageBytes = int(pc.GetMaxMessageBytes()) },
+	"overflow":          func(c *q.Config, pc *proto.QueueConfig) { c.Overflow = overflow(pc.GetOverflow()) },
+	"retention":         func(c *q.Config, pc *proto.QueueConfig) { c.Retention = proto.ToDuration(pc.GetRetention()) },
+	"expire_after_idle": func(c *q.Config, pc *proto.QueueConfig) {
+		c.ExpireAfterIdle = proto.ToDuration(pc.GetExpireAfterIdle())
+	},
+}
+
+// overflow converts a protobuf overflow policy to q.Overflow. Unknown policies
+// are passed through so that they fail validation.
+func overflow(o proto.QueueConfig_Overflow) q.Overflow {
+	if p, ok := proto.ToOverflow[o]; ok {
+		return p
+	}
+	return q.Overflow(o)
+}
+
+// update returns a copy of the supplied config with the fields named by the
+// supplied mask set to their values in the supplied protobuf config.
+func update(c q.Config, pc *proto.QueueConfig, mask *field_mask.FieldMask) (q.Config, error) {
+	if len(mask.GetPaths()) == 0 {
+		return c, e.ErrInvalid(errors.New("update mask names no fields to update"))
+	}
+	for _, path := range mask.GetPaths() {
+		fn, ok := updaters[path]
+		if !ok {
+			return c, e.ErrInvalid(errors.Errorf("cannot update field %s", path))
+		}
+		fn(&c, pc)
+	}
+	return c, nil
+}
+
+// updates serializes updates to the config of each queue, so that concurrent
+// updates of different fields of the same queue do not overwrite each other.
+// The zero value is ready to use.
+type updates struct {
+	mx     sync.Mutex
+	queues map[uuid.UUID]*updating
+}
+
+type updating struct {
+	sync.Mutex
+	waiters int
+}
+
+// lock blocks until no other update of the queue with the supplied ID is in
+// progress. It returns a function that ends the update.
+func (u *updates) lock(id uuid.UUID) func() {
+	u.mx.Lock()
+	if u.queues == nil {
+		u.queues = make(map[uuid.UUID]*updating)
+	}
+	l, ok := u.queues[id]
+	if !ok {
+		l = &updating{}
+		u.queues[id] = l
+	}
+	l.waiters++
+	u.mx.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		u.mx.Lock()
+		defer u.mx.Unlock()
+		if l.waiters--; l.waiters == 0 {
+			delete(u.queues, id)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package rpc
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestUpdates(t *testing.T) {
+	u := &updates{}
+	id := uuid.New()
+
+	// Updates of the same queue are serialized.
+	n := 0
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := u.lock(id)
+			defer unlock()
+			n++
+		}()
+	}
+	wg.Wait()
+	if n != 100 {
+		t.Errorf("n: want 100, got %v", n)
+	}
+
+	// Updates of different queues do not block each other.
+	unlock := u.lock(id)
+	other := u.lock(uuid.New())
+	other()
+	unlock()
+
+	if len(u.queues) != 0 {
+		t.Errorf("u.queues: want no queues after all updates end, got %v", len(u.queues))
+	}
+}
@@ -0,0 +1,114 @@
+package rpc
+
+import (
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+	"github.com/negz/q/proto"
+)
+
+// log returns the log with the supplied ID.
+func (s *qServer) log(queueID string) (q.Log, error) {
+	id, err := proto.ParseID(queueID)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse ID")
+	}
+	queue, err := s.m.Get(id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get queue %s", id)
+	}
+	l, ok := queue.(q.Log)
+	if !ok {
+		return nil, e.ErrInvalid(errors.Errorf("queue %s is not a log and does not support consumer groups", id))
+	}
+	return l, nil
+}
+
+func (s *qServer) ListGroups(_ context.Context, r *proto.ListGroupsRequest) (*proto.ListGroupsResponse, error) {
+	l, err := s.log(r.GetQueueId())
+	if err != nil {
+		return nil, e.GRPC(err)
+	}
+	groups := l.Groups()
+	pg := make([]*proto.Group, 0, len(groups))
+	for _, g := range groups {
+		pg = append(pg, proto.FromGroup(g))
+	}
+	return &proto.ListGroupsResponse{Groups: pg}, nil
+}
+
+func (s *qServer) NewGroup(_ context.Context, r *proto.NewGroupRequest) (*proto.NewGroupResponse, error) {
+	l, err := s.log(r.GetQueueId())
+	if err != nil {
+		return nil, e.GRPC(err)
+	}
+	g, err := l.AddGroup(r.GetGroup())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot add consumer group %s", r.GetGroup()))
+	}
+	return &proto.NewGroupResponse{Group: proto.FromGroup(g)}, nil
+}
+
+func (s *qServer) GetGroup(_ context.Context, r *proto.GetGroupRequest) (*proto.GetGroupResponse, error) {
+	l, err := s.log(r.GetQueueId())
+	if err != nil {
+		return nil, e.GRPC(err)
+	}
+	g, err := l.GetGroup(r.GetGroup())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot get consumer group %s", r.GetGroup()))
+	}
+	return &proto.GetGroupResponse{Group: proto.FromGroup(g)}, nil
+}
+
+func (s *qServer) DeleteGroup(_ context.Context, r *proto.DeleteGroupRequest) (*proto.DeleteGroupResponse, error) {
+	l, err := s.log(r.GetQueueId())
+	if err != nil {
+		return nil, e.GRPC(err)
+	}
+	if err := l.DeleteGroup(r.GetGroup()); err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot delete consumer group %s", r.GetGroup()))
+	}
+	return &proto.DeleteGroupResponse{}, nil
+}
+
+func (s *qServer) Read(_ context.Context, r *proto.ReadRequest) (*proto.ReadResponse, error) {
+	l, err := s.log(r.GetQueueId())
+	if err != nil {
+		return nil, e.GRPC(err)
+	}
+	max := int(r.GetMaxCount())
+	switch {
+	case max < 0:
+		return nil, e.GRPC(e.ErrInvalid(errors.Errorf("invalid maximum count %d", max)))
+	case max == 0:
+		max = q.Unbounded
+	}
+	msgs, next, err := l.Read(r.GetGroup(), max)
+	if err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot read from consumer group %s", r.GetGroup()))
+	}
+	pm := make([]*proto.Message, 0, len(msgs))
+	for _, m := range msgs {
+		p, err := proto.FromMessage(m)
+		if err != nil {
+			return nil, e.GRPC(errors.Wrap(err, "cannot marshal message to protobuf"))
+		}
+		pm = append(pm, p)
+	}
+	return &proto.ReadResponse{Messages: pm, NextOffset: next}, nil
+}
+
+func (s *qServer) Commit(_ context.Context, r *proto.CommitRequest) (*proto.CommitResponse, error) {
+	l, err := s.log(r.GetQueueId())
+	if err != nil {
+		return nil, e.GRPC(err)
+	}
+	g, err := l.Commit(r.GetGroup(), r.GetOffset())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot commit offset of consumer group %s", r.GetGroup()))
+	}
+	return &proto.CommitResponse{Group: proto.FromGroup(g)}, nil
+}
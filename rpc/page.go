@@ -0,0 +1,136 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/negz/q/e"
+)
+
+const (
+	// defaultPageSize is the number of queues, topics, or messages returned
+	// by a list call when the caller does not specify a page size.
+	defaultPageSize = 100
+
+	// maxPageSize is the maximum number of queues, topics, or messages
+	// returned by a list call.
+	maxPageSize = 1000
+)
+
+// pageSize validates the requested page size, applying the default and maximum.
+func pageSize(size int32) (int, error) {
+	switch {
+	case size < 0:
+		return 0, e.ErrInvalid(errors.Errorf("page size %d is negative", size))
+	case size == 0:
+		return defaultPageSize, nil
+	case size > maxPageSize:
+		return maxPageSize, nil
+	}
+	return int(size), nil
+}
+
+// A resource is a listable queue or topic.
+type resource interface {
+	ID() uuid.UUID
+	Created() time.Time
+}
+
+// A pageToken identifies the last resource returned by a page of results.
+// Queues and topics are listed in order of creation time then ID, so the next
+// page starts with the first resource that sorts after the token. This remains
+// correct when resources are added or deleted between calls.
+type pageToken struct {
+	created int64 // Unix nanoseconds.
+	id      uuid.UUID
+}
+
+func tokenFor(r resource) *pageToken {
+	return &pageToken{created: r.Created().UnixNano(), id: r.ID()}
+}
+
+func parsePageToken(s string) (*pageToken, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, e.ErrInvalid(errors.Wrap(err, "cannot decode page token"))
+	}
+	if len(b) != 8+len(uuid.UUID{}) {
+		return nil, e.ErrInvalid(errors.Errorf("page token has invalid length %d", len(b)))
+	}
+	t := &pageToken{created: int64(binary.BigEndian.Uint64(b[:8]))}
+	copy(t.id[:], b[8:])
+	return t, nil
+}
+
+func (t *pageToken) String() string {
+	b := make([]byte, 8, 8+len(t.id))
+	binary.BigEndian.PutUint64(b, uint64(t.created))
+	b = append(b, t.id[:]...)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// before reports whether the resource identified by this token sorts before
+// the supplied resource. Creation times are compared as Unix nanoseconds, as
+// they are when queues and topics are listed.
+func (t *pageToken) before(r resource) bool {
+	if created := r.Created().UnixNano(); t.created != created {
+		return t.created < created
+	}
+	id := r.ID()
+	return bytes.Compare(t.id[:], id[:]) < 0
+}
+
+// paginate returns the bounds of the page of the supplied number of ordered
+// resources that starts after the supplied token, and the token for the next
+// page. The next page token is empty when there are no more resources. at
+// returns the resource at the supplied index.
+func paginate(length int, at func(i int) resource, size int32, token string) (int, int, string, error) {
+	n, err := pageSize(size)
+	if err != nil {
+		return 0, 0, "", err
+	}
+
+	start := 0
+	if token != "" {
+		t, err := parsePageToken(token)
+		if err != nil {
+			return 0, 0, "", err
+		}
+		start = sort.Search(length, func(i int) bool { return t.before(at(i)) })
+	}
+
+	if length-start <= n {
+		return start, length, "", nil
+	}
+	end := start + n
+	return start, end, tokenFor(at(end - 1)).String(), nil
+}
+
+// An offset token identifies the offset at which the next page of messages
+// starts. Message offsets are never reused, so this remains correct when
+// messages are added or consumed between calls.
+func offsetToken(offset uint64) string {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, offset)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func parseOffsetToken(s string) (uint64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return 0, e.ErrInvalid(errors.Wrap(err, "cannot decode page token"))
+	}
+	if len(b) != 8 {
+		return 0, e.ErrInvalid(errors.Errorf("page token has invalid length %d", len(b)))
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
@@ -0,0 +1,61 @@
+package rpc
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type listed struct {
+	id      uuid.UUID
+	created time.Time
+}
+
+func (l listed) ID() uuid.UUID      { return l.id }
+func (l listed) Created() time.Time { return l.created }
+
+func TestPaginate(t *testing.T) {
+	// Creation times carry a monotonic clock reading, which page tokens do
+	// not. Some resources share a creation time, and so are ordered by ID.
+	now := time.Now()
+	resources := []listed{}
+	for i := 0; i < 10; i++ {
+		created := now.Add(time.Duration(i/2) * time.Nanosecond)
+		resources = append(resources, listed{id: uuid.New(), created: created})
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		ci, cj := resources[i].created.UnixNano(), resources[j].created.UnixNano()
+		if ci != cj {
+			return ci < cj
+		}
+		return bytes.Compare(resources[i].id[:], resources[j].id[:]) < 0
+	})
+	at := func(i int) resource { return resources[i] }
+
+	got := []uuid.UUID{}
+	token := ""
+	for {
+		start, end, next, err := paginate(len(resources), at, 3, token)
+		if err != nil {
+			t.Fatalf("paginate(%q): %v", token, err)
+		}
+		for i := start; i < end; i++ {
+			got = append(got, resources[i].id)
+		}
+		if next == "" {
+			break
+		}
+		token = next
+	}
+	if len(got) != len(resources) {
+		t.Fatalf("paginate(): want %v resources, got %v", len(resources), len(got))
+	}
+	for i, id := range got {
+		if id != resources[i].id {
+			t.Errorf("paginate(): want resource %v at %v, got %v", resources[i].id, i, id)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package rpc
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/negz/q"
+	"github.com/negz/q/proto"
+)
+
+// A Permission allows a principal to perform a class of operations on a queue.
+type Permission int
+
+// Permissions.
+const (
+	// Read allows a principal to get a queue and to read its messages without
+	// consuming them.
+	Read Permission = iota
+
+	// Produce allows a principal to add messages to a queue.
+	Produce
+
+	// Consume allows a principal to consume and delete a queue's messages,
+	// including via consumer groups.
+	Consume
+
+	// Admin allows a principal to create, update, purge, and delete a queue,
+	// and to subscribe it to topics. Admin implies every other permission.
+	Admin
+)
+
+var permissions = []string{Read: "read", Produce: "produce", Consume: "consume", Admin: "admin"}
+
+func (p Permission) String() string {
+	if p < 0 || int(p) >= len(permissions) {
+		return "unknown"
+	}
+	return permissions[p]
+}
+
+// ParsePermission parses the name of a permission, e.g. "consume".
+func ParsePermission(name string) (Permission, error) {
+	for p, n := range permissions {
+		if n == name {
+			return Permission(p), nil
+		}
+	}
+	return 0, errors.Errorf("unknown permission %q", name)
+}
+
+// A Grant allows a principal to perform operations on the queues it selects.
+type Grant struct {
+	// Principal is granted permissions. "*" grants them to every principal.
+	Principal string
+
+	// Permissions granted to the principal.
+	Permissions []Permission
+
+	// Queues selects the queues tagged with all of these tags. A grant with no
+	// tags selects every queue, and is required for operations that do not
+	// concern a particular queue, such as listing queues or managing topics.
+	Queues []q.Tag
+}
+
+// allows determines whether a grant allows the supplied principal to perform
+// operations requiring the supplied permission on a queue with the supplied
+// tags. Nil tags require a grant that selects every queue.
+func (g Grant) allows(principal string, p Permission, tags *q.Tags) bool {
+	if g.Principal != "*" && g.Principal != principal {
+		return false
+	}
+	if len(g.Queues) > 0 && (tags == nil || !tags.ContainsAll(g.Queues...)) {
+		return false
+	}
+	for _, gp := range g.Permissions {
+		if gp == p || gp == Admin {
+			return true
+		}
+	}
+	return false
+}
+
+// A Policy authorizes principals to perform operations on queues. A principal
+// may perform an operation if any of the policy's grants allow it.
+type Policy []Grant
+
+// Allows determines whether a policy allows the supplied principal to perform
+// operations requiring the supplied permission on a queue with the supplied
+// tags. Nil tags require a grant that selects every queue.
+func (p Policy) Allows(principal string, perm Permission, tags *q.Tags) bool {
+	for _, g := range p {
+		if g.allows(principal, perm, tags) {
+			return true
+		}
+	}
+	return false
+}
+
+type jsonGrant struct {
+	Principal   string            `json:"principal"`
+	Permissions []string          `json:"permissions"`
+	Queues      map[string]string `json:"queues"`
+}
+
+// LoadPolicy loads a policy from the supplied JSON file. The file is read once.
+// For example:
+//
+//	{"grants": [
+//	  {"principal": "ops", "permissions": ["admin"]},
+//	  {"principal": "*", "permissions": ["read"], "queues": {"public": "true"}},
+//	  {"principal": "billing", "permissions": ["produce", "consume"], "queues": {"team": "billing"}}
+//	]}
+func LoadPolicy(file string) (Policy, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot open %s", file)
+	}
+	defer f.Close()
+
+	j := struct {
+		Grants []jsonGrant `json:"grants"`
+	}{}
+	if err := json.NewDecoder(f).Decode(&j); err != nil {
+		return nil, errors.Wrapf(err, "cannot decode %s", file)
+	}
+	p := make(Policy, 0, len(j.Grants))
+	for i, jg := range j.Grants {
+		if jg.Principal == "" {
+			return nil, errors.Errorf("%s grant %d: no principal", file, i)
+		}
+		g := Grant{Principal: jg.Principal}
+		for _, name := range jg.Permissions {
+			perm, err := ParsePermission(name)
+			if err != nil {
+				return nil, errors.Wrapf(err, "%s grant %d", file, i)
+			}
+			g.Permissions = append(g.Permissions, perm)
+		}
+		for k, v := range jg.Queues {
+			g.Queues = append(g.Queues, q.Tag{Key: k, Value: v})
+		}
+		p = append(p, g)
+	}
+	return p, nil
+}
+
+// A target determines the tags of the queue a request concerns, and describes
+// the queue for use in errors. Nil tags indicate that the request concerns
+// every queue, or a queue that does not exist. An empty description indicates
+// that the request concerns no queue, and so requires no permission.
+type target func(m q.Manager, req interface{}) (*q.Tags, string)
+
+// everyQueue is the target of requests that do not concern a particular queue.
+func everyQueue(_ q.Manager, _ interface{}) (*q.Tags, string) {
+	return nil, "every queue"
+}
+
+// queueOf returns a target that gets the queue with the ID returned by the
+// supplied function from the manager.
+func queueOf(id func(req interface{}) string) target {
+	return func(m q.Manager, req interface{}) (*q.Tags, string) {
+		uid, err := proto.ParseID(id(req))
+		if err != nil {
+			return nil, "every queue"
+		}
+		qu, err := m.Get(uid)
+		if err != nil {
+			return nil, "every queue"
+		}
+		return qu.Tags(), "queue " + uid.String()
+	}
+}
+
+var (
+	queueID = queueOf(func(req interface{}) string {
+		return req.(interface{ GetQueueId() string }).GetQueueId()
+	})
+	sourceQueueID = queueOf(func(req interface{}) string {
+		return req.(interface{ GetSourceQueueId() string }).GetSourceQueueId()
+	})
+	destinationQueueID = queueOf(func(req interface{}) string {
+		return req.(interface{ GetDestinationQueueId() string }).GetDestinationQueueId()
+	})
+	subscriberQueueID = queueOf(func(req interface{}) string {
+		return req.(*proto.SubscribeRequest).GetSubscription().GetQueueId()
+	})
+	replyToQueueID = queueOf(func(req interface{}) string {
+		return req.(*proto.RequestRequest).GetMessage().GetReplyTo()
+	})
+)
+
+// replyTo is the target of requests that consume replies from the queue named
+// by their message's reply_to. Requests whose message names no reply queue
+// consume replies from a temporary queue, and so concern no queue.
+func replyTo(m q.Manager, req interface{}) (*q.Tags, string) {
+	if req.(*proto.RequestRequest).GetMessage().GetReplyTo() == "" {
+		return nil, ""
+	}
+	return replyToQueueID(m, req)
+}
+
+// newQueueTags is the target of requests to create a queue, which concern a
+// queue with the requested tags.
+func newQueueTags(_ q.Manager, req interface{}) (*q.Tags, string) {
+	tags := &q.Tags{}
+	for _, t := range proto.ToTags(req.(*proto.NewQueueRequest).GetTags()) {
+		tags.AddTag(t)
+	}
+	return tags, "new queue"
+}
+
+// A requirement is a permission a principal must hold on the target of a
+// request.
+type requirement struct {
+	p      Permission
+	target target
+}
+
+// requirements of each gRPC method, keyed by method name. Methods that are not
+// listed require the admin permission on every queue.
+var requirements = map[string][]requirement{
+	"ListQueues":     {{Read, everyQueue}},
+	"NewQueue":       {{Admin, newQueueTags}},
+	"GetQueue":       {{Read, queueID}},
+	"UpdateQueue":    {{Admin, queueID}},
+	"DeleteQueue":    {{Admin, queueID}},
+	"PurgeQueue":     {{Admin, queueID}},
+	"AddQueueTag":    {{Admin, queueID}},
+	"DeleteQueueTag": {{Admin, queueID}},
+	"Add":            {{Produce, queueID}},
+	"AddBatch":       {{Produce, queueID}},
+	"Request":        {{Produce, queueID}, {Consume, replyTo}},
+	"Pop":            {{Consume, queueID}},
+	"Peek":           {{Read, queueID}},
+	"ListMessages":   {{Read, queueID}},
+	"GetMessage":     {{Read, queueID}},
+	"DeleteMessage":  {{Consume, queueID}},
+	"MoveMessages":   {{Consume, sourceQueueID}, {Produce, destinationQueueID}},
+	"CopyMessages":   {{Read, sourceQueueID}, {Produce, destinationQueueID}},
+	"ListTopics":     {{Read, everyQueue}},
+	"NewTopic":       {{Admin, everyQueue}},
+	"GetTopic":       {{Read, everyQueue}},
+	"DeleteTopic":    {{Admin, everyQueue}},
+	"Subscribe":      {{Admin, subscriberQueueID}},
+	"Unsubscribe":    {{Admin, queueID}},
+	"Publish":        {{Produce, everyQueue}},
+	"ListGroups":     {{Read, queueID}},
+	"NewGroup":       {{Consume, queueID}},
+	"GetGroup":       {{Read, queueID}},
+	"DeleteGroup":    {{Consume, queueID}},
+	"Read":           {{Consume, queueID}},
+	"Commit":         {{Consume, queueID}},
+	"Seek":           {{Consume, queueID}},
+}
@@ -0,0 +1,83 @@
+package rpc
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/negz/q"
+)
+
+func TestPolicy(t *testing.T) {
+	f, err := ioutil.TempFile("", "qtestpolicy")
+	if err != nil {
+		t.Fatalf("ioutil.TempFile(): %v", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(`{"grants": [
+		{"principal": "ops", "permissions": ["admin"]},
+		{"principal": "*", "permissions": ["read"], "queues": {"public": "true"}},
+		{"principal": "billing", "permissions": ["produce", "consume"], "queues": {"team": "billing", "env": "prod"}}
+	]}`); err != nil {
+		t.Fatalf("f.WriteString(): %v", err)
+	}
+	f.Close()
+
+	p, err := LoadPolicy(f.Name())
+	if err != nil {
+		t.Fatalf("LoadPolicy(%v): %v", f.Name(), err)
+	}
+
+	billing, public, both := &q.Tags{}, &q.Tags{}, &q.Tags{}
+	billing.AddMap(map[string]string{"team": "billing", "env": "prod"})
+	public.Add("public", "true")
+	both.AddMap(map[string]string{"team": "billing", "env": "prod", "public": "true"})
+
+	cases := []struct {
+		name      string
+		principal string
+		p         Permission
+		tags      *q.Tags
+		want      bool
+	}{
+		{name: "AdminImpliesAll", principal: "ops", p: Consume, tags: billing, want: true},
+		{name: "EveryQueue", principal: "ops", p: Read, tags: nil, want: true},
+		{name: "Selected", principal: "billing", p: Produce, tags: billing, want: true},
+		{name: "NotGranted", principal: "billing", p: Admin, tags: billing, want: false},
+		{name: "NotSelected", principal: "billing", p: Produce, tags: public, want: false},
+		{name: "SelectorNotEveryQueue", principal: "billing", p: Produce, tags: nil, want: false},
+		{name: "Wildcard", principal: "anyone", p: Read, tags: public, want: true},
+		{name: "WildcardNotGranted", principal: "anyone", p: Consume, tags: public, want: false},
+		{name: "AnyGrant", principal: "billing", p: Read, tags: both, want: true},
+		{name: "UnknownPrincipal", principal: "anyone", p: Read, tags: billing, want: false},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.Allows(tt.principal, tt.p, tt.tags); got != tt.want {
+				t.Errorf("p.Allows(%v, %v, ...): want %v, got %v", tt.principal, tt.p, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLoadPolicyErrors(t *testing.T) {
+	cases := map[string]string{
+		"NotJSON":           `grants`,
+		"NoPrincipal":       `{"grants": [{"permissions": ["read"]}]}`,
+		"UnknownPermission": `{"grants": [{"principal": "ops", "permissions": ["delete"]}]}`,
+	}
+	for name, policy := range cases {
+		t.Run(name, func(t *testing.T) {
+			f, err := ioutil.TempFile("", "qtestpolicy")
+			if err != nil {
+				t.Fatalf("ioutil.TempFile(): %v", err)
+			}
+			defer os.Remove(f.Name())
+			f.WriteString(policy)
+			f.Close()
+			if _, err := LoadPolicy(f.Name()); err == nil {
+				t.Errorf("LoadPolicy(%v): want error", policy)
+			}
+		})
+	}
+}
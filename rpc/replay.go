@@ -0,0 +1,56 @@
+package rpc
+
+import (
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+	"github.com/negz/q/proto"
+)
+
+func (s *qServer) Seek(_ context.Context, r *proto.SeekRequest) (*proto.SeekResponse, error) {
+	if r.GetGroup() != "" {
+		return s.seekGroup(r)
+	}
+	id, err := proto.ParseID(r.GetQueueId())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot parse ID"))
+	}
+	queue, err := s.m.Get(id)
+	if err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot get queue %s", id))
+	}
+	sk, ok := queue.(q.Seeker)
+	if !ok {
+		return nil, e.GRPC(e.ErrInvalid(errors.Errorf("queue %s does not retain consumed messages", id)))
+	}
+
+	var replayed []*q.Message
+	if r.GetTime() != nil {
+		replayed, err = sk.SeekTime(proto.ToTime(r.GetTime()))
+	} else {
+		replayed, err = sk.Seek(r.GetOffset())
+	}
+	if err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot seek queue %s", id))
+	}
+	return &proto.SeekResponse{Replayed: int64(len(replayed))}, nil
+}
+
+func (s *qServer) seekGroup(r *proto.SeekRequest) (*proto.SeekResponse, error) {
+	l, err := s.log(r.GetQueueId())
+	if err != nil {
+		return nil, e.GRPC(err)
+	}
+	var g q.Group
+	if r.GetTime() != nil {
+		g, err = l.SeekTime(r.GetGroup(), proto.ToTime(r.GetTime()))
+	} else {
+		g, err = l.Seek(r.GetGroup(), r.GetOffset())
+	}
+	if err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot seek consumer group %s", r.GetGroup()))
+	}
+	return &proto.SeekResponse{Group: proto.FromGroup(g)}, nil
+}
@@ -0,0 +1,116 @@
+package rpc
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+	"github.com/negz/q/proto"
+)
+
+// pollInterval is how often Request and Pop check for messages in queues that
+// cannot notify them when messages are added.
+const pollInterval = 25 * time.Millisecond
+
+// Request adds a message to a queue and waits until a correlated reply is added
+// to the message's reply queue, or the deadline of its context passes.
+func (s *qServer) Request(ctx context.Context, r *proto.RequestRequest) (*proto.RequestResponse, error) {
+	id, err := proto.ParseID(r.GetQueueId())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot parse ID"))
+	}
+	queue, err := s.m.Get(id)
+	if err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot get queue %s", id))
+	}
+	m, err := proto.ToNewMessage(r.GetMessage())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot parse message"))
+	}
+	if m.CorrelationID == "" {
+		m.CorrelationID = uuid.New().String()
+	}
+	if m.ReplyTo == uuid.Nil {
+		c := q.Config{Limit: q.Unbounded, MaxBytes: s.d.MaxBytes, MaxMessageBytes: s.d.MaxMessageBytes}
+		tmp, err := s.f.New(q.Memory, c)
+		if err != nil {
+			return nil, e.GRPC(errors.Wrap(err, "cannot create temporary reply queue"))
+		}
+		if err := s.m.Add(tmp); err != nil {
+			return nil, e.GRPC(errors.Wrap(err, "cannot add temporary reply queue to manager"))
+		}
+		defer s.m.Delete(tmp.ID(), true)
+		m.ReplyTo = tmp.ID()
+	}
+	replies, err := s.m.Get(m.ReplyTo)
+	if err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot get reply queue %s", m.ReplyTo))
+	}
+
+	if err := queue.AddContext(ctx, m); err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot add message to queue"))
+	}
+	reply, err := awaitReply(ctx, replies, m.CorrelationID)
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot receive reply"))
+	}
+	pm, err := proto.FromMessage(reply)
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot marshal message to protobuf"))
+	}
+	return &proto.RequestResponse{Reply: pm}, nil
+}
+
+// awaitReply consumes and returns the first message in the supplied queue with
+// the supplied correlation ID, waiting until one arrives or the supplied
+// context is done. Messages with other correlation IDs are left in place. The
+// queue is searched again each time a message is added to it, or periodically
+// if it cannot notify us of added messages.
+func awaitReply(ctx context.Context, queue q.Queue, correlationID string) (*q.Message, error) {
+	var poll <-chan time.Time
+	for {
+		// We ask to be notified before searching so that we don't miss a
+		// reply added while we search.
+		added := q.Added(queue)
+		if added == nil && poll == nil {
+			t := time.NewTicker(pollInterval)
+			defer t.Stop()
+			poll = t.C
+		}
+
+		var reply *q.Message
+		if err := queue.Walk(0, func(_ uint64, m *q.Message) bool {
+			if m.CorrelationID != correlationID {
+				return true
+			}
+			reply = m
+			return false
+		}); err != nil {
+			return nil, errors.Wrapf(err, "cannot search queue %s", queue.ID())
+		}
+		if reply != nil {
+			err := queue.Delete(reply.ID)
+			if err == nil {
+				return reply, nil
+			}
+			// Another consumer may have taken the reply first.
+			if !e.IsNotFound(err) {
+				return nil, errors.Wrapf(err, "cannot consume reply %s", reply.ID)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			err := errors.Wrapf(ctx.Err(), "no reply correlated with %s arrived on queue %s", correlationID, queue.ID())
+			if ctx.Err() == context.Canceled {
+				return nil, e.ErrCanceled(err)
+			}
+			return nil, e.ErrDeadlineExceeded(err)
+		case <-added:
+		case <-poll:
+		}
+	}
+}
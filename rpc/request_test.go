@@ -0,0 +1,27 @@
+package rpc
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/negz/q/e"
+	"github.com/negz/q/memory"
+)
+
+func TestAwaitReply(t *testing.T) {
+	queue := memory.New()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := awaitReply(ctx, queue, "apollo"); !e.IsCanceled(err) {
+		t.Errorf("awaitReply(): want error satisfying e.IsCanceled() when abandoned, got %v", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if _, err := awaitReply(ctx, queue, "apollo"); !e.IsDeadlineExceeded(err) {
+		t.Errorf("awaitReply(): want error satisfying e.IsDeadlineExceeded() when timed out, got %v", err)
+	}
+}
@@ -2,11 +2,16 @@
 package rpc
 
 import (
+	"crypto/tls"
+	"math"
 	"net"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/negz/q"
 	"github.com/negz/q/e"
@@ -14,11 +19,33 @@ import (
 	"github.com/negz/q/proto"
 )
 
+const (
+	// DefaultMaxMessageBytes is the default maximum size of a message payload.
+	DefaultMaxMessageBytes = 4 << 20
+
+	// DefaultTemporaryIdleTimeout is the default ExpireAfterIdle of new
+	// temporary queues.
+	DefaultTemporaryIdleTimeout = 10 * time.Minute
+
+	// envelopeBytes is the room allowed for the rest of a request beyond its
+	// message payload when limiting the size of gRPC requests.
+	envelopeBytes = 64 << 10
+)
+
 // A Server serves gRPC requests.
 type Server struct {
-	l net.Listener
-	f q.Factory
-	m q.Manager
+	l    net.Listener
+	f    q.Factory
+	m    q.Manager
+	d    q.Config
+	idle time.Duration
+	tls  *tls.Config
+	auth Authenticator
+	pol  Policy
+
+	g    *grpc.Server
+	sn   *sessions
+	stop sync.Once
 }
 
 // An Option represents an optional argument to a new server.
@@ -32,32 +59,118 @@ func WithQueueFactory(f q.Factory) Option {
 	}
 }
 
+// WithMaxBytes specifies the maximum total size of message payloads of new
+// queues that do not specify their own. Defaults to q.Unbounded.
+func WithMaxBytes(n int) Option {
+	return func(s *Server) {
+		s.d.MaxBytes = n
+	}
+}
+
+// WithMaxMessageBytes specifies the server-wide maximum size of a message
+// payload. New queues that do not specify their own maximum use this value, and
+// the server rejects gRPC requests too large to carry a message of this size.
+// Defaults to DefaultMaxMessageBytes.
+func WithMaxMessageBytes(n int) Option {
+	return func(s *Server) {
+		s.d.MaxMessageBytes = n
+	}
+}
+
+// WithTemporaryIdleTimeout specifies the ExpireAfterIdle of new temporary
+// queues that do not set their own. Idle queues are only deleted by a manager
+// returned by manager.Expiring, which never deletes queues that hold messages;
+// such temporary queues are deleted when the connection that created them
+// closes. Zero disables the timeout. Defaults to DefaultTemporaryIdleTimeout.
+func WithTemporaryIdleTimeout(d time.Duration) Option {
+	return func(s *Server) {
+		s.idle = d
+	}
+}
+
+// WithTLS specifies that a server should serve gRPC requests over TLS using
+// the supplied configuration. Use certs.Server to create a configuration whose
+// certificates are reloaded when they change.
+func WithTLS(c *tls.Config) Option {
+	return func(s *Server) {
+		s.tls = c
+	}
+}
+
+// WithAuthentication specifies that a server should reject requests with
+// codes.Unauthenticated unless the supplied authenticator identifies the
+// principal that made them.
+func WithAuthentication(a Authenticator) Option {
+	return func(s *Server) {
+		s.auth = a
+	}
+}
+
+// WithAuthorization specifies that a server should reject requests with
+// codes.PermissionDenied unless the supplied policy allows the principal that
+// made them. Servers that do not authenticate requests authorize them as the
+// empty principal, which only grants to "*" allow.
+func WithAuthorization(p Policy) Option {
+	return func(s *Server) {
+		s.pol = p
+	}
+}
+
 // NewServer returns a new gRPC server.
 func NewServer(l net.Listener, m q.Manager, o ...Option) *Server {
-	s := &Server{l: l, f: factory.Default, m: m}
+	d := q.Config{MaxBytes: q.Unbounded, MaxMessageBytes: DefaultMaxMessageBytes}
+	s := &Server{l: l, f: factory.Default, m: m, d: d, idle: DefaultTemporaryIdleTimeout}
 	for _, opt := range o {
 		opt(s)
 	}
+
+	max := math.MaxInt32
+	if s.d.MaxMessageBytes != q.Unbounded && s.d.MaxMessageBytes < max-envelopeBytes {
+		max = s.d.MaxMessageBytes + envelopeBytes
+	}
+	s.sn = newSessions(s.m)
+	so := []grpc.ServerOption{grpc.MaxRecvMsgSize(max), grpc.StatsHandler(s.sn)}
+	if s.tls != nil {
+		so = append(so, grpc.Creds(credentials.NewTLS(s.tls)))
+	}
+	if s.auth != nil || s.pol != nil {
+		so = append(so, grpc.UnaryInterceptor(s.authorize))
+	}
+	s.g = grpc.NewServer(so...)
+	proto.RegisterQServer(s.g, &qServer{f: s.f, m: s.sn, d: s.d, idle: s.idle, sn: s.sn})
 	return s
 }
 
-// Serve gRPC requests forever.
+// Serve gRPC requests until the server is stopped.
 func (s *Server) Serve() error {
-	g := grpc.NewServer()
-	proto.RegisterQServer(g, &qServer{s.f, s.m})
-	return errors.Wrap(g.Serve(s.l), "cannot serve gRPC requests")
+	return errors.Wrap(s.g.Serve(s.l), "cannot serve gRPC requests")
+}
+
+// Stop the server, waiting for pending requests to complete. Serve returns nil
+// once the server has stopped. Stopping a stopped server has no effect.
+func (s *Server) Stop() {
+	s.stop.Do(s.g.GracefulStop)
 }
 
 type qServer struct {
-	f q.Factory
-	m q.Manager
+	f    q.Factory
+	m    q.Manager
+	d    q.Config      // Defaults for new queues.
+	idle time.Duration // Default ExpireAfterIdle of new temporary queues.
+	sn   *sessions
+	u    updates
 }
 
-func (s *qServer) ListQueues(_ context.Context, _ *proto.ListQueuesRequest) (*proto.ListQueuesResponse, error) {
+func (s *qServer) ListQueues(_ context.Context, r *proto.ListQueuesRequest) (*proto.ListQueuesResponse, error) {
 	l, err := s.m.List()
 	if err != nil {
 		return nil, e.GRPC(errors.Wrap(err, "cannot list queues"))
 	}
+	start, end, next, err := paginate(len(l), func(i int) resource { return l[i] }, r.GetPageSize(), r.GetPageToken())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot paginate queues"))
+	}
+	l = l[start:end]
 	queues := make([]*proto.Queue, 0, len(l))
 	for _, queue := range l {
 		pq, err := proto.FromQueue(queue)
@@ -66,18 +179,38 @@ func (s *qServer) ListQueues(_ context.Context, _ *proto.ListQueuesRequest) (*pr
 		}
 		queues = append(queues, pq)
 	}
-	return &proto.ListQueuesResponse{Queues: queues}, nil
+	return &proto.ListQueuesResponse{Queues: queues, NextPageToken: next}, nil
 }
 
-func (s *qServer) NewQueue(_ context.Context, r *proto.NewQueueRequest) (*proto.NewQueueResponse, error) {
+func (s *qServer) NewQueue(ctx context.Context, r *proto.NewQueueRequest) (*proto.NewQueueResponse, error) {
+	c := q.Config{
+		Limit:           int(r.GetLimit()),
+		MaxBytes:        int(r.GetMaxBytes()),
+		MaxMessageBytes: int(r.GetMaxMessageBytes()),
+		Overflow:        overflow(r.GetOverflow()),
+		Retention:       proto.ToDuration(r.GetRetention()),
+		ExpireAfterIdle: proto.ToDuration(r.GetExpireAfterIdle()),
+	}
+	if c.MaxBytes == 0 {
+		c.MaxBytes = s.d.MaxBytes
+	}
+	if c.MaxMessageBytes == 0 {
+		c.MaxMessageBytes = s.d.MaxMessageBytes
+	}
+	if r.GetTemporary() && c.ExpireAfterIdle == 0 {
+		c.ExpireAfterIdle = s.idle
+	}
 	tags := proto.ToTags(r.GetTags())
-	queue, err := s.f.New(proto.ToStore[r.GetStore()], int(r.GetLimit()), tags...)
+	queue, err := s.f.New(proto.ToStore[r.GetStore()], c, tags...)
 	if err != nil {
 		return nil, e.GRPC(errors.Wrap(err, "cannot create new queue"))
 	}
 	if aerr := s.m.Add(queue); aerr != nil {
 		return nil, e.GRPC(errors.Wrap(aerr, "cannot add queue to manager"))
 	}
+	if r.GetTemporary() {
+		s.sn.temporary(ctx, queue.ID())
+	}
 	pq, err := proto.FromQueue(queue)
 	if err != nil {
 		return nil, e.GRPC(errors.Wrap(err, "cannot marshal queue to protobuf"))
@@ -101,17 +234,58 @@ func (s *qServer) GetQueue(_ context.Context, r *proto.GetQueueRequest) (*proto.
 	return &proto.GetQueueResponse{Queue: pq}, nil
 }
 
+func (s *qServer) UpdateQueue(_ context.Context, r *proto.UpdateQueueRequest) (*proto.UpdateQueueResponse, error) {
+	id, err := proto.ParseID(r.GetQueueId())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot parse ID"))
+	}
+	queue, err := s.m.Get(id)
+	if err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot get queue %s", id))
+	}
+	unlock := s.u.lock(id)
+	defer unlock()
+	c, err := update(queue.Config(), r.GetConfig(), r.GetUpdateMask())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot apply update mask"))
+	}
+	if err := queue.Configure(c); err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot update queue %s", id))
+	}
+	pq, err := proto.FromQueue(queue)
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot marshal queue to protobuf"))
+	}
+	return &proto.UpdateQueueResponse{Queue: pq}, nil
+}
+
 func (s *qServer) DeleteQueue(_ context.Context, r *proto.DeleteQueueRequest) (*proto.DeleteQueueResponse, error) {
 	id, err := proto.ParseID(r.GetQueueId())
 	if err != nil {
 		return nil, e.GRPC(errors.Wrap(err, "cannot parse ID"))
 	}
-	if err := s.m.Delete(id); err != nil {
+	if err := s.m.Delete(id, r.GetForce()); err != nil {
 		return nil, e.GRPC(errors.Wrapf(err, "cannot delete queue %s", id))
 	}
 	return &proto.DeleteQueueResponse{}, nil
 }
 
+func (s *qServer) PurgeQueue(_ context.Context, r *proto.PurgeQueueRequest) (*proto.PurgeQueueResponse, error) {
+	id, err := proto.ParseID(r.GetQueueId())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot parse ID"))
+	}
+	queue, err := s.m.Get(id)
+	if err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot get queue %s", id))
+	}
+	n, err := queue.Purge()
+	if err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot purge queue %s", id))
+	}
+	return &proto.PurgeQueueResponse{Purged: int64(n)}, nil
+}
+
 func (s *qServer) AddQueueTag(_ context.Context, r *proto.AddQueueTagRequest) (*proto.AddQueueTagResponse, error) {
 	id, err := proto.ParseID(r.GetQueueId())
 	if err != nil {
@@ -146,7 +320,9 @@ func (s *qServer) DeleteQueueTag(_ context.Context, r *proto.DeleteQueueTagReque
 	return &proto.DeleteQueueTagResponse{}, nil
 }
 
-func (s *qServer) Add(_ context.Context, r *proto.AddRequest) (*proto.AddResponse, error) {
+// Add honours the deadline of its context when adding to a queue whose
+// overflow policy blocks producers.
+func (s *qServer) Add(ctx context.Context, r *proto.AddRequest) (*proto.AddResponse, error) {
 	id, err := proto.ParseID(r.GetQueueId())
 	if err != nil {
 		return nil, e.GRPC(errors.Wrap(err, "cannot parse ID"))
@@ -155,10 +331,11 @@ func (s *qServer) Add(_ context.Context, r *proto.AddRequest) (*proto.AddRespons
 	if err != nil {
 		return nil, e.GRPC(errors.Wrapf(err, "cannot get queue %s", id))
 	}
-	p := r.GetMessage().GetPayload()
-	tags := proto.ToTags(r.GetMessage().GetTags())
-	m := q.NewMessage(p, q.Tagged(tags...))
-	if aerr := queue.Add(m); aerr != nil {
+	m, err := proto.ToNewMessage(r.GetMessage())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot parse message"))
+	}
+	if aerr := queue.AddContext(ctx, m); aerr != nil {
 		return nil, e.GRPC(errors.Wrap(aerr, "cannot add message to queue"))
 	}
 	pm, err := proto.FromMessage(m)
@@ -168,7 +345,7 @@ func (s *qServer) Add(_ context.Context, r *proto.AddRequest) (*proto.AddRespons
 	return &proto.AddResponse{Message: pm}, nil
 }
 
-func (s *qServer) Pop(_ context.Context, r *proto.PopRequest) (*proto.PopResponse, error) {
+func (s *qServer) AddBatch(ctx context.Context, r *proto.AddBatchRequest) (*proto.AddBatchResponse, error) {
 	id, err := proto.ParseID(r.GetQueueId())
 	if err != nil {
 		return nil, e.GRPC(errors.Wrap(err, "cannot parse ID"))
@@ -177,7 +354,44 @@ func (s *qServer) Pop(_ context.Context, r *proto.PopRequest) (*proto.PopRespons
 	if err != nil {
 		return nil, e.GRPC(errors.Wrapf(err, "cannot get queue %s", id))
 	}
-	m, err := queue.Pop()
+	added := make([]*proto.Message, 0, len(r.GetMessages()))
+	for i, nm := range r.GetMessages() {
+		// Each message is marshalled before it is added so that every
+		// message we add can be reported.
+		var pm *proto.Message
+		m, err := proto.ToNewMessage(nm)
+		if err != nil {
+			err = errors.Wrap(err, "cannot parse message")
+		} else if pm, err = proto.FromMessage(m); err != nil {
+			err = errors.Wrap(err, "cannot marshal message to protobuf")
+		} else if aerr := queue.AddContext(ctx, m); aerr != nil {
+			err = errors.Wrap(aerr, "cannot add message to queue")
+		}
+		if err != nil {
+			// Report the messages added so far. The caller may resend the
+			// rest to learn why they could not be added.
+			if i > 0 {
+				break
+			}
+			return nil, e.GRPC(err)
+		}
+		added = append(added, pm)
+	}
+	return &proto.AddBatchResponse{Messages: added}, nil
+}
+
+func (s *qServer) Pop(ctx context.Context, r *proto.PopRequest) (*proto.PopResponse, error) {
+	id, err := proto.ParseID(r.GetQueueId())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot parse ID"))
+	}
+	queue, err := s.m.Get(id)
+	if err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot get queue %s", id))
+	}
+	wctx, cancel := context.WithTimeout(ctx, proto.ToDuration(r.GetWait()))
+	defer cancel()
+	m, err := awaitPop(wctx, queue, proto.ToTags(r.GetFilter()))
 	if err != nil {
 		return nil, e.GRPC(errors.Wrap(err, "cannot pop message from queue"))
 	}
@@ -188,6 +402,36 @@ func (s *qServer) Pop(_ context.Context, r *proto.PopRequest) (*proto.PopRespons
 	return &proto.PopResponse{Message: pm}, nil
 }
 
+// awaitPop pops the first message in the supplied queue that is tagged with all
+// of the supplied tags, waiting until one arrives or the supplied context is
+// done. The queue is popped again each time a message is added to it, or
+// periodically if it cannot notify us of added messages. The error returned
+// when the context is done is that of the last pop.
+func awaitPop(ctx context.Context, queue q.Queue, t []q.Tag) (*q.Message, error) {
+	var poll <-chan time.Time
+	for {
+		// We ask to be notified before popping so that we don't miss a
+		// message added while we pop.
+		added := q.Added(queue)
+		if added == nil && poll == nil {
+			tk := time.NewTicker(pollInterval)
+			defer tk.Stop()
+			poll = tk.C
+		}
+
+		m, err := queue.PopMatching(t...)
+		if err == nil || e.ReasonOf(err) != e.QUEUE_EMPTY {
+			return m, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-added:
+		case <-poll:
+		}
+	}
+}
+
 func (s *qServer) Peek(_ context.Context, r *proto.PeekRequest) (*proto.PeekResponse, error) {
 	id, err := proto.ParseID(r.GetQueueId())
 	if err != nil {
@@ -197,7 +441,7 @@ func (s *qServer) Peek(_ context.Context, r *proto.PeekRequest) (*proto.PeekResp
 	if err != nil {
 		return nil, e.GRPC(errors.Wrapf(err, "cannot get queue %s", id))
 	}
-	m, err := queue.Peek()
+	m, err := queue.PeekMatching(proto.ToTags(r.GetFilter())...)
 	if err != nil {
 		return nil, e.GRPC(errors.Wrap(err, "cannot peek into queue"))
 	}
@@ -207,3 +451,145 @@ func (s *qServer) Peek(_ context.Context, r *proto.PeekRequest) (*proto.PeekResp
 	}
 	return &proto.PeekResponse{Message: pm}, nil
 }
+
+func (s *qServer) ListMessages(_ context.Context, r *proto.ListMessagesRequest) (*proto.ListMessagesResponse, error) {
+	id, err := proto.ParseID(r.GetQueueId())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot parse ID"))
+	}
+	queue, err := s.m.Get(id)
+	if err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot get queue %s", id))
+	}
+	size, err := pageSize(r.GetPageSize())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot paginate messages"))
+	}
+	offset, err := parseOffsetToken(r.GetPageToken())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot paginate messages"))
+	}
+	preview := int(r.GetPreviewLength())
+	if preview < 0 {
+		return nil, e.GRPC(e.ErrInvalid(errors.Errorf("preview length %d is negative", preview)))
+	}
+
+	tags := proto.ToTags(r.GetFilter())
+	rsp := &proto.ListMessagesResponse{}
+	var merr error
+	werr := queue.Walk(offset, func(o uint64, m *q.Message) bool {
+		if !m.Tags.ContainsAll(tags...) {
+			return true
+		}
+		if len(rsp.Messages) == size {
+			// There is at least one more matching message.
+			rsp.NextPageToken = offsetToken(o)
+			return false
+		}
+		pm, err := proto.FromMessage(m)
+		if err != nil {
+			merr = errors.Wrap(err, "cannot marshal message to protobuf")
+			return false
+		}
+		if preview > 0 && len(pm.Payload) > preview {
+			pm.Payload = pm.Payload[:preview]
+		}
+		rsp.Messages = append(rsp.Messages, pm)
+		return true
+	})
+	if werr != nil {
+		return nil, e.GRPC(errors.Wrap(werr, "cannot list messages in queue"))
+	}
+	if merr != nil {
+		return nil, e.GRPC(merr)
+	}
+	return rsp, nil
+}
+
+func (s *qServer) GetMessage(_ context.Context, r *proto.GetMessageRequest) (*proto.GetMessageResponse, error) {
+	id, err := proto.ParseID(r.GetQueueId())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot parse ID"))
+	}
+	mid, err := proto.ParseID(r.GetMessageId())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot parse message ID"))
+	}
+	queue, err := s.m.Get(id)
+	if err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot get queue %s", id))
+	}
+	m, err := queue.Get(mid)
+	if err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot get message %s", mid))
+	}
+	pm, err := proto.FromMessage(m)
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot marshal message to protobuf"))
+	}
+	return &proto.GetMessageResponse{Message: pm}, nil
+}
+
+func (s *qServer) DeleteMessage(_ context.Context, r *proto.DeleteMessageRequest) (*proto.DeleteMessageResponse, error) {
+	id, err := proto.ParseID(r.GetQueueId())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot parse ID"))
+	}
+	mid, err := proto.ParseID(r.GetMessageId())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot parse message ID"))
+	}
+	queue, err := s.m.Get(id)
+	if err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot get queue %s", id))
+	}
+	if err := queue.Delete(mid); err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot delete message %s", mid))
+	}
+	return &proto.DeleteMessageResponse{}, nil
+}
+
+func (s *qServer) MoveMessages(ctx context.Context, r *proto.MoveMessagesRequest) (*proto.MoveMessagesResponse, error) {
+	n, err := s.transfer(ctx, r.GetSourceQueueId(), r.GetDestinationQueueId(), r.GetMaxCount(), true, r.GetFilter())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot move messages after moving %d", n))
+	}
+	return &proto.MoveMessagesResponse{Moved: int64(n)}, nil
+}
+
+func (s *qServer) CopyMessages(ctx context.Context, r *proto.CopyMessagesRequest) (*proto.CopyMessagesResponse, error) {
+	n, err := s.transfer(ctx, r.GetSourceQueueId(), r.GetDestinationQueueId(), r.GetMaxCount(), false, r.GetFilter())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot copy messages after copying %d", n))
+	}
+	return &proto.CopyMessagesResponse{Copied: int64(n)}, nil
+}
+
+// transfer transfers up to max messages from the source to the destination
+// queue. A max of zero transfers all messages.
+func (s *qServer) transfer(ctx context.Context, srcID, dstID string, max int64, move bool, filter []*proto.Tag) (int, error) {
+	if max < 0 {
+		return 0, e.ErrInvalid(errors.Errorf("invalid maximum count %d", max))
+	}
+	limit := int(max)
+	if limit == 0 {
+		limit = q.Unbounded
+	}
+	sid, err := proto.ParseID(srcID)
+	if err != nil {
+		return 0, errors.Wrap(err, "cannot parse source queue ID")
+	}
+	did, err := proto.ParseID(dstID)
+	if err != nil {
+		return 0, errors.Wrap(err, "cannot parse destination queue ID")
+	}
+	src, err := s.m.Get(sid)
+	if err != nil {
+		return 0, errors.Wrapf(err, "cannot get source queue %s", sid)
+	}
+	dst, err := s.m.Get(did)
+	if err != nil {
+		return 0, errors.Wrapf(err, "cannot get destination queue %s", did)
+	}
+	return q.Transfer(ctx, src, dst, limit, move, proto.ToTags(filter)...)
+}
@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/stats"
+
+	"github.com/negz/q"
+)
+
+// connKey is the context key under which the ID of a client connection is
+// stored.
+type connKey struct{}
+
+// sessions is a queue manager that tracks temporary queues. Temporary queues
+// are deleted when the client connection that created them closes. sessions is
+// also a gRPC stats handler, which is how it learns of client connections.
+type sessions struct {
+	q.Manager
+
+	conns uint64 // Accessed atomically.
+	mx    *sync.Mutex
+	owner map[uuid.UUID]uint64 // Temporary queue ID to connection ID.
+}
+
+func newSessions(m q.Manager) *sessions {
+	return &sessions{
+		Manager: m,
+		mx:      &sync.Mutex{},
+		owner:   make(map[uuid.UUID]uint64),
+	}
+}
+
+// temporary marks the queue with the supplied ID as temporary, owned by the
+// client connection of the supplied context.
+func (s *sessions) temporary(ctx context.Context, id uuid.UUID) {
+	conn, _ := ctx.Value(connKey{}).(uint64)
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.owner[id] = conn
+}
+
+func (s *sessions) Delete(id uuid.UUID, force bool) error {
+	if err := s.Manager.Delete(id, force); err != nil {
+		return err
+	}
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	delete(s.owner, id)
+	return nil
+}
+
+// deleteOwnedBy deletes each temporary queue owned by the supplied connection.
+func (s *sessions) deleteOwnedBy(closed uint64) {
+	s.mx.Lock()
+	ids := []uuid.UUID{}
+	for id, conn := range s.owner {
+		if conn == closed {
+			ids = append(ids, id)
+		}
+	}
+	s.mx.Unlock()
+	for _, id := range ids {
+		// Temporary queues are deleted even if they hold messages. The
+		// manager is responsible for logging failures to delete queues.
+		s.Delete(id, true)
+	}
+}
+
+func (s *sessions) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return context.WithValue(ctx, connKey{}, atomic.AddUint64(&s.conns, 1))
+}
+
+func (s *sessions) HandleConn(ctx context.Context, cs stats.ConnStats) {
+	if _, ok := cs.(*stats.ConnEnd); !ok {
+		return
+	}
+	closed, ok := ctx.Value(connKey{}).(uint64)
+	if !ok {
+		return
+	}
+	s.deleteOwnedBy(closed)
+}
+
+func (s *sessions) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (s *sessions) HandleRPC(_ context.Context, _ stats.RPCStats) {}
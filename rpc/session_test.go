@@ -0,0 +1,70 @@
+package rpc
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/stats"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+	"github.com/negz/q/manager"
+	"github.com/negz/q/memory"
+	"github.com/negz/q/queuetest"
+)
+
+func TestSessionsSuite(t *testing.T) {
+	newManager := func() q.Manager { return newSessions(manager.New()) }
+	newQueue := func(limit int) q.Queue { return memory.New(memory.Limit(limit)) }
+	t.Run("Manager", func(t *testing.T) { queuetest.RunManagerSuite(t, newManager, newQueue) })
+
+	// The suite calls this from its own subtests, so it panics rather than
+	// calling t.Fatalf.
+	sn := newSessions(manager.New())
+	t.Run("Queue", func(t *testing.T) {
+		queuetest.RunQueueSuite(t, func(limit int) q.Queue {
+			queue := newQueue(limit)
+			if err := sn.Add(queue); err != nil {
+				panic(fmt.Sprintf("sn.Add(%v): %v", queue.ID(), err))
+			}
+			got, err := sn.Get(queue.ID())
+			if err != nil {
+				panic(fmt.Sprintf("sn.Get(%v): %v", queue.ID(), err))
+			}
+			return got
+		})
+	})
+}
+
+func TestSessions(t *testing.T) {
+	sn := newSessions(manager.New())
+	closed := sn.TagConn(context.Background(), &stats.ConnTagInfo{})
+	open := sn.TagConn(context.Background(), &stats.ConnTagInfo{})
+
+	temporary := memory.New()
+	kept := memory.New()
+	persistent := memory.New()
+	for _, queue := range []q.Queue{temporary, kept, persistent} {
+		if err := sn.Add(queue); err != nil {
+			t.Fatalf("sn.Add(%v): %v", queue.ID(), err)
+		}
+	}
+	if err := temporary.Add(q.NewMessage([]byte("zarya"))); err != nil {
+		t.Fatalf("temporary.Add(): %v", err)
+	}
+	sn.temporary(closed, temporary.ID())
+	sn.temporary(open, kept.ID())
+
+	// Temporary queues are deleted when their connection closes, even if
+	// they hold messages.
+	sn.HandleConn(closed, &stats.ConnEnd{})
+	if _, err := sn.Get(temporary.ID()); !e.IsNotFound(err) {
+		t.Errorf("sn.Get(%v): want error satisfying e.IsNotFound(), got %v", temporary.ID(), err)
+	}
+	for _, queue := range []q.Queue{kept, persistent} {
+		if _, err := sn.Get(queue.ID()); err != nil {
+			t.Errorf("sn.Get(%v): %v", queue.ID(), err)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package rpc
+
+import (
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+	"github.com/negz/q/proto"
+	"github.com/negz/q/topic"
+)
+
+func (s *qServer) ListTopics(_ context.Context, r *proto.ListTopicsRequest) (*proto.ListTopicsResponse, error) {
+	l, err := s.m.ListTopics()
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot list topics"))
+	}
+	start, end, next, err := paginate(len(l), func(i int) resource { return l[i] }, r.GetPageSize(), r.GetPageToken())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot paginate topics"))
+	}
+	topics := make([]*proto.Topic, 0, end-start)
+	for _, t := range l[start:end] {
+		pt, err := proto.FromTopic(t)
+		if err != nil {
+			return nil, e.GRPC(errors.Wrap(err, "cannot marshal topic to protobuf"))
+		}
+		topics = append(topics, pt)
+	}
+	return &proto.ListTopicsResponse{Topics: topics, NextPageToken: next}, nil
+}
+
+func (s *qServer) NewTopic(_ context.Context, r *proto.NewTopicRequest) (*proto.NewTopicResponse, error) {
+	t := topic.New(topic.Tagged(proto.ToTags(r.GetTags())...))
+	if err := s.m.AddTopic(t); err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot add topic to manager"))
+	}
+	pt, err := proto.FromTopic(t)
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot marshal topic to protobuf"))
+	}
+	return &proto.NewTopicResponse{Topic: pt}, nil
+}
+
+func (s *qServer) GetTopic(_ context.Context, r *proto.GetTopicRequest) (*proto.GetTopicResponse, error) {
+	id, err := proto.ParseID(r.GetTopicId())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot parse ID"))
+	}
+	t, err := s.m.GetTopic(id)
+	if err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot get topic %s", id))
+	}
+	pt, err := proto.FromTopic(t)
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot marshal topic to protobuf"))
+	}
+	return &proto.GetTopicResponse{Topic: pt}, nil
+}
+
+func (s *qServer) DeleteTopic(_ context.Context, r *proto.DeleteTopicRequest) (*proto.DeleteTopicResponse, error) {
+	id, err := proto.ParseID(r.GetTopicId())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot parse ID"))
+	}
+	if err := s.m.DeleteTopic(id); err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot delete topic %s", id))
+	}
+	return &proto.DeleteTopicResponse{}, nil
+}
+
+func (s *qServer) Subscribe(_ context.Context, r *proto.SubscribeRequest) (*proto.SubscribeResponse, error) {
+	id, err := proto.ParseID(r.GetTopicId())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot parse ID"))
+	}
+	if r.GetSubscription() == nil {
+		return nil, e.GRPC(e.ErrInvalid(errors.New("did not supply a subscription")))
+	}
+	sub, err := proto.ToSubscription(r.GetSubscription())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot parse subscription"))
+	}
+	t, err := s.m.GetTopic(id)
+	if err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot get topic %s", id))
+	}
+	if _, err := s.m.Get(sub.Queue); err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot get queue %s", sub.Queue))
+	}
+	if err := t.Subscribe(sub); err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot subscribe queue %s", sub.Queue))
+	}
+	pt, err := proto.FromTopic(t)
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot marshal topic to protobuf"))
+	}
+	return &proto.SubscribeResponse{Topic: pt}, nil
+}
+
+func (s *qServer) Unsubscribe(_ context.Context, r *proto.UnsubscribeRequest) (*proto.UnsubscribeResponse, error) {
+	id, err := proto.ParseID(r.GetTopicId())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot parse ID"))
+	}
+	qid, err := proto.ParseID(r.GetQueueId())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot parse queue ID"))
+	}
+	t, err := s.m.GetTopic(id)
+	if err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot get topic %s", id))
+	}
+	if err := t.Unsubscribe(qid); err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot unsubscribe queue %s", qid))
+	}
+	return &proto.UnsubscribeResponse{}, nil
+}
+
+// Publish returns an error if the message could not be added to one or more
+// subscribed queues, even if it was added to others.
+func (s *qServer) Publish(ctx context.Context, r *proto.PublishRequest) (*proto.PublishResponse, error) {
+	id, err := proto.ParseID(r.GetTopicId())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot parse ID"))
+	}
+	m, err := proto.ToNewMessage(r.GetMessage())
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot parse message"))
+	}
+	n, err := q.Publish(ctx, s.m, id, m)
+	if err != nil {
+		return nil, e.GRPC(errors.Wrapf(err, "cannot publish message after delivering it to %d queues", n))
+	}
+	pm, err := proto.FromMessage(m)
+	if err != nil {
+		return nil, e.GRPC(errors.Wrap(err, "cannot marshal message to protobuf"))
+	}
+	return &proto.PublishResponse{Message: pm, Delivered: int64(n)}, nil
+}
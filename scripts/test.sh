@@ -4,7 +4,7 @@ set -e
 echo "" > coverage.txt
 
 for d in $(go list ./...|grep -v "vendor/"); do
-    go test -tags integration -race -coverprofile=c $d
+    go test -race -coverprofile=c $d
     if [ -f c ]; then
         cat c >> coverage.txt
         rm c
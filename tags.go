@@ -56,6 +56,25 @@ func (t *Tags) ContainsTag(tag Tag) bool {
 	return t.t[tag]
 }
 
+// ContainsAll indicates whether all of the supplied tags exist in a set of
+// tags. It returns true if no tags are supplied.
+func (t *Tags) ContainsAll(tags ...Tag) bool {
+	if len(tags) == 0 {
+		return true
+	}
+	if t.t == nil {
+		return false
+	}
+	t.m.RLock()
+	defer t.m.RUnlock()
+	for _, tag := range tags {
+		if !t.t[tag] {
+			return false
+		}
+	}
+	return true
+}
+
 // Add the supplied key value pair to a set of Tags.
 func (t *Tags) Add(k, v string) {
 	t.AddTag(Tag{k, v})
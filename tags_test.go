@@ -50,6 +50,22 @@ func TestTags(t *testing.T) {
 		}
 	})
 
+	t.Run("ContainsAll", func(t *testing.T) {
+		for _, tt := range tagsTests {
+			all := make([]Tag, 0, len(tt.tags))
+			for k, v := range tt.tags {
+				all = append(all, Tag{k, v})
+			}
+			if !tags.ContainsAll(all...) {
+				t.Errorf("tags.ContainsAll(%v): want true, got false", all)
+			}
+			missing := append(all, Tag{"orbit", "lunar"})
+			if tags.ContainsAll(missing...) {
+				t.Errorf("tags.ContainsAll(%v): want false, got true", missing)
+			}
+		}
+	})
+
 	t.Run("Get", func(t *testing.T) {
 		want := make(map[Tag]bool)
 		got := make(map[Tag]bool)
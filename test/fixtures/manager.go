@@ -24,10 +24,26 @@ func (m *predictableManager) Get(id uuid.UUID) (q.Queue, error) {
 	return m.q, m.err
 }
 
-func (m *predictableManager) Delete(id uuid.UUID) error {
+func (m *predictableManager) Delete(id uuid.UUID, force bool) error {
 	return m.err
 }
 
 func (m *predictableManager) List() ([]q.Queue, error) {
 	return []q.Queue{m.q}, m.err
 }
+
+func (m *predictableManager) AddTopic(t q.Topic) error {
+	return m.err
+}
+
+func (m *predictableManager) GetTopic(id uuid.UUID) (q.Topic, error) {
+	return nil, m.err
+}
+
+func (m *predictableManager) DeleteTopic(id uuid.UUID) error {
+	return m.err
+}
+
+func (m *predictableManager) ListTopics() ([]q.Topic, error) {
+	return []q.Topic{}, m.err
+}
@@ -4,17 +4,20 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"golang.org/x/net/context"
 
 	"github.com/negz/q"
 )
 
 type predictableQueue struct {
-	err error
-	msg *q.Message
+	err   error
+	msg   *q.Message
+	evict []func(*q.Message)
 }
 
 // NewPredictableQueue returns a queue that  always returns the error and/or
-// message provided.
+// message provided. Successfully adding to a queue with a message evicts that
+// message.
 func NewPredictableQueue(m *q.Message, err error) q.Queue {
 	return &predictableQueue{err: err, msg: m}
 }
@@ -38,10 +41,34 @@ func (p *predictableQueue) Tags() *q.Tags {
 	return t
 }
 
-func (p *predictableQueue) Add(m *q.Message) error {
+func (p *predictableQueue) Config() q.Config {
+	return q.Config{Limit: q.Unbounded}
+}
+
+func (p *predictableQueue) Configure(c q.Config) error {
 	return p.err
 }
 
+func (p *predictableQueue) OnEvict(fn func(*q.Message)) {
+	p.evict = append(p.evict, fn)
+}
+
+func (p *predictableQueue) Add(m *q.Message) error {
+	return p.AddContext(context.Background(), m)
+}
+
+func (p *predictableQueue) AddContext(ctx context.Context, m *q.Message) error {
+	if p.err != nil {
+		return p.err
+	}
+	if p.msg != nil {
+		for _, fn := range p.evict {
+			fn(p.msg)
+		}
+	}
+	return nil
+}
+
 func (p *predictableQueue) Pop() (*q.Message, error) {
 	return p.msg, p.err
 }
@@ -49,3 +76,39 @@ func (p *predictableQueue) Pop() (*q.Message, error) {
 func (p *predictableQueue) Peek() (*q.Message, error) {
 	return p.msg, p.err
 }
+
+func (p *predictableQueue) PopMatching(t ...q.Tag) (*q.Message, error) {
+	return p.msg, p.err
+}
+
+func (p *predictableQueue) PeekMatching(t ...q.Tag) (*q.Message, error) {
+	return p.msg, p.err
+}
+
+func (p *predictableQueue) Get(id uuid.UUID) (*q.Message, error) {
+	return p.msg, p.err
+}
+
+func (p *predictableQueue) Delete(id uuid.UUID) error {
+	return p.err
+}
+
+func (p *predictableQueue) Purge() (int, error) {
+	if p.err != nil {
+		return 0, p.err
+	}
+	if p.msg != nil {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func (p *predictableQueue) Walk(offset uint64, fn q.WalkFunc) error {
+	if p.err != nil {
+		return p.err
+	}
+	if p.msg != nil && offset <= 1 {
+		fn(1, p.msg)
+	}
+	return nil
+}
@@ -1,22 +1,36 @@
-// +build integration
-
 package integration
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"net"
+	"os"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
 
+	"github.com/negz/q"
+	"github.com/negz/q/certs"
+	"github.com/negz/q/client"
+	"github.com/negz/q/e"
+	"github.com/negz/q/embedded"
 	"github.com/negz/q/manager"
 	"github.com/negz/q/metrics"
 	"github.com/negz/q/proto"
 	"github.com/negz/q/rpc"
+	"github.com/negz/q/test/fixtures"
 )
 
 const Unbounded int64 = -1
@@ -67,18 +81,12 @@ var integrationTests = []struct {
 }
 
 func TestIntegration(t *testing.T) {
-	// This is a little racey. Something could consume the port immediately
-	// between us closing it and attempting to reuse it.
-	listen, err := localhostWithRandomPort()
-	if err != nil {
-		t.Fatal("Cannot find available port to listen on.")
-	}
-	conn, err := newServer(listen)
+	qc, shutdown, err := embedded.Start()
 	if err != nil {
-		t.Fatal("Cannot create new server: %v", err)
+		t.Fatalf("embedded.Start(): %v", err)
 	}
-	defer conn.Close()
-	c := &itClient{proto.NewQClient(conn)}
+	defer shutdown()
+	c := &itClient{qc}
 
 	for _, tt := range integrationTests {
 		id, err := c.newQueue(tt.limit, tt.store, tt.tags...)
@@ -111,7 +119,7 @@ func TestIntegration(t *testing.T) {
 				t.Errorf("c.peekMessage(%v): %v", id, err)
 			}
 			if !reflect.DeepEqual(payload, tt.messages[0].payload) {
-				t.Errorf("c.popMessage(%v): want %s, got %s", tt.messages[0].payload, payload)
+				t.Errorf("c.peekMessage(%v): want %s, got %s", id, tt.messages[0].payload, payload)
 			}
 		})
 
@@ -131,7 +139,7 @@ func TestIntegration(t *testing.T) {
 					continue
 				}
 				if !reflect.DeepEqual(payload, m.payload) {
-					t.Errorf("c.popMessage(%v): want %s, got %s", m.payload, payload)
+					t.Errorf("c.popMessage(%v): want %s, got %s", id, m.payload, payload)
 				}
 			}
 		})
@@ -152,6 +160,782 @@ func TestIntegration(t *testing.T) {
 	}
 }
 
+func TestListQueuesPaginated(t *testing.T) {
+	qc, shutdown, err := embedded.Start()
+	if err != nil {
+		t.Fatalf("embedded.Start(): %v", err)
+	}
+	defer shutdown()
+	c := &itClient{qc}
+
+	want := make(map[string]bool)
+	for i := 0; i < 5; i++ {
+		id, err := c.newQueue(Unbounded, proto.MEMORY)
+		if err != nil {
+			t.Fatalf("c.newQueue(%v, %v): %v", Unbounded, proto.MEMORY, err)
+		}
+		want[id] = true
+	}
+
+	got := make(map[string]bool)
+	req := &proto.ListQueuesRequest{PageSize: 2}
+	for pages := 1; ; pages++ {
+		rsp, err := c.c.ListQueues(ctx, req)
+		if err != nil {
+			t.Fatalf("c.c.ListQueues(%v): %v", req, err)
+		}
+		if len(rsp.GetQueues()) > int(req.PageSize) {
+			t.Errorf("c.c.ListQueues(%v): want at most %d queues, got %d", req, req.PageSize, len(rsp.GetQueues()))
+		}
+		for _, queue := range rsp.GetQueues() {
+			id := queue.GetMeta().GetId()
+			if got[id] {
+				t.Errorf("c.c.ListQueues(%v): queue %v returned more than once", req, id)
+			}
+			got[id] = true
+		}
+		if rsp.GetNextPageToken() == "" {
+			if pages != 3 {
+				t.Errorf("c.c.ListQueues(): want 3 pages, got %d", pages)
+			}
+			break
+		}
+		req.PageToken = rsp.GetNextPageToken()
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("c.c.ListQueues():\nwant %v\ngot %v", want, got)
+	}
+}
+
+func TestListMessages(t *testing.T) {
+	qc, shutdown, err := embedded.Start()
+	if err != nil {
+		t.Fatalf("embedded.Start(): %v", err)
+	}
+	defer shutdown()
+	c := &itClient{qc}
+
+	id, err := c.newQueue(Unbounded, proto.MEMORY)
+	if err != nil {
+		t.Fatalf("c.newQueue(%v, %v): %v", Unbounded, proto.MEMORY, err)
+	}
+	small := &proto.Tag{"size", "3U"}
+	large := &proto.Tag{"size", "6U"}
+	for _, m := range []*message{
+		&message{payload: []byte("dove 001"), tags: []*proto.Tag{small}},
+		&message{payload: []byte("dove 002"), tags: []*proto.Tag{large}},
+		&message{payload: []byte("dove 003"), tags: []*proto.Tag{small}},
+		&message{payload: []byte("dove 004"), tags: []*proto.Tag{small}},
+	} {
+		if err := c.newMessage(id, m.payload, m.tags...); err != nil {
+			t.Fatalf("c.newMessage(%v, %v, %v): %v", id, m.payload, m.tags, err)
+		}
+	}
+
+	want := []string{"dove 001", "dove 003", "dove 004"}
+	got := []string{}
+	req := &proto.ListMessagesRequest{QueueId: id, PageSize: 2, Filter: []*proto.Tag{small}}
+	for pages := 1; ; pages++ {
+		rsp, err := c.c.ListMessages(ctx, req)
+		if err != nil {
+			t.Fatalf("c.c.ListMessages(%v): %v", req, err)
+		}
+		for _, m := range rsp.GetMessages() {
+			got = append(got, string(m.GetPayload()))
+		}
+		if rsp.GetNextPageToken() == "" {
+			if pages != 2 {
+				t.Errorf("c.c.ListMessages(): want 2 pages, got %d", pages)
+			}
+			break
+		}
+		req.PageToken = rsp.GetNextPageToken()
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("c.c.ListMessages():\nwant %v\ngot %v", want, got)
+	}
+
+	// Listing messages must not consume them.
+	if p, err := c.peekMessage(id); err != nil || string(p) != "dove 001" {
+		t.Errorf("c.peekMessage(%v): want dove 001, got %s, %v", id, p, err)
+	}
+
+	req = &proto.ListMessagesRequest{QueueId: id, PageSize: 1, PreviewLength: 4}
+	rsp, err := c.c.ListMessages(ctx, req)
+	if err != nil {
+		t.Fatalf("c.c.ListMessages(%v): %v", req, err)
+	}
+	if len(rsp.GetMessages()) != 1 || string(rsp.GetMessages()[0].GetPayload()) != "dove" {
+		t.Errorf("c.c.ListMessages(%v): want one message with payload dove, got %v", req, rsp.GetMessages())
+	}
+}
+
+func TestGetDeleteMessage(t *testing.T) {
+	qc, shutdown, err := embedded.Start()
+	if err != nil {
+		t.Fatalf("embedded.Start(): %v", err)
+	}
+	defer shutdown()
+	c := &itClient{qc}
+
+	id, err := c.newQueue(Unbounded, proto.MEMORY)
+	if err != nil {
+		t.Fatalf("c.newQueue(%v, %v): %v", Unbounded, proto.MEMORY, err)
+	}
+	ids := make([]string, 0, 2)
+	for _, p := range []string{"dove 001", "dove 002"} {
+		rsp, err := c.c.Add(ctx, &proto.AddRequest{QueueId: id, Message: &proto.NewMessage{Payload: []byte(p)}})
+		if err != nil {
+			t.Fatalf("c.c.Add(%v): %v", p, err)
+		}
+		ids = append(ids, rsp.GetMessage().GetMeta().GetId())
+	}
+
+	get := &proto.GetMessageRequest{QueueId: id, MessageId: ids[1]}
+	rsp, err := c.c.GetMessage(ctx, get)
+	if err != nil {
+		t.Fatalf("c.c.GetMessage(%v): %v", get, err)
+	}
+	if string(rsp.GetMessage().GetPayload()) != "dove 002" {
+		t.Errorf("c.c.GetMessage(%v): want dove 002, got %s", get, rsp.GetMessage().GetPayload())
+	}
+
+	del := &proto.DeleteMessageRequest{QueueId: id, MessageId: ids[0]}
+	if _, err := c.c.DeleteMessage(ctx, del); err != nil {
+		t.Fatalf("c.c.DeleteMessage(%v): %v", del, err)
+	}
+	_, err = c.c.DeleteMessage(ctx, del)
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.NotFound {
+		t.Errorf("c.c.DeleteMessage(%v): want %v, got %v", del, codes.NotFound, err)
+	}
+	if p, err := c.popMessage(id); err != nil || string(p) != "dove 002" {
+		t.Errorf("c.popMessage(%v): want dove 002, got %s, %v", id, p, err)
+	}
+}
+
+func TestPurgeQueue(t *testing.T) {
+	qc, shutdown, err := embedded.Start()
+	if err != nil {
+		t.Fatalf("embedded.Start(): %v", err)
+	}
+	defer shutdown()
+	c := &itClient{qc}
+
+	id, err := c.newQueue(Unbounded, proto.MEMORY)
+	if err != nil {
+		t.Fatalf("c.newQueue(%v, %v): %v", Unbounded, proto.MEMORY, err)
+	}
+	for _, p := range []string{"dove 001", "dove 002", "dove 003"} {
+		if err := c.newMessage(id, []byte(p)); err != nil {
+			t.Fatalf("c.newMessage(%v, %v): %v", id, p, err)
+		}
+	}
+
+	req := &proto.PurgeQueueRequest{QueueId: id}
+	rsp, err := c.c.PurgeQueue(ctx, req)
+	if err != nil {
+		t.Fatalf("c.c.PurgeQueue(%v): %v", req, err)
+	}
+	if rsp.GetPurged() != 3 {
+		t.Errorf("c.c.PurgeQueue(%v): want 3 purged, got %v", req, rsp.GetPurged())
+	}
+	_, err = c.popMessage(id)
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.NotFound {
+		t.Errorf("c.popMessage(%v): want %v, got %v", id, codes.NotFound, err)
+	}
+
+	// The queue keeps its ID, so producers can keep using it.
+	if err := c.newMessage(id, []byte("dove 004")); err != nil {
+		t.Errorf("c.newMessage(%v, %v): %v", id, "dove 004", err)
+	}
+}
+
+func TestDeleteQueue(t *testing.T) {
+	qc, shutdown, err := embedded.Start()
+	if err != nil {
+		t.Fatalf("embedded.Start(): %v", err)
+	}
+	defer shutdown()
+	c := &itClient{qc}
+
+	err = c.deleteQueue(uuid.New().String())
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.NotFound {
+		t.Errorf("c.deleteQueue(): want %v, got %v", codes.NotFound, err)
+	}
+
+	id, err := c.newQueue(Unbounded, proto.MEMORY)
+	if err != nil {
+		t.Fatalf("c.newQueue(%v, %v): %v", Unbounded, proto.MEMORY, err)
+	}
+	if err := c.newMessage(id, []byte("gemini 3")); err != nil {
+		t.Fatalf("c.newMessage(%v, %v): %v", id, "gemini 3", err)
+	}
+
+	err = c.deleteQueue(id)
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.FailedPrecondition {
+		t.Errorf("c.deleteQueue(%v): want %v, got %v", id, codes.FailedPrecondition, err)
+	}
+	req := &proto.DeleteQueueRequest{QueueId: id, Force: true}
+	if _, err := c.c.DeleteQueue(ctx, req); err != nil {
+		t.Errorf("c.c.DeleteQueue(%v): %v", req, err)
+	}
+	_, err = c.peekMessage(id)
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.NotFound {
+		t.Errorf("c.peekMessage(%v): want %v, got %v", id, codes.NotFound, err)
+	}
+}
+
+func TestErrorReasons(t *testing.T) {
+	qc, shutdown, err := embedded.Start()
+	if err != nil {
+		t.Fatalf("embedded.Start(): %v", err)
+	}
+	defer shutdown()
+	c := &itClient{qc}
+
+	id, err := c.newQueue(1, proto.MEMORY)
+	if err != nil {
+		t.Fatalf("c.newQueue(%v, %v): %v", 1, proto.MEMORY, err)
+	}
+	_, err = c.popMessage(id)
+	if err := e.FromGRPC(err); !e.IsNotFound(err) || e.ReasonOf(err) != e.QUEUE_EMPTY {
+		t.Errorf("c.popMessage(%v): want not found error with reason %v, got %v", id, e.QUEUE_EMPTY, err)
+	}
+	missing := uuid.New().String()
+	_, err = c.popMessage(missing)
+	if err := e.FromGRPC(err); !e.IsNotFound(err) || e.ReasonOf(err) != e.QUEUE_NOT_FOUND {
+		t.Errorf("c.popMessage(%v): want not found error with reason %v, got %v", missing, e.QUEUE_NOT_FOUND, err)
+	}
+	if err := c.newMessage(id, []byte("voyager 1")); err != nil {
+		t.Fatalf("c.newMessage(%v, %v): %v", id, "voyager 1", err)
+	}
+	err = c.newMessage(id, []byte("voyager 2"))
+	if err := e.FromGRPC(err); !e.IsFull(err) || e.ReasonOf(err) != e.QUEUE_FULL {
+		t.Errorf("c.newMessage(%v): want full error with reason %v, got %v", id, e.QUEUE_FULL, err)
+	}
+}
+
+func TestConsumer(t *testing.T) {
+	qc, shutdown, err := embedded.Start()
+	if err != nil {
+		t.Fatalf("embedded.Start(): %v", err)
+	}
+	defer shutdown()
+	c := client.New(qc)
+
+	id, err := c.NewQueue(ctx, q.Memory, q.Config{Limit: q.Unbounded})
+	if err != nil {
+		t.Fatalf("c.NewQueue(): %v", err)
+	}
+	want := map[string]bool{}
+	for i := 0; i < 20; i++ {
+		p := fmt.Sprintf("starlink %d", i)
+		if _, err := c.Add(ctx, id, q.NewMessage([]byte(p))); err != nil {
+			t.Fatalf("c.Add(%v, %v): %v", id, p, err)
+		}
+		want[p] = true
+	}
+
+	// Each message fails once, and so is nacked, before it is handled.
+	mx := &sync.Mutex{}
+	failed := map[string]bool{}
+	got := map[string]bool{}
+	cctx, cancel := context.WithCancel(ctx)
+	h := func(_ context.Context, m *q.Message) error {
+		mx.Lock()
+		defer mx.Unlock()
+		p := string(m.Payload)
+		if !failed[p] {
+			failed[p] = true
+			return errors.New("kaboom!")
+		}
+		got[p] = true
+		if len(got) == len(want) {
+			cancel()
+		}
+		return nil
+	}
+	cs := c.NewConsumer(id, client.WithWorkers(4), client.WithWait(10*time.Millisecond))
+	if err := cs.Run(cctx, h); err != nil {
+		t.Errorf("cs.Run(): %v", err)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("cs.Run(): want %v handled, got %v", want, got)
+	}
+	if _, err := c.Pop(ctx, id); e.ReasonOf(err) != e.QUEUE_EMPTY {
+		t.Errorf("c.Pop(%v): want error with reason %v, got %v", id, e.QUEUE_EMPTY, err)
+	}
+
+	// A consumer of a queue that does not exist stops with an error.
+	missing := uuid.New()
+	if err := c.NewConsumer(missing).Run(ctx, h); !e.IsNotFound(err) {
+		t.Errorf("c.NewConsumer(%v).Run(): want error satisfying e.IsNotFound(), got %v", missing, err)
+	}
+}
+
+func TestPopWait(t *testing.T) {
+	qc, shutdown, err := embedded.Start()
+	if err != nil {
+		t.Fatalf("embedded.Start(): %v", err)
+	}
+	defer shutdown()
+	c := client.New(qc)
+
+	id, err := c.NewQueue(ctx, q.Memory, q.Config{Limit: q.Unbounded})
+	if err != nil {
+		t.Fatalf("c.NewQueue(): %v", err)
+	}
+	if _, err := c.PopWait(ctx, id, 10*time.Millisecond); e.ReasonOf(err) != e.QUEUE_EMPTY {
+		t.Errorf("c.PopWait(%v): want error with reason %v, got %v", id, e.QUEUE_EMPTY, err)
+	}
+
+	popped := make(chan *q.Message)
+	go func() {
+		m, err := c.PopWait(ctx, id, time.Minute)
+		if err != nil {
+			t.Errorf("c.PopWait(%v): %v", id, err)
+		}
+		popped <- m
+	}()
+	time.Sleep(50 * time.Millisecond)
+	want := q.NewMessage([]byte("tiangong"))
+	if _, err := c.Add(ctx, id, want); err != nil {
+		t.Fatalf("c.Add(%v): %v", id, err)
+	}
+	if got := <-popped; got == nil || !reflect.DeepEqual(want.Payload, got.Payload) {
+		t.Errorf("c.PopWait(%v): want %v, got %v", id, want, got)
+	}
+}
+
+func TestClientSubscribe(t *testing.T) {
+	qc, shutdown, err := embedded.Start()
+	if err != nil {
+		t.Fatalf("embedded.Start(): %v", err)
+	}
+	defer shutdown()
+	c := client.New(qc)
+
+	nt := &proto.NewTopicRequest{}
+	nrsp, err := qc.NewTopic(ctx, nt)
+	if err != nil {
+		t.Fatalf("NewTopic(%v): %v", nt, err)
+	}
+	topic, err := proto.ParseID(nrsp.GetTopic().GetMeta().GetId())
+	if err != nil {
+		t.Fatalf("proto.ParseID(): %v", err)
+	}
+
+	id, err := c.Subscribe(ctx, topic, q.Tag{Key: "program", Value: "mercury"})
+	if err != nil {
+		t.Fatalf("c.Subscribe(%v): %v", topic, err)
+	}
+	for _, p := range []string{"freedom 7", "liberty bell 7"} {
+		m := q.NewMessage([]byte(p), q.Tagged(q.Tag{Key: "program", Value: "mercury"}))
+		if n, err := c.Publish(ctx, topic, m); err != nil || n != 1 {
+			t.Errorf("c.Publish(%v, %v): want 1 delivery, got %v: %v", topic, p, n, err)
+		}
+	}
+	m, err := c.Pop(ctx, id)
+	if err != nil {
+		t.Fatalf("c.Pop(%v): %v", id, err)
+	}
+	if string(m.Payload) != "freedom 7" {
+		t.Errorf("c.Pop(%v): want %s, got %s", id, "freedom 7", m.Payload)
+	}
+}
+
+func TestAddBatch(t *testing.T) {
+	qc, shutdown, err := embedded.Start()
+	if err != nil {
+		t.Fatalf("embedded.Start(): %v", err)
+	}
+	defer shutdown()
+	c := &itClient{qc}
+
+	id, err := c.newQueue(2, proto.MEMORY)
+	if err != nil {
+		t.Fatalf("c.newQueue(%v, %v): %v", 2, proto.MEMORY, err)
+	}
+	req := &proto.AddBatchRequest{QueueId: id, Messages: []*proto.NewMessage{
+		{Payload: []byte("tiangong 1")},
+		{Payload: []byte("tiangong 2")},
+		{Payload: []byte("tiangong 3")},
+	}}
+	rsp, err := c.c.AddBatch(ctx, req)
+	if err != nil {
+		t.Fatalf("c.c.AddBatch(%v): %v", req, err)
+	}
+	if len(rsp.GetMessages()) != 2 {
+		t.Errorf("c.c.AddBatch(%v): want 2 messages added, got %v", req, len(rsp.GetMessages()))
+	}
+
+	// Resending the messages that were not added explains why.
+	req.Messages = req.Messages[len(rsp.GetMessages()):]
+	_, err = c.c.AddBatch(ctx, req)
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.ResourceExhausted {
+		t.Errorf("c.c.AddBatch(%v): want %v, got %v", req, codes.ResourceExhausted, err)
+	}
+}
+
+func TestUpdateQueue(t *testing.T) {
+	qc, shutdown, err := embedded.Start()
+	if err != nil {
+		t.Fatalf("embedded.Start(): %v", err)
+	}
+	defer shutdown()
+	c := &itClient{qc}
+
+	id, err := c.newQueue(Unbounded, proto.MEMORY)
+	if err != nil {
+		t.Fatalf("c.newQueue(%v, %v): %v", Unbounded, proto.MEMORY, err)
+	}
+	for _, p := range []string{"dove 001", "dove 002"} {
+		if err := c.newMessage(id, []byte(p)); err != nil {
+			t.Fatalf("c.newMessage(%v, %v): %v", id, p, err)
+		}
+	}
+
+	req := &proto.UpdateQueueRequest{
+		QueueId:    id,
+		Config:     &proto.QueueConfig{Limit: 1},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"limit"}},
+	}
+	rsp, err := c.c.UpdateQueue(ctx, req)
+	if err != nil {
+		t.Fatalf("c.c.UpdateQueue(%v): %v", req, err)
+	}
+	if rsp.GetQueue().GetConfig().GetLimit() != 1 {
+		t.Errorf("c.c.UpdateQueue(%v): want limit 1, got %v", req, rsp.GetQueue().GetConfig().GetLimit())
+	}
+
+	err = c.newMessage(id, []byte("dove 003"))
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.ResourceExhausted {
+		t.Errorf("c.newMessage(%v): want %v, got %v", id, codes.ResourceExhausted, err)
+	}
+	if p, err := c.peekMessage(id); err != nil || string(p) != "dove 001" {
+		t.Errorf("c.peekMessage(%v): want dove 001, got %s, %v", id, p, err)
+	}
+
+	req.UpdateMask.Paths = []string{"ttl"}
+	_, err = c.c.UpdateQueue(ctx, req)
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.InvalidArgument {
+		t.Errorf("c.c.UpdateQueue(%v): want %v, got %v", req, codes.InvalidArgument, err)
+	}
+}
+
+func TestMaxBytes(t *testing.T) {
+	qc, shutdown, err := embedded.Start()
+	if err != nil {
+		t.Fatalf("embedded.Start(): %v", err)
+	}
+	defer shutdown()
+	c := &itClient{qc}
+
+	req := &proto.NewQueueRequest{Store: proto.MEMORY, Limit: Unbounded, MaxBytes: 16, MaxMessageBytes: 8}
+	rsp, err := c.c.NewQueue(ctx, req)
+	if err != nil {
+		t.Fatalf("c.c.NewQueue(%v): %v", req, err)
+	}
+	id := rsp.GetQueue().GetMeta().GetId()
+
+	err = c.newMessage(id, []byte("dove 0001"))
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.InvalidArgument {
+		t.Errorf("c.newMessage(%v): want %v, got %v", id, codes.InvalidArgument, err)
+	}
+	for _, p := range []string{"dove 001", "dove 002"} {
+		if err := c.newMessage(id, []byte(p)); err != nil {
+			t.Fatalf("c.newMessage(%v, %v): %v", id, p, err)
+		}
+	}
+	err = c.newMessage(id, []byte("dove 003"))
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.ResourceExhausted {
+		t.Errorf("c.newMessage(%v): want %v, got %v", id, codes.ResourceExhausted, err)
+	}
+
+	// Queues that do not specify a maximum message size use the server's.
+	req = &proto.NewQueueRequest{Store: proto.MEMORY, Limit: Unbounded}
+	rsp, err = c.c.NewQueue(ctx, req)
+	if err != nil {
+		t.Fatalf("c.c.NewQueue(%v): %v", req, err)
+	}
+	if got := rsp.GetQueue().GetConfig().GetMaxMessageBytes(); got != rpc.DefaultMaxMessageBytes {
+		t.Errorf("c.c.NewQueue(%v): want max message bytes %v, got %v", req, rpc.DefaultMaxMessageBytes, got)
+	}
+}
+
+func TestOverflow(t *testing.T) {
+	qc, shutdown, err := embedded.Start()
+	if err != nil {
+		t.Fatalf("embedded.Start(): %v", err)
+	}
+	defer shutdown()
+	c := &itClient{qc}
+
+	req := &proto.NewQueueRequest{Store: proto.MEMORY, Limit: 1, Overflow: proto.DROP_OLDEST}
+	rsp, err := c.c.NewQueue(ctx, req)
+	if err != nil {
+		t.Fatalf("c.c.NewQueue(%v): %v", req, err)
+	}
+	id := rsp.GetQueue().GetMeta().GetId()
+	if got := rsp.GetQueue().GetConfig().GetOverflow(); got != proto.DROP_OLDEST {
+		t.Errorf("c.c.NewQueue(%v): want overflow %v, got %v", req, proto.DROP_OLDEST, got)
+	}
+	for _, p := range []string{"sputnik 1", "sputnik 2"} {
+		if err := c.newMessage(id, []byte(p)); err != nil {
+			t.Fatalf("c.newMessage(%v, %v): %v", id, p, err)
+		}
+	}
+	p, err := c.popMessage(id)
+	if err != nil {
+		t.Fatalf("c.popMessage(%v): %v", id, err)
+	}
+	if string(p) != "sputnik 2" {
+		t.Errorf("c.popMessage(%v): want %s, got %s", id, "sputnik 2", p)
+	}
+
+	req = &proto.NewQueueRequest{Store: proto.MEMORY, Limit: 1, Overflow: proto.BLOCK}
+	rsp, err = c.c.NewQueue(ctx, req)
+	if err != nil {
+		t.Fatalf("c.c.NewQueue(%v): %v", req, err)
+	}
+	id = rsp.GetQueue().GetMeta().GetId()
+	if err := c.newMessage(id, []byte("sputnik 3")); err != nil {
+		t.Fatalf("c.newMessage(%v): %v", id, err)
+	}
+
+	// Producers should block until their deadline passes.
+	add := &proto.AddRequest{QueueId: id, Message: &proto.NewMessage{Payload: []byte("sputnik 4")}}
+	tctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = c.c.Add(tctx, add)
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.DeadlineExceeded {
+		t.Errorf("c.c.Add(%v): want %v, got %v", add, codes.DeadlineExceeded, err)
+	}
+}
+
+func TestMoveCopyMessages(t *testing.T) {
+	qc, shutdown, err := embedded.Start()
+	if err != nil {
+		t.Fatalf("embedded.Start(): %v", err)
+	}
+	defer shutdown()
+	c := &itClient{qc}
+
+	ids := make([]string, 0, 3)
+	for i := 0; i < 3; i++ {
+		id, err := c.newQueue(Unbounded, proto.MEMORY)
+		if err != nil {
+			t.Fatalf("c.newQueue(%v, %v): %v", Unbounded, proto.MEMORY, err)
+		}
+		ids = append(ids, id)
+	}
+	src, dst, staging := ids[0], ids[1], ids[2]
+	tag := &proto.Tag{Key: "flight", Value: "apollo"}
+	for _, p := range []string{"apollo 7", "apollo 8", "apollo 9"} {
+		if err := c.newMessage(src, []byte(p), tag); err != nil {
+			t.Fatalf("c.newMessage(%v, %v): %v", src, p, err)
+		}
+	}
+
+	cp := &proto.CopyMessagesRequest{SourceQueueId: src, DestinationQueueId: staging}
+	crsp, err := c.c.CopyMessages(ctx, cp)
+	if err != nil {
+		t.Fatalf("c.c.CopyMessages(%v): %v", cp, err)
+	}
+	if crsp.GetCopied() != 3 {
+		t.Errorf("c.c.CopyMessages(%v): want 3 copied, got %v", cp, crsp.GetCopied())
+	}
+
+	mv := &proto.MoveMessagesRequest{SourceQueueId: src, DestinationQueueId: dst, MaxCount: 2, Filter: []*proto.Tag{tag}}
+	mrsp, err := c.c.MoveMessages(ctx, mv)
+	if err != nil {
+		t.Fatalf("c.c.MoveMessages(%v): %v", mv, err)
+	}
+	if mrsp.GetMoved() != 2 {
+		t.Errorf("c.c.MoveMessages(%v): want 2 moved, got %v", mv, mrsp.GetMoved())
+	}
+	want := map[string]string{src: "apollo 9", dst: "apollo 7", staging: "apollo 7"}
+	for id, p := range want {
+		got, err := c.popMessage(id)
+		if err != nil {
+			t.Fatalf("c.popMessage(%v): %v", id, err)
+		}
+		if string(got) != p {
+			t.Errorf("c.popMessage(%v): want %s, got %s", id, p, got)
+		}
+	}
+
+	mv = &proto.MoveMessagesRequest{SourceQueueId: src, DestinationQueueId: src}
+	_, err = c.c.MoveMessages(ctx, mv)
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.InvalidArgument {
+		t.Errorf("c.c.MoveMessages(%v): want %v, got %v", mv, codes.InvalidArgument, err)
+	}
+}
+
+func TestTopics(t *testing.T) {
+	qc, shutdown, err := embedded.Start()
+	if err != nil {
+		t.Fatalf("embedded.Start(): %v", err)
+	}
+	defer shutdown()
+	c := &itClient{qc}
+
+	all, err := c.newQueue(Unbounded, proto.MEMORY)
+	if err != nil {
+		t.Fatalf("c.newQueue(%v, %v): %v", Unbounded, proto.MEMORY, err)
+	}
+	crewed, err := c.newQueue(Unbounded, proto.MEMORY)
+	if err != nil {
+		t.Fatalf("c.newQueue(%v, %v): %v", Unbounded, proto.MEMORY, err)
+	}
+
+	nt := &proto.NewTopicRequest{Tags: []*proto.Tag{{Key: "program", Value: "gemini"}}}
+	nrsp, err := c.c.NewTopic(ctx, nt)
+	if err != nil {
+		t.Fatalf("c.c.NewTopic(%v): %v", nt, err)
+	}
+	id := nrsp.GetTopic().GetMeta().GetId()
+
+	crew := &proto.Tag{Key: "crewed", Value: "true"}
+	for _, sub := range []*proto.Subscription{{QueueId: all}, {QueueId: crewed, Filter: []*proto.Tag{crew}}} {
+		req := &proto.SubscribeRequest{TopicId: id, Subscription: sub}
+		if _, err := c.c.Subscribe(ctx, req); err != nil {
+			t.Fatalf("c.c.Subscribe(%v): %v", req, err)
+		}
+	}
+	missing := &proto.SubscribeRequest{TopicId: id, Subscription: &proto.Subscription{QueueId: uuid.New().String()}}
+	_, err = c.c.Subscribe(ctx, missing)
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.NotFound {
+		t.Errorf("c.c.Subscribe(%v): want %v, got %v", missing, codes.NotFound, err)
+	}
+
+	grsp, err := c.c.GetTopic(ctx, &proto.GetTopicRequest{TopicId: id})
+	if err != nil {
+		t.Fatalf("c.c.GetTopic(%v): %v", id, err)
+	}
+	if got := len(grsp.GetTopic().GetSubscriptions()); got != 2 {
+		t.Errorf("c.c.GetTopic(%v): want 2 subscriptions, got %v", id, got)
+	}
+
+	pubs := []*proto.PublishRequest{
+		{TopicId: id, Message: &proto.NewMessage{Payload: []byte("gemini 2")}},
+		{TopicId: id, Message: &proto.NewMessage{Payload: []byte("gemini 3"), Tags: []*proto.Tag{crew}}},
+	}
+	for i, req := range pubs {
+		rsp, err := c.c.Publish(ctx, req)
+		if err != nil {
+			t.Fatalf("c.c.Publish(%v): %v", req, err)
+		}
+		if want := int64(i + 1); rsp.GetDelivered() != want {
+			t.Errorf("c.c.Publish(%v): want %v delivered, got %v", req, want, rsp.GetDelivered())
+		}
+	}
+	want := map[string]string{all: "gemini 2", crewed: "gemini 3"}
+	for q, p := range want {
+		got, err := c.popMessage(q)
+		if err != nil {
+			t.Fatalf("c.popMessage(%v): %v", q, err)
+		}
+		if string(got) != p {
+			t.Errorf("c.popMessage(%v): want %s, got %s", q, p, got)
+		}
+	}
+
+	un := &proto.UnsubscribeRequest{TopicId: id, QueueId: crewed}
+	if _, err := c.c.Unsubscribe(ctx, un); err != nil {
+		t.Fatalf("c.c.Unsubscribe(%v): %v", un, err)
+	}
+	_, err = c.c.Unsubscribe(ctx, un)
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.NotFound {
+		t.Errorf("c.c.Unsubscribe(%v): want %v, got %v", un, codes.NotFound, err)
+	}
+
+	lrsp, err := c.c.ListTopics(ctx, &proto.ListTopicsRequest{})
+	if err != nil {
+		t.Fatalf("c.c.ListTopics(): %v", err)
+	}
+	if got := len(lrsp.GetTopics()); got != 1 {
+		t.Errorf("c.c.ListTopics(): want 1 topic, got %v", got)
+	}
+
+	if _, err := c.c.DeleteTopic(ctx, &proto.DeleteTopicRequest{TopicId: id}); err != nil {
+		t.Fatalf("c.c.DeleteTopic(%v): %v", id, err)
+	}
+	_, err = c.c.GetTopic(ctx, &proto.GetTopicRequest{TopicId: id})
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.NotFound {
+		t.Errorf("c.c.GetTopic(%v): want %v, got %v", id, codes.NotFound, err)
+	}
+}
+
+func TestConsumerGroups(t *testing.T) {
+	qc, shutdown, err := embedded.Start()
+	if err != nil {
+		t.Fatalf("embedded.Start(): %v", err)
+	}
+	defer shutdown()
+	c := &itClient{qc}
+
+	id, err := c.newQueue(Unbounded, proto.MEMORY_LOG)
+	if err != nil {
+		t.Fatalf("c.newQueue(%v, %v): %v", Unbounded, proto.MEMORY_LOG, err)
+	}
+	for _, g := range []string{"houston", "baikonur"} {
+		req := &proto.NewGroupRequest{QueueId: id, Group: g}
+		if _, err := c.c.NewGroup(ctx, req); err != nil {
+			t.Fatalf("c.c.NewGroup(%v): %v", req, err)
+		}
+	}
+	for _, p := range []string{"soyuz 19", "apollo 18"} {
+		if err := c.newMessage(id, []byte(p)); err != nil {
+			t.Fatalf("c.newMessage(%v, %v): %v", id, p, err)
+		}
+	}
+
+	for _, g := range []string{"houston", "baikonur"} {
+		req := &proto.ReadRequest{QueueId: id, Group: g, MaxCount: 1}
+		rsp, err := c.c.Read(ctx, req)
+		if err != nil {
+			t.Fatalf("c.c.Read(%v): %v", req, err)
+		}
+		if len(rsp.GetMessages()) != 1 || string(rsp.GetMessages()[0].GetPayload()) != "soyuz 19" {
+			t.Errorf("c.c.Read(%v): want soyuz 19, got %v", req, rsp.GetMessages())
+		}
+		cm := &proto.CommitRequest{QueueId: id, Group: g, Offset: rsp.GetNextOffset()}
+		crsp, err := c.c.Commit(ctx, cm)
+		if err != nil {
+			t.Fatalf("c.c.Commit(%v): %v", cm, err)
+		}
+		if crsp.GetGroup().GetLag() != 1 {
+			t.Errorf("c.c.Commit(%v): want lag 1, got %v", cm, crsp.GetGroup().GetLag())
+		}
+	}
+
+	// Both groups have consumed the first message, so the log discards it.
+	got, err := c.peekMessage(id)
+	if err != nil {
+		t.Fatalf("c.peekMessage(%v): %v", id, err)
+	}
+	if string(got) != "apollo 18" {
+		t.Errorf("c.peekMessage(%v): want apollo 18, got %s", id, got)
+	}
+
+	_, err = c.popMessage(id)
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.InvalidArgument {
+		t.Errorf("c.popMessage(%v): want %v, got %v", id, codes.InvalidArgument, err)
+	}
+
+	fifo, err := c.newQueue(Unbounded, proto.MEMORY)
+	if err != nil {
+		t.Fatalf("c.newQueue(%v, %v): %v", Unbounded, proto.MEMORY, err)
+	}
+	req := &proto.NewGroupRequest{QueueId: fifo, Group: "houston"}
+	_, err = c.c.NewGroup(ctx, req)
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.InvalidArgument {
+		t.Errorf("c.c.NewGroup(%v): want %v, got %v", req, codes.InvalidArgument, err)
+	}
+}
+
 func localhostWithRandomPort() (string, error) {
 	l, err := net.Listen("tcp", "localhost:0")
 	if err != nil {
@@ -161,22 +945,478 @@ func localhostWithRandomPort() (string, error) {
 	return l.Addr().String(), nil
 }
 
-func newServer(listen string) (*grpc.ClientConn, error) {
+// newServer serves on the supplied address until the supplied channel is
+// closed, returning a connection to the server.
+func newServer(done <-chan struct{}, listen string, o ...rpc.Option) (*grpc.ClientConn, error) {
 	mx, _ := metrics.NewPrometheus()
-	m := manager.Instrumented(
-		manager.New(),
-		manager.WithMetrics(mx),
-		manager.WithLogger(zap.NewNop()),
+	ectx, cancel := context.WithCancel(context.Background())
+	m := manager.Expiring(ectx,
+		manager.Instrumented(
+			manager.New(),
+			manager.WithMetrics(mx),
+			manager.WithLogger(zap.NewNop()),
+		),
+		manager.WithExpiryInterval(50*time.Millisecond),
 	)
 	l, err := net.Listen("tcp", listen)
 	if err != nil {
+		cancel()
 		return nil, err
 	}
-	s := rpc.NewServer(l, m)
+	s := rpc.NewServer(l, m, o...)
 	go s.Serve()
+	go func() {
+		<-done
+		cancel()
+		s.Stop()
+	}()
 	return grpc.Dial(listen, grpc.WithInsecure())
 }
 
+func TestReplay(t *testing.T) {
+	qc, shutdown, err := embedded.Start()
+	if err != nil {
+		t.Fatalf("embedded.Start(): %v", err)
+	}
+	defer shutdown()
+	c := &itClient{qc}
+
+	nq := &proto.NewQueueRequest{Store: proto.MEMORY_LOG, Limit: Unbounded, Retention: ptypes.DurationProto(time.Hour)}
+	nrsp, err := c.c.NewQueue(ctx, nq)
+	if err != nil {
+		t.Fatalf("c.c.NewQueue(%v): %v", nq, err)
+	}
+	id := nrsp.GetQueue().GetMeta().GetId()
+	if _, err := c.c.NewGroup(ctx, &proto.NewGroupRequest{QueueId: id, Group: "houston"}); err != nil {
+		t.Fatalf("c.c.NewGroup(%v, houston): %v", id, err)
+	}
+	since, err := ptypes.TimestampProto(time.Now())
+	if err != nil {
+		t.Fatalf("ptypes.TimestampProto(): %v", err)
+	}
+	for _, p := range []string{"skylab 2", "skylab 3"} {
+		if err := c.newMessage(id, []byte(p)); err != nil {
+			t.Fatalf("c.newMessage(%v, %v): %v", id, p, err)
+		}
+	}
+	rd := &proto.ReadRequest{QueueId: id, Group: "houston"}
+	rsp, err := c.c.Read(ctx, rd)
+	if err != nil {
+		t.Fatalf("c.c.Read(%v): %v", rd, err)
+	}
+	cm := &proto.CommitRequest{QueueId: id, Group: "houston", Offset: rsp.GetNextOffset()}
+	if _, err := c.c.Commit(ctx, cm); err != nil {
+		t.Fatalf("c.c.Commit(%v): %v", cm, err)
+	}
+
+	sk := &proto.SeekRequest{QueueId: id, Group: "houston", Time: since}
+	srsp, err := c.c.Seek(ctx, sk)
+	if err != nil {
+		t.Fatalf("c.c.Seek(%v): %v", sk, err)
+	}
+	if srsp.GetGroup().GetLag() != 2 {
+		t.Errorf("c.c.Seek(%v): want lag 2, got %v", sk, srsp.GetGroup().GetLag())
+	}
+	rsp, err = c.c.Read(ctx, rd)
+	if err != nil {
+		t.Fatalf("c.c.Read(%v): %v", rd, err)
+	}
+	if len(rsp.GetMessages()) != 2 || string(rsp.GetMessages()[0].GetPayload()) != "skylab 2" {
+		t.Errorf("c.c.Read(%v): want skylab 2 and skylab 3 replayed, got %v", rd, rsp.GetMessages())
+	}
+
+	fifo, err := c.newQueue(Unbounded, proto.MEMORY)
+	if err != nil {
+		t.Fatalf("c.newQueue(%v, %v): %v", Unbounded, proto.MEMORY, err)
+	}
+	sk = &proto.SeekRequest{QueueId: fifo, Time: since}
+	_, err = c.c.Seek(ctx, sk)
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.InvalidArgument {
+		t.Errorf("c.c.Seek(%v): want %v, got %v", sk, codes.InvalidArgument, err)
+	}
+}
+
+func TestRequestReply(t *testing.T) {
+	qc, shutdown, err := embedded.Start()
+	if err != nil {
+		t.Fatalf("embedded.Start(): %v", err)
+	}
+	defer shutdown()
+	c := &itClient{qc}
+
+	id, err := c.newQueue(Unbounded, proto.MEMORY)
+	if err != nil {
+		t.Fatalf("c.newQueue(%v, %v): %v", Unbounded, proto.MEMORY, err)
+	}
+
+	replied := make(chan error, 1)
+	go func() {
+		for {
+			rsp, err := c.c.Pop(ctx, &proto.PopRequest{QueueId: id})
+			if s, ok := status.FromError(err); ok && s.Code() == codes.NotFound {
+				time.Sleep(10 * time.Millisecond)
+				continue
+			}
+			if err != nil {
+				replied <- err
+				return
+			}
+			m := rsp.GetMessage()
+			req := &proto.AddRequest{
+				QueueId: m.GetReplyTo(),
+				Message: &proto.NewMessage{Payload: []byte("roger"), CorrelationId: m.GetCorrelationId()},
+			}
+			_, err = c.c.Add(ctx, req)
+			replied <- err
+			return
+		}
+	}()
+
+	tctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	req := &proto.RequestRequest{QueueId: id, Message: &proto.NewMessage{Payload: []byte("houston?")}}
+	rsp, err := c.c.Request(tctx, req)
+	if err != nil {
+		t.Fatalf("c.c.Request(%v): %v", req, err)
+	}
+	if err := <-replied; err != nil {
+		t.Fatalf("reply: %v", err)
+	}
+	if string(rsp.GetReply().GetPayload()) != "roger" || rsp.GetReply().GetCorrelationId() == "" {
+		t.Errorf("c.c.Request(%v): want correlated reply roger, got %v", req, rsp.GetReply())
+	}
+
+	// The temporary reply queue is deleted once the request completes.
+	if _, err := c.c.GetQueue(ctx, &proto.GetQueueRequest{QueueId: rsp.GetReply().GetMeta().GetId()}); err == nil {
+		t.Errorf("c.c.GetQueue(%v): want error after temporary reply queue was deleted", rsp.GetReply().GetMeta().GetId())
+	}
+	l, err := c.c.ListQueues(ctx, &proto.ListQueuesRequest{})
+	if err != nil {
+		t.Fatalf("c.c.ListQueues(): %v", err)
+	}
+	if len(l.GetQueues()) != 1 {
+		t.Errorf("c.c.ListQueues(): want only the request queue after the temporary reply queue was deleted, got %v", l.GetQueues())
+	}
+
+	tctx, cancel = context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	_, err = c.c.Request(tctx, req)
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.DeadlineExceeded {
+		t.Errorf("c.c.Request(%v): want %v when no reply arrives, got %v", req, codes.DeadlineExceeded, err)
+	}
+}
+
+// awaitDeleted returns an error unless the queue with the supplied ID is
+// deleted before the supplied timeout.
+func awaitDeleted(c *itClient, id string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		rsp, err := c.c.ListQueues(ctx, &proto.ListQueuesRequest{})
+		if err != nil {
+			return err
+		}
+		found := false
+		for _, queue := range rsp.GetQueues() {
+			found = found || queue.GetMeta().GetId() == id
+		}
+		if !found {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("queue %s was not deleted after %s", id, timeout)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestTLS(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "qtesttls")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir(): %v", err)
+	}
+	defer os.RemoveAll(tmp)
+	ca, err := fixtures.NewCA(tmp, "ca")
+	if err != nil {
+		t.Fatalf("fixtures.NewCA(): %v", err)
+	}
+	server, err := ca.Issue(tmp, "server")
+	if err != nil {
+		t.Fatalf("ca.Issue(): %v", err)
+	}
+	client, err := ca.Issue(tmp, "client")
+	if err != nil {
+		t.Fatalf("ca.Issue(): %v", err)
+	}
+
+	sc, err := certs.Server(server.CertFile, server.KeyFile, ca.CertFile)
+	if err != nil {
+		t.Fatalf("certs.Server(): %v", err)
+	}
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("net.Listen(): %v", err)
+	}
+	s := rpc.NewServer(l, manager.New(), rpc.WithTLS(sc))
+	go s.Serve()
+	defer s.Stop()
+
+	cc, err := certs.Client(ca.CertFile, client.CertFile, client.KeyFile, "")
+	if err != nil {
+		t.Fatalf("certs.Client(): %v", err)
+	}
+	conn, err := grpc.Dial(l.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(cc)))
+	if err != nil {
+		t.Fatalf("grpc.Dial(%v): %v", l.Addr(), err)
+	}
+	defer conn.Close()
+	c := &itClient{proto.NewQClient(conn)}
+	if _, err := c.newQueue(Unbounded, proto.MEMORY); err != nil {
+		t.Errorf("c.newQueue(%v, %v): %v", Unbounded, proto.MEMORY, err)
+	}
+
+	// Clients must present a certificate signed by the client CA.
+	cc, err = certs.Client(ca.CertFile, "", "", "")
+	if err != nil {
+		t.Fatalf("certs.Client(): %v", err)
+	}
+	anonymous, err := grpc.Dial(l.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(cc)))
+	if err != nil {
+		t.Fatalf("grpc.Dial(%v): %v", l.Addr(), err)
+	}
+	defer anonymous.Close()
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	req := &proto.NewQueueRequest{Store: proto.MEMORY, Limit: Unbounded}
+	if _, err := proto.NewQClient(anonymous).NewQueue(ctx, req); err == nil {
+		t.Errorf("NewQueue(%v): want error for client without a certificate", req)
+	}
+}
+
+func TestTokenAuthorization(t *testing.T) {
+	f, err := ioutil.TempFile("", "qtesttokens")
+	if err != nil {
+		t.Fatalf("ioutil.TempFile(): %v", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString("# Principal and token.\nops ops-token\nbilling billing-token\n"); err != nil {
+		t.Fatalf("f.WriteString(): %v", err)
+	}
+	f.Close()
+	tokens, err := rpc.LoadTokens(f.Name())
+	if err != nil {
+		t.Fatalf("rpc.LoadTokens(%v): %v", f.Name(), err)
+	}
+	policy := rpc.Policy{
+		{Principal: "ops", Permissions: []rpc.Permission{rpc.Admin}},
+		{Principal: "billing", Permissions: []rpc.Permission{rpc.Produce, rpc.Consume}, Queues: []q.Tag{{Key: "team", Value: "billing"}}},
+		{Principal: "*", Permissions: []rpc.Permission{rpc.Read}, Queues: []q.Tag{{Key: "public", Value: "true"}}},
+	}
+
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("net.Listen(): %v", err)
+	}
+	s := rpc.NewServer(l, manager.New(), rpc.WithAuthentication(tokens), rpc.WithAuthorization(policy))
+	go s.Serve()
+	defer s.Stop()
+
+	conns := []*grpc.ClientConn{}
+	defer func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}()
+	as := func(token string) *itClient {
+		o := []grpc.DialOption{grpc.WithInsecure()}
+		if token != "" {
+			o = append(o, grpc.WithPerRPCCredentials(client.BearerToken(token)))
+		}
+		conn, err := grpc.Dial(l.Addr().String(), o...)
+		if err != nil {
+			t.Fatalf("grpc.Dial(%v): %v", l.Addr(), err)
+		}
+		conns = append(conns, conn)
+		return &itClient{proto.NewQClient(conn)}
+	}
+	ops, billing, anonymous, intruder := as("ops-token"), as("billing-token"), as(""), as("guess")
+
+	want := func(c codes.Code, err error, call string) {
+		if s, ok := status.FromError(err); !ok || s.Code() != c {
+			t.Errorf("%s: want %v, got %v", call, c, err)
+		}
+	}
+
+	_, err = anonymous.c.ListQueues(ctx, &proto.ListQueuesRequest{})
+	want(codes.Unauthenticated, err, "anonymous ListQueues")
+	_, err = intruder.c.ListQueues(ctx, &proto.ListQueuesRequest{})
+	want(codes.Unauthenticated, err, "intruder ListQueues")
+
+	team := &proto.Tag{Key: "team", Value: "billing"}
+	id, err := ops.newQueue(Unbounded, proto.MEMORY, team)
+	if err != nil {
+		t.Fatalf("ops.newQueue(): %v", err)
+	}
+	if err := billing.newMessage(id, []byte("invoice")); err != nil {
+		t.Errorf("billing.newMessage(%v): %v", id, err)
+	}
+	if _, err := billing.popMessage(id); err != nil {
+		t.Errorf("billing.popMessage(%v): %v", id, err)
+	}
+	_, err = billing.newQueue(Unbounded, proto.MEMORY, team)
+	want(codes.PermissionDenied, err, "billing newQueue")
+	want(codes.PermissionDenied, billing.deleteQueue(id), "billing deleteQueue")
+	_, err = billing.c.ListQueues(ctx, &proto.ListQueuesRequest{})
+	want(codes.PermissionDenied, err, "billing ListQueues")
+
+	public, err := ops.newQueue(Unbounded, proto.MEMORY, &proto.Tag{Key: "public", Value: "true"})
+	if err != nil {
+		t.Fatalf("ops.newQueue(): %v", err)
+	}
+	want(codes.PermissionDenied, billing.newMessage(public, []byte("invoice")), "billing newMessage")
+	_, err = billing.peekMessage(public)
+	want(codes.NotFound, err, "billing peekMessage")
+
+	// Queues that do not exist may only be operated on by principals with
+	// permissions on every queue, so that their existence is not revealed.
+	missing := uuid.New().String()
+	_, err = billing.popMessage(missing)
+	want(codes.PermissionDenied, err, "billing popMessage")
+	_, err = ops.popMessage(missing)
+	want(codes.NotFound, err, "ops popMessage")
+
+	// Requests consume replies from the reply queue their message names.
+	replies, err := ops.newQueue(Unbounded, proto.MEMORY, team)
+	if err != nil {
+		t.Fatalf("ops.newQueue(): %v", err)
+	}
+	reply := &proto.AddRequest{QueueId: replies, Message: &proto.NewMessage{Payload: []byte("paid"), CorrelationId: "invoice"}}
+	if _, err := billing.c.Add(ctx, reply); err != nil {
+		t.Fatalf("billing.c.Add(%v): %v", reply, err)
+	}
+	req := &proto.RequestRequest{QueueId: id, Message: &proto.NewMessage{Payload: []byte("invoice"), CorrelationId: "invoice", ReplyTo: public}}
+	_, err = billing.c.Request(ctx, req)
+	want(codes.PermissionDenied, err, "billing Request replying to public queue")
+	req.Message.ReplyTo = replies
+	if _, err := billing.c.Request(ctx, req); err != nil {
+		t.Errorf("billing.c.Request(%v): %v", req, err)
+	}
+	if _, err := billing.popMessage(id); err != nil {
+		t.Errorf("billing.popMessage(%v): %v", id, err)
+	}
+
+	if err := ops.deleteQueue(id); err != nil {
+		t.Errorf("ops.deleteQueue(%v): %v", id, err)
+	}
+}
+
+func TestClientCertificateAuthorization(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "qtestauth")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir(): %v", err)
+	}
+	defer os.RemoveAll(tmp)
+	ca, err := fixtures.NewCA(tmp, "ca")
+	if err != nil {
+		t.Fatalf("fixtures.NewCA(): %v", err)
+	}
+	server, err := ca.Issue(tmp, "server")
+	if err != nil {
+		t.Fatalf("ca.Issue(): %v", err)
+	}
+
+	sc, err := certs.Server(server.CertFile, server.KeyFile, ca.CertFile)
+	if err != nil {
+		t.Fatalf("certs.Server(): %v", err)
+	}
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("net.Listen(): %v", err)
+	}
+	policy := rpc.Policy{{Principal: "ops", Permissions: []rpc.Permission{rpc.Admin}}}
+	s := rpc.NewServer(l, manager.New(), rpc.WithTLS(sc), rpc.WithAuthentication(rpc.ClientCertificates()), rpc.WithAuthorization(policy))
+	go s.Serve()
+	defer s.Stop()
+
+	conns := []*grpc.ClientConn{}
+	defer func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}()
+	as := func(principal string) *itClient {
+		cert, err := ca.Issue(tmp, principal)
+		if err != nil {
+			t.Fatalf("ca.Issue(): %v", err)
+		}
+		cc, err := certs.Client(ca.CertFile, cert.CertFile, cert.KeyFile, "")
+		if err != nil {
+			t.Fatalf("certs.Client(): %v", err)
+		}
+		conn, err := grpc.Dial(l.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(cc)))
+		if err != nil {
+			t.Fatalf("grpc.Dial(%v): %v", l.Addr(), err)
+		}
+		conns = append(conns, conn)
+		return &itClient{proto.NewQClient(conn)}
+	}
+
+	if _, err := as("ops").newQueue(Unbounded, proto.MEMORY); err != nil {
+		t.Errorf("ops.newQueue(): %v", err)
+	}
+	_, err = as("dev").newQueue(Unbounded, proto.MEMORY)
+	if s, ok := status.FromError(err); !ok || s.Code() != codes.PermissionDenied {
+		t.Errorf("dev.newQueue(): want %v, got %v", codes.PermissionDenied, err)
+	}
+}
+
+func TestTemporaryQueues(t *testing.T) {
+	// Temporary queues are tied to the connection that created them, so this
+	// test serves over TCP in order to connect more than once.
+	listen, err := localhostWithRandomPort()
+	if err != nil {
+		t.Fatal("Cannot find available port to listen on.")
+	}
+	done := make(chan struct{})
+	defer close(done)
+	conn, err := newServer(done, listen, rpc.WithTemporaryIdleTimeout(200*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Cannot create new server: %v", err)
+	}
+	defer conn.Close()
+	c := &itClient{proto.NewQClient(conn)}
+
+	other, err := grpc.Dial(listen, grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("grpc.Dial(%v): %v", listen, err)
+	}
+	req := &proto.NewQueueRequest{Store: proto.MEMORY, Limit: Unbounded, Temporary: true}
+	rsp, err := proto.NewQClient(other).NewQueue(ctx, req)
+	if err != nil {
+		t.Fatalf("NewQueue(%v): %v", req, err)
+	}
+	other.Close()
+	if err := awaitDeleted(c, rsp.GetQueue().GetMeta().GetId(), 5*time.Second); err != nil {
+		t.Errorf("after closing connection: %v", err)
+	}
+
+	rsp, err = c.c.NewQueue(ctx, req)
+	if err != nil {
+		t.Fatalf("c.c.NewQueue(%v): %v", req, err)
+	}
+	if err := awaitDeleted(c, rsp.GetQueue().GetMeta().GetId(), 5*time.Second); err != nil {
+		t.Errorf("after idle timeout: %v", err)
+	}
+
+	id, err := c.newQueue(Unbounded, proto.MEMORY)
+	if err != nil {
+		t.Fatalf("c.newQueue(%v, %v): %v", Unbounded, proto.MEMORY, err)
+	}
+	time.Sleep(500 * time.Millisecond)
+	if _, err := c.c.GetQueue(ctx, &proto.GetQueueRequest{QueueId: id}); err != nil {
+		t.Errorf("c.c.GetQueue(%v): want persistent queue to survive idle timeout, got %v", id, err)
+	}
+}
+
 type itClient struct {
 	c proto.QClient
 }
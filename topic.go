@@ -0,0 +1,70 @@
+package q
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+
+	"github.com/negz/q/e"
+)
+
+// A Subscription routes messages published to a topic to a queue.
+type Subscription struct {
+	Queue  uuid.UUID // Queue is the ID of the subscribed queue.
+	Filter []Tag     // Only messages tagged with all of these tags are routed to the queue.
+}
+
+// A Topic fans out the messages published to it to its subscribed queues.
+type Topic interface {
+	ID() uuid.UUID      // ID is the globally unique identifier for this topic.
+	Created() time.Time // Created is the creation time of this topic.
+	Tags() *Tags        // Tags are arbitrary key:value pairs associated with this topic.
+
+	// Subscribe routes messages published to this topic to a queue. Any
+	// existing subscription for the same queue is replaced.
+	Subscribe(s Subscription) error
+
+	// Unsubscribe stops routing messages published to this topic to a queue.
+	Unsubscribe(queue uuid.UUID) error
+
+	// Subscriptions returns this topic's subscriptions, ordered by the time
+	// they were first made.
+	Subscriptions() []Subscription
+}
+
+// Publish adds a copy of the supplied message to every queue subscribed to the
+// supplied topic whose filter the message matches. Each copy has its own ID and
+// tags, and is added to its queue via the queue's AddContext method. Publish
+// attempts to add the message to every matching queue, returning the number of
+// queues it was added to and the first error it encountered, if any. Queues
+// that no longer exist are skipped.
+func Publish(ctx context.Context, m Manager, topic uuid.UUID, msg *Message) (int, error) {
+	t, err := m.GetTopic(topic)
+	if err != nil {
+		return 0, errors.Wrapf(err, "cannot get topic %s", topic)
+	}
+	n := 0
+	var first error
+	for _, s := range t.Subscriptions() {
+		if !msg.Tags.ContainsAll(s.Filter...) {
+			continue
+		}
+		queue, err := m.Get(s.Queue)
+		if e.IsNotFound(err) {
+			continue
+		}
+		if err == nil {
+			err = queue.AddContext(ctx, msg.Copy())
+		}
+		if err != nil {
+			if first == nil {
+				first = errors.Wrapf(err, "cannot publish message to queue %s", s.Queue)
+			}
+			continue
+		}
+		n++
+	}
+	return n, first
+}
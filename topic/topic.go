@@ -0,0 +1,89 @@
+// Package topic provides an in-memory topic that fans out messages to its
+// subscribed queues.
+package topic
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+)
+
+type topic struct {
+	meta *q.Metadata
+	subs []q.Subscription
+	m    *sync.RWMutex
+}
+
+// An Option represents an optional argument to a new topic.
+type Option func(*topic)
+
+// Tagged applies the provided tags to a new topic.
+func Tagged(t ...q.Tag) Option {
+	return func(tp *topic) {
+		for _, tag := range t {
+			tp.meta.Tags.AddTag(tag)
+		}
+	}
+}
+
+// New returns a new in-memory topic with no subscriptions.
+func New(o ...Option) q.Topic {
+	meta := &q.Metadata{ID: uuid.New(), Created: time.Now(), Tags: &q.Tags{}}
+	t := &topic{meta: meta, m: &sync.RWMutex{}}
+	for _, opt := range o {
+		opt(t)
+	}
+	return t
+}
+
+func (t *topic) ID() uuid.UUID {
+	return t.meta.ID
+}
+
+func (t *topic) Created() time.Time {
+	return t.meta.Created
+}
+
+func (t *topic) Tags() *q.Tags {
+	return t.meta.Tags
+}
+
+// Subscribe replaces an existing subscription in place, so that it keeps its
+// position.
+func (t *topic) Subscribe(s q.Subscription) error {
+	t.m.Lock()
+	defer t.m.Unlock()
+	for i := range t.subs {
+		if t.subs[i].Queue == s.Queue {
+			t.subs[i] = s
+			return nil
+		}
+	}
+	t.subs = append(t.subs, s)
+	return nil
+}
+
+func (t *topic) Unsubscribe(queue uuid.UUID) error {
+	t.m.Lock()
+	defer t.m.Unlock()
+	for i := range t.subs {
+		if t.subs[i].Queue == queue {
+			t.subs = append(t.subs[:i], t.subs[i+1:]...)
+			return nil
+		}
+	}
+	return e.WithReason(e.ErrNotFound(errors.Errorf("queue %s is not subscribed to topic %s", queue, t.ID())), e.SUBSCRIPTION_NOT_FOUND)
+}
+
+func (t *topic) Subscriptions() []q.Subscription {
+	t.m.RLock()
+	defer t.m.RUnlock()
+	s := make([]q.Subscription, len(t.subs))
+	copy(s, t.subs)
+	return s
+}
@@ -0,0 +1,52 @@
+package topic
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+)
+
+func TestTopic(t *testing.T) {
+	tp := New(Tagged(q.Tag{Key: "program", Value: "mercury"}))
+	if !tp.Tags().Contains("program", "mercury") {
+		t.Errorf("tp.Tags(): want tag program:mercury, got %v", tp.Tags())
+	}
+
+	a, b := uuid.New(), uuid.New()
+	subs := []q.Subscription{{Queue: a}, {Queue: b, Filter: []q.Tag{{Key: "capsule", Value: "friendship 7"}}}}
+	for _, s := range subs {
+		if err := tp.Subscribe(s); err != nil {
+			t.Fatalf("tp.Subscribe(%v): %v", s, err)
+		}
+	}
+	if got := tp.Subscriptions(); !reflect.DeepEqual(subs, got) {
+		t.Errorf("tp.Subscriptions():\nwant %v\ngot %v", subs, got)
+	}
+
+	t.Run("Replace", func(t *testing.T) {
+		replaced := q.Subscription{Queue: a, Filter: []q.Tag{{Key: "capsule", Value: "aurora 7"}}}
+		if err := tp.Subscribe(replaced); err != nil {
+			t.Fatalf("tp.Subscribe(%v): %v", replaced, err)
+		}
+		want := []q.Subscription{replaced, subs[1]}
+		if got := tp.Subscriptions(); !reflect.DeepEqual(want, got) {
+			t.Errorf("tp.Subscriptions():\nwant %v\ngot %v", want, got)
+		}
+	})
+
+	t.Run("Unsubscribe", func(t *testing.T) {
+		if err := tp.Unsubscribe(b); err != nil {
+			t.Fatalf("tp.Unsubscribe(%v): %v", b, err)
+		}
+		if got := tp.Subscriptions(); len(got) != 1 || got[0].Queue != a {
+			t.Errorf("tp.Subscriptions(): want only %v, got %v", a, got)
+		}
+		if err := tp.Unsubscribe(b); !e.IsNotFound(err) {
+			t.Errorf("tp.Unsubscribe(%v): want error satisfying e.IsNotFound(), got %v", b, err)
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package q_test
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+	"github.com/negz/q/manager"
+	"github.com/negz/q/memory"
+	"github.com/negz/q/topic"
+)
+
+func TestPublish(t *testing.T) {
+	usa := q.Tag{Key: "country", Value: "USA"}
+	m := manager.New()
+	all, american, full, deleted := memory.New(), memory.New(), memory.New(memory.Limit(1)), memory.New()
+	for _, queue := range []q.Queue{all, american, full, deleted} {
+		if err := m.Add(queue); err != nil {
+			t.Fatalf("m.Add(%v): %v", queue.ID(), err)
+		}
+	}
+	if err := full.Add(q.NewMessage([]byte("luna"))); err != nil {
+		t.Fatalf("full.Add(): %v", err)
+	}
+
+	tp := topic.New()
+	if err := m.AddTopic(tp); err != nil {
+		t.Fatalf("m.AddTopic(%v): %v", tp.ID(), err)
+	}
+	for _, s := range []q.Subscription{{Queue: all.ID()}, {Queue: american.ID(), Filter: []q.Tag{usa}}, {Queue: deleted.ID()}} {
+		if err := tp.Subscribe(s); err != nil {
+			t.Fatalf("tp.Subscribe(%v): %v", s, err)
+		}
+	}
+	// Deleting a queue from the manager should unsubscribe it from the topic.
+	if err := m.Delete(deleted.ID(), false); err != nil {
+		t.Fatalf("m.Delete(%v): %v", deleted.ID(), err)
+	}
+	if got := len(tp.Subscriptions()); got != 2 {
+		t.Errorf("tp.Subscriptions(): want 2 subscriptions after deleting queue, got %v", got)
+	}
+
+	for _, msg := range []*q.Message{q.NewMessage([]byte("sputnik")), q.NewMessage([]byte("explorer"), q.Tagged(usa))} {
+		if _, err := q.Publish(context.Background(), m, tp.ID(), msg); err != nil {
+			t.Fatalf("q.Publish(%s): %v", msg.Payload, err)
+		}
+	}
+	if got, want := payloads(t, all), []string{"sputnik", "explorer"}; !equal(want, got) {
+		t.Errorf("all: want %v, got %v", want, got)
+	}
+	if got, want := payloads(t, american), []string{"explorer"}; !equal(want, got) {
+		t.Errorf("american: want %v, got %v", want, got)
+	}
+
+	t.Run("QueueFull", func(t *testing.T) {
+		if err := tp.Subscribe(q.Subscription{Queue: full.ID()}); err != nil {
+			t.Fatalf("tp.Subscribe(%v): %v", full.ID(), err)
+		}
+		n, err := q.Publish(context.Background(), m, tp.ID(), q.NewMessage([]byte("vostok")))
+		if !e.IsFull(err) {
+			t.Errorf("q.Publish(): want error satisfying e.IsFull(), got %v", err)
+		}
+		if n != 1 {
+			t.Errorf("q.Publish(): want 1 delivered, got %v", n)
+		}
+	})
+
+	t.Run("TopicNotFound", func(t *testing.T) {
+		if err := m.DeleteTopic(tp.ID()); err != nil {
+			t.Fatalf("m.DeleteTopic(%v): %v", tp.ID(), err)
+		}
+		if _, err := q.Publish(context.Background(), m, tp.ID(), q.NewMessage([]byte("mir"))); !e.IsNotFound(err) {
+			t.Errorf("q.Publish(): want error satisfying e.IsNotFound(), got %v", err)
+		}
+	})
+}
+
+func TestPublishCopies(t *testing.T) {
+	m := manager.New()
+	a, b := memory.New(), memory.New()
+	tp := topic.New()
+	if err := m.AddTopic(tp); err != nil {
+		t.Fatalf("m.AddTopic(%v): %v", tp.ID(), err)
+	}
+	for _, queue := range []q.Queue{a, b} {
+		if err := m.Add(queue); err != nil {
+			t.Fatalf("m.Add(%v): %v", queue.ID(), err)
+		}
+		if err := tp.Subscribe(q.Subscription{Queue: queue.ID()}); err != nil {
+			t.Fatalf("tp.Subscribe(%v): %v", queue.ID(), err)
+		}
+	}
+
+	msg := q.NewMessage([]byte("sputnik"), q.Tagged(q.Tag{Key: "country", Value: "USSR"}))
+	if _, err := q.Publish(context.Background(), m, tp.ID(), msg); err != nil {
+		t.Fatalf("q.Publish(%s): %v", msg.Payload, err)
+	}
+	ma, err := a.Peek()
+	if err != nil {
+		t.Fatalf("a.Peek(): %v", err)
+	}
+	ma.Tags.Add("country", "RU")
+	ma.Payload[0] = 'S'
+
+	mb, err := b.Peek()
+	if err != nil {
+		t.Fatalf("b.Peek(): %v", err)
+	}
+	if ma.ID == mb.ID {
+		t.Errorf("b.Peek(): want ID other than %v", ma.ID)
+	}
+	if mb.Tags.Contains("country", "RU") {
+		t.Errorf("b.Peek(): want tags %v, got %v", msg.Tags.Get(), mb.Tags.Get())
+	}
+	if string(mb.Payload) != "sputnik" {
+		t.Errorf("b.Peek(): want payload sputnik, got %s", mb.Payload)
+	}
+}
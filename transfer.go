@@ -0,0 +1,96 @@
+package q
+
+import (
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+
+	"github.com/negz/q/e"
+)
+
+// A Wrapper is a queue that wraps another queue, for example to add logging or
+// metrics.
+type Wrapper interface {
+	Unwrap() Queue // Unwrap returns the wrapped queue.
+}
+
+// Unwrap returns the innermost queue wrapped by the supplied queue.
+func Unwrap(queue Queue) Queue {
+	for {
+		w, ok := queue.(Wrapper)
+		if !ok {
+			return queue
+		}
+		queue = w.Unwrap()
+	}
+}
+
+// A Transferer is a queue that can atomically transfer messages to some other
+// queues, for example those stored in the same database.
+type Transferer interface {
+	// CanTransfer returns true if messages can be atomically transferred to
+	// the supplied queue.
+	CanTransfer(dst Queue) bool
+
+	// Transfer adds up to max messages tagged with all of the supplied tags to
+	// dst, removing them from this queue if move is true. Each message is
+	// transferred atomically. Transfer returns the messages that were
+	// transferred, even if it returns an error. It must only be called if
+	// CanTransfer returns true for dst.
+	Transfer(ctx context.Context, dst Queue, max int, move bool, t ...Tag) ([]*Message, error)
+}
+
+// Transfer adds up to max messages tagged with all of the supplied tags from
+// src to dst, removing them from src if move is true. max may be Unbounded.
+// Messages are transferred in queue order. Moved messages keep their ID, while
+// each copy is a new message with a new ID. Transfer returns
+// the number of messages transferred, even if it returns an error.
+//
+// Messages are transferred atomically if src is a Transferer that can transfer
+// to dst. Otherwise each message is added to dst before it is deleted from src,
+// and any message that is consumed from src while being moved is deleted from
+// dst so that it is not delivered twice.
+func Transfer(ctx context.Context, src, dst Queue, max int, move bool, t ...Tag) (int, error) {
+	if src.ID() == dst.ID() {
+		return 0, e.ErrInvalid(errors.Errorf("cannot transfer messages from queue %s to itself", src.ID()))
+	}
+	if tr, ok := src.(Transferer); ok && tr.CanTransfer(dst) {
+		transferred, err := tr.Transfer(ctx, dst, max, move, t...)
+		return len(transferred), err
+	}
+
+	// Walk must not call the queue's other methods, so we collect the
+	// messages to transfer before adding them to dst.
+	candidates := []*Message{}
+	if err := src.Walk(0, func(_ uint64, m *Message) bool {
+		if m.Tags.ContainsAll(t...) {
+			candidates = append(candidates, m)
+		}
+		return max == Unbounded || len(candidates) < max
+	}); err != nil {
+		return 0, errors.Wrap(err, "cannot find messages to transfer")
+	}
+
+	n := 0
+	for _, m := range candidates {
+		if !move {
+			m = m.Copy()
+		}
+		if err := dst.AddContext(ctx, m); err != nil {
+			return n, errors.Wrapf(err, "cannot add message %s to destination queue", m.ID)
+		}
+		if move {
+			if err := src.Delete(m.ID); err != nil {
+				if derr := dst.Delete(m.ID); derr != nil {
+					return n, errors.Wrapf(derr, "cannot remove message %s from destination queue", m.ID)
+				}
+				if e.IsNotFound(err) {
+					// Someone consumed this message while we were moving it.
+					continue
+				}
+				return n, errors.Wrapf(err, "cannot remove message %s from source queue", m.ID)
+			}
+		}
+		n++
+	}
+	return n, nil
+}
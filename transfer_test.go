@@ -0,0 +1,147 @@
+package q_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"golang.org/x/net/context"
+
+	"github.com/negz/q"
+	"github.com/negz/q/e"
+	"github.com/negz/q/memory"
+)
+
+func payloads(t *testing.T, queue q.Queue) []string {
+	p := []string{}
+	if err := queue.Walk(0, func(_ uint64, m *q.Message) bool {
+		p = append(p, string(m.Payload))
+		return true
+	}); err != nil {
+		t.Fatalf("queue.Walk(): %v", err)
+	}
+	return p
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestTransfer(t *testing.T) {
+	usa := q.Tag{Key: "country", Value: "USA"}
+	messages := []*q.Message{
+		q.NewMessage([]byte("explorer"), q.Tagged(usa)),
+		q.NewMessage([]byte("sputnik")),
+		q.NewMessage([]byte("vanguard"), q.Tagged(usa)),
+		q.NewMessage([]byte("pioneer"), q.Tagged(usa)),
+	}
+	newSource := func() q.Queue {
+		src := memory.New()
+		for _, m := range messages {
+			if err := src.Add(m); err != nil {
+				t.Fatalf("src.Add(%v): %v", m, err)
+			}
+		}
+		return src
+	}
+
+	cases := []struct {
+		name    string
+		max     int
+		move    bool
+		tags    []q.Tag
+		wantSrc []string
+		wantDst []string
+	}{
+		{
+			name:    "MoveAll",
+			max:     q.Unbounded,
+			move:    true,
+			wantSrc: []string{},
+			wantDst: []string{"explorer", "sputnik", "vanguard", "pioneer"},
+		},
+		{
+			name:    "MoveMatching",
+			max:     2,
+			move:    true,
+			tags:    []q.Tag{usa},
+			wantSrc: []string{"sputnik", "pioneer"},
+			wantDst: []string{"explorer", "vanguard"},
+		},
+		{
+			name:    "Copy",
+			max:     3,
+			wantSrc: []string{"explorer", "sputnik", "vanguard", "pioneer"},
+			wantDst: []string{"explorer", "sputnik", "vanguard"},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			src, dst := newSource(), memory.New()
+			n, err := q.Transfer(context.Background(), src, dst, tt.max, tt.move, tt.tags...)
+			if err != nil {
+				t.Fatalf("q.Transfer(): %v", err)
+			}
+			if n != len(tt.wantDst) {
+				t.Errorf("q.Transfer(): want %v transferred, got %v", len(tt.wantDst), n)
+			}
+			if got := payloads(t, src); !equal(tt.wantSrc, got) {
+				t.Errorf("src: want %v, got %v", tt.wantSrc, got)
+			}
+			if got := payloads(t, dst); !equal(tt.wantDst, got) {
+				t.Errorf("dst: want %v, got %v", tt.wantDst, got)
+			}
+		})
+	}
+
+	t.Run("DestinationFull", func(t *testing.T) {
+		src, dst := newSource(), memory.New(memory.Limit(1))
+		n, err := q.Transfer(context.Background(), src, dst, q.Unbounded, true)
+		if !e.IsFull(err) {
+			t.Errorf("q.Transfer(): want error satisfying e.IsFull(), got %v", err)
+		}
+		if n != 1 {
+			t.Errorf("q.Transfer(): want 1 transferred, got %v", n)
+		}
+		if got, want := payloads(t, src), []string{"sputnik", "vanguard", "pioneer"}; !equal(want, got) {
+			t.Errorf("src: want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("Self", func(t *testing.T) {
+		src := newSource()
+		if _, err := q.Transfer(context.Background(), src, src, q.Unbounded, true); !e.IsInvalid(err) {
+			t.Errorf("q.Transfer(): want error satisfying e.IsInvalid(), got %v", err)
+		}
+	})
+
+	t.Run("CopyTwice", func(t *testing.T) {
+		src, dst := newSource(), memory.New()
+		for i := 0; i < 2; i++ {
+			if _, err := q.Transfer(context.Background(), src, dst, 1, false); err != nil {
+				t.Fatalf("q.Transfer(): %v", err)
+			}
+		}
+		ids := map[uuid.UUID]bool{messages[0].ID: true}
+		if err := dst.Walk(0, func(_ uint64, m *q.Message) bool {
+			if ids[m.ID] {
+				t.Errorf("dst.Walk(): want copies with new IDs, got message %v again", m.ID)
+			}
+			ids[m.ID] = true
+			m.Tags.Add("copied", "true")
+			return true
+		}); err != nil {
+			t.Fatalf("dst.Walk(): %v", err)
+		}
+		if messages[0].Tags.Contains("copied", "true") {
+			t.Errorf("src: want message unchanged when its copy is changed, got tags %v", messages[0].Tags.Get())
+		}
+	})
+}